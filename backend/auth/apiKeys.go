@@ -0,0 +1,153 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/keep-starknet-strange/art-peace/backend/core"
+)
+
+// Admin API keys, sent in the X-Api-Key header of admin routes
+// Only their sha256 hash is stored, the key itself is shown once when minted
+
+const (
+	RoleSuperAdmin = "super-admin"
+	RoleModerator  = "moderator"
+	// Manages a single world's regions & chat
+	RoleWorldHost = "world-host"
+)
+
+type Permission string
+
+const (
+	// Initializing the backend, contract addresses & raw canvas writes
+	PermissionSetup Permission = "setup"
+	// Clearing & rolling back pixels
+	PermissionCanvas Permission = "canvas"
+	// Removing stencils
+	PermissionStencils Permission = "stencils"
+	// Protected regions & chat moderation of worlds
+	PermissionWorlds Permission = "worlds"
+)
+
+var RolePermissions = map[string][]Permission{
+	RoleSuperAdmin: {PermissionSetup, PermissionCanvas, PermissionStencils, PermissionWorlds},
+	RoleModerator:  {PermissionCanvas, PermissionStencils, PermissionWorlds},
+	RoleWorldHost:  {PermissionWorlds},
+}
+
+const apiKeyPrefix = "apk_"
+
+// Characters of the key kept to recognize it in listings & logs
+const apiKeyVisibleLength = len(apiKeyPrefix) + 8
+
+type ApiKey struct {
+	Key    int    `json:"key"`
+	Name   string `json:"name"`
+	Prefix string `json:"prefix"`
+	Role   string `json:"role"`
+	// World of world-host keys
+	WorldId   *int       `json:"worldId"`
+	CreatedAt time.Time  `json:"createdAt"`
+	RevokedAt *time.Time `json:"revokedAt"`
+}
+
+func (k *ApiKey) Allows(permission Permission) bool {
+	return slices.Contains(RolePermissions[k.Role], permission)
+}
+
+// World-host keys are only allowed on their own world
+func (k *ApiKey) AllowsWorld(permission Permission, worldId int) bool {
+	if !k.Allows(permission) {
+		return false
+	}
+	if k.Role == RoleWorldHost {
+		return k.WorldId != nil && *k.WorldId == worldId
+	}
+	return true
+}
+
+func hashApiKey(secret string) string {
+	hash := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(hash[:])
+}
+
+// Create a key, returning the secret to hand out
+func MintApiKey(name string, role string, worldId *int) (string, *ApiKey, error) {
+	if _, ok := RolePermissions[role]; !ok {
+		return "", nil, fmt.Errorf("unknown role %s", role)
+	}
+	if role == RoleWorldHost && worldId == nil {
+		return "", nil, fmt.Errorf("world-host keys need a world")
+	}
+	if role != RoleWorldHost {
+		worldId = nil
+	}
+
+	secretBytes := make([]byte, 32)
+	if _, err := rand.Read(secretBytes); err != nil {
+		return "", nil, err
+	}
+	secret := apiKeyPrefix + hex.EncodeToString(secretBytes)
+
+	apiKey := &ApiKey{
+		Name:    name,
+		Prefix:  secret[:apiKeyVisibleLength],
+		Role:    role,
+		WorldId: worldId,
+	}
+	err := core.ArtPeaceBackend.Databases.Postgres.QueryRow(context.Background(), "INSERT INTO AdminKeys (name, prefix, key_hash, role, world_id) VALUES ($1, $2, $3, $4, $5) RETURNING key, created_at", name, apiKey.Prefix, hashApiKey(secret), role, worldId).Scan(&apiKey.Key, &apiKey.CreatedAt)
+	if err != nil {
+		return "", nil, err
+	}
+	return secret, apiKey, nil
+}
+
+func RevokeApiKey(key int) error {
+	result, err := core.ArtPeaceBackend.Databases.Postgres.Exec(context.Background(), "UPDATE AdminKeys SET revoked_at = CURRENT_TIMESTAMP WHERE key = $1 AND revoked_at IS NULL", key)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("no active key %d", key)
+	}
+	return nil
+}
+
+func ListApiKeys() ([]ApiKey, error) {
+	return core.PostgresQuery[ApiKey]("SELECT key, name, prefix, role, world_id, created_at, revoked_at FROM AdminKeys ORDER BY key ASC")
+}
+
+// Active key matching the secret, nil if it is unknown or revoked
+func LookupApiKey(secret string) (*ApiKey, error) {
+	if secret == "" {
+		return nil, nil
+	}
+	keys, err := core.PostgresQuery[ApiKey]("SELECT key, name, prefix, role, world_id, created_at, revoked_at FROM AdminKeys WHERE key_hash = $1 AND revoked_at IS NULL", hashApiKey(secret))
+	if err != nil {
+		return nil, err
+	}
+	if len(keys) == 0 {
+		return nil, nil
+	}
+	return &keys[0], nil
+}
+
+// Audit log entry of a request to an admin route, key is nil for requests allowed by admin mode or without a key
+func RecordAdminRequest(key *ApiKey, method string, path string, query string, status int, ip string) {
+	var keyId *int
+	var role *string
+	if key != nil {
+		keyId = &key.Key
+		role = &key.Role
+	}
+	_, err := core.ArtPeaceBackend.Databases.Postgres.Exec(context.Background(), "INSERT INTO AdminAuditLog (admin_key, role, method, path, query, status, ip) VALUES ($1, $2, $3, $4, $5, $6, $7)", keyId, role, method, path, query, status, ip)
+	if err != nil {
+		fmt.Println("Failed to record admin request", method, path, err)
+	}
+}
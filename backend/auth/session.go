@@ -0,0 +1,155 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"time"
+
+	"github.com/NethermindEth/juno/core/felt"
+	"github.com/redis/go-redis/v9"
+
+	"github.com/keep-starknet-strange/art-peace/backend/config"
+	"github.com/keep-starknet-strange/art-peace/backend/core"
+)
+
+var ErrInvalidSignature = errors.New("invalid signature")
+var ErrNonceNotFound = errors.New("nonce expired or not found")
+
+// Addresses are stored as 64 hex characters without 0x
+func FormatAddress(address *felt.Felt) string {
+	return fmt.Sprintf("%064x", address.BigInt(new(big.Int)))
+}
+
+// Address from a request, padded or not, in its stored form
+func NormalizeAddress(address string) (string, error) {
+	parsed, err := ParseFelt(address)
+	if err != nil {
+		return "", err
+	}
+	return FormatAddress(parsed), nil
+}
+
+// Each nonce has its own key, so issuing one doesn't invalidate the ones already signed
+func nonceKey(address string, nonce string) string {
+	return "auth-nonce-" + address + "-" + nonce
+}
+
+// Nonces are 31 bytes, formatted like IssueNonce does
+func formatNonce(nonce *felt.Felt) string {
+	return fmt.Sprintf("0x%062x", nonce.BigInt(new(big.Int)))
+}
+
+// Tokens are only stored hashed
+func sessionKey(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return "auth-session-" + hex.EncodeToString(hash[:])
+}
+
+func chainId() string {
+	if core.ArtPeaceBackend.BackendConfig.Auth.ChainId == "" {
+		return config.DefaultBackendConfig.Auth.ChainId
+	}
+	return core.ArtPeaceBackend.BackendConfig.Auth.ChainId
+}
+
+func nonceTTL() time.Duration {
+	ttl := core.ArtPeaceBackend.BackendConfig.Auth.NonceTTL
+	if ttl <= 0 {
+		ttl = config.DefaultBackendConfig.Auth.NonceTTL
+	}
+	return time.Duration(ttl) * time.Second
+}
+
+func SessionTTL() time.Duration {
+	ttl := core.ArtPeaceBackend.BackendConfig.Auth.SessionTTL
+	if ttl <= 0 {
+		ttl = config.DefaultBackendConfig.Auth.SessionTTL
+	}
+	return time.Duration(ttl) * time.Second
+}
+
+// Typed data for the address to sign, nonces issued before stay valid until they are used or expire
+func IssueNonce(address *felt.Felt) (*LoginTypedData, error) {
+	// 31 bytes always fit in a felt
+	nonceBytes := make([]byte, 31)
+	if _, err := rand.Read(nonceBytes); err != nil {
+		return nil, err
+	}
+	nonce := "0x" + hex.EncodeToString(nonceBytes)
+	issuedAt := time.Now().Unix()
+
+	err := core.ArtPeaceBackend.Databases.Redis.Set(context.Background(), nonceKey(FormatAddress(address), nonce), issuedAt, nonceTTL()).Err()
+	if err != nil {
+		return nil, err
+	}
+	return NewLoginTypedData(chainId(), nonce, issuedAt), nil
+}
+
+// Nonces are single use, a failed login has to ask for a new one
+func consumeNonce(address *felt.Felt, nonceFelt *felt.Felt) (*LoginTypedData, error) {
+	nonce := formatNonce(nonceFelt)
+	issuedAtStr, err := core.ArtPeaceBackend.Databases.Redis.GetDel(context.Background(), nonceKey(FormatAddress(address), nonce)).Result()
+	if err == redis.Nil {
+		return nil, ErrNonceNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	issuedAt, err := strconv.ParseInt(issuedAtStr, 10, 64)
+	if err != nil {
+		return nil, ErrNonceNotFound
+	}
+	return NewLoginTypedData(chainId(), nonce, issuedAt), nil
+}
+
+// Check the signature of one of the address's pending nonces & start a session, returning its token
+func Login(address *felt.Felt, nonce *felt.Felt, publicKey *felt.Felt, signature []*felt.Felt) (string, error) {
+	typedData, err := consumeNonce(address, nonce)
+	if err != nil {
+		return "", err
+	}
+	hash, err := typedData.Hash(address)
+	if err != nil {
+		return "", err
+	}
+	valid, err := VerifySignature(address, publicKey, hash, signature)
+	if err != nil {
+		fmt.Println("Failed to verify signature", err)
+	}
+	if !valid {
+		return "", ErrInvalidSignature
+	}
+
+	tokenBytes := make([]byte, 32)
+	if _, err := rand.Read(tokenBytes); err != nil {
+		return "", err
+	}
+	token := hex.EncodeToString(tokenBytes)
+	err = core.ArtPeaceBackend.Databases.Redis.Set(context.Background(), sessionKey(token), FormatAddress(address), SessionTTL()).Err()
+	if err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+// Address the session token was issued to, empty if the session is unknown or expired
+func SessionAddress(token string) (string, error) {
+	if token == "" {
+		return "", nil
+	}
+	address, err := core.ArtPeaceBackend.Databases.Redis.Get(context.Background(), sessionKey(token)).Result()
+	if err == redis.Nil {
+		return "", nil
+	}
+	return address, err
+}
+
+func Logout(token string) error {
+	return core.ArtPeaceBackend.Databases.Redis.Del(context.Background(), sessionKey(token)).Err()
+}
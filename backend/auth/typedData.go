@@ -0,0 +1,164 @@
+package auth
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/NethermindEth/juno/core/crypto"
+	"github.com/NethermindEth/juno/core/felt"
+)
+
+// SNIP-12 ( revision 1 ) typed data signed by wallets to log in
+// https://github.com/starknet-io/SNIPs/blob/main/SNIPS/snip-12.md
+
+const (
+	DomainName       = "art/peace"
+	DomainVersion    = "1"
+	DomainRevision   = "1"
+	LoginStatement   = "Sign in to art/peace"
+	LoginPrimaryType = "Login"
+)
+
+const domainTypeString = `"StarknetDomain"("name":"shortstring","version":"shortstring","chainId":"shortstring","revision":"shortstring")`
+const loginTypeString = `"Login"("statement":"shortstring","nonce":"felt","issuedAt":"timestamp")`
+
+type TypedDataMember struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+type TypedDataDomain struct {
+	Name     string `json:"name"`
+	Version  string `json:"version"`
+	ChainId  string `json:"chainId"`
+	Revision string `json:"revision"`
+}
+
+type LoginMessage struct {
+	Statement string `json:"statement"`
+	Nonce     string `json:"nonce"`
+	IssuedAt  string `json:"issuedAt"`
+}
+
+// Passed as is to the wallet's signMessage
+type LoginTypedData struct {
+	Types       map[string][]TypedDataMember `json:"types"`
+	PrimaryType string                       `json:"primaryType"`
+	Domain      TypedDataDomain              `json:"domain"`
+	Message     LoginMessage                 `json:"message"`
+}
+
+func NewLoginTypedData(chainId string, nonce string, issuedAt int64) *LoginTypedData {
+	return &LoginTypedData{
+		Types: map[string][]TypedDataMember{
+			"StarknetDomain": {
+				{Name: "name", Type: "shortstring"},
+				{Name: "version", Type: "shortstring"},
+				{Name: "chainId", Type: "shortstring"},
+				{Name: "revision", Type: "shortstring"},
+			},
+			LoginPrimaryType: {
+				{Name: "statement", Type: "shortstring"},
+				{Name: "nonce", Type: "felt"},
+				{Name: "issuedAt", Type: "timestamp"},
+			},
+		},
+		PrimaryType: LoginPrimaryType,
+		Domain: TypedDataDomain{
+			Name:     DomainName,
+			Version:  DomainVersion,
+			ChainId:  chainId,
+			Revision: DomainRevision,
+		},
+		Message: LoginMessage{
+			Statement: LoginStatement,
+			Nonce:     nonce,
+			IssuedAt:  strconv.FormatInt(issuedAt, 10),
+		},
+	}
+}
+
+// Parse a hex felt, with or without the 0x prefix
+func ParseFelt(value string) (*felt.Felt, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil, fmt.Errorf("empty felt")
+	}
+	if !strings.HasPrefix(value, "0x") && !strings.HasPrefix(value, "0X") {
+		value = "0x" + value
+	}
+	return new(felt.Felt).SetString(value)
+}
+
+// Short strings are encoded like starknet.js : whole numbers & hex values as is, other text as its ascii bytes
+func encodeShortString(value string) (*felt.Felt, error) {
+	if _, err := strconv.ParseUint(value, 10, 64); err == nil {
+		return new(felt.Felt).SetString(value)
+	}
+	if strings.HasPrefix(value, "0x") {
+		return new(felt.Felt).SetString(value)
+	}
+	if len(value) > 31 {
+		return nil, fmt.Errorf("short string longer than 31 characters")
+	}
+	return new(felt.Felt).SetBytes([]byte(value)), nil
+}
+
+func typeHash(typeString string) (*felt.Felt, error) {
+	return crypto.StarknetKeccak([]byte(typeString))
+}
+
+func (d *TypedDataDomain) hash() (*felt.Felt, error) {
+	domainType, err := typeHash(domainTypeString)
+	if err != nil {
+		return nil, err
+	}
+	values := []*felt.Felt{domainType}
+	for _, value := range []string{d.Name, d.Version, d.ChainId, d.Revision} {
+		encoded, err := encodeShortString(value)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, encoded)
+	}
+	return crypto.PoseidonArray(values...), nil
+}
+
+func (m *LoginMessage) hash() (*felt.Felt, error) {
+	loginType, err := typeHash(loginTypeString)
+	if err != nil {
+		return nil, err
+	}
+	statement, err := encodeShortString(m.Statement)
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := ParseFelt(m.Nonce)
+	if err != nil {
+		return nil, err
+	}
+	issuedAt, err := new(felt.Felt).SetString(m.IssuedAt)
+	if err != nil {
+		return nil, err
+	}
+	return crypto.PoseidonArray(loginType, statement, nonce, issuedAt), nil
+}
+
+// Message hash the account signs : poseidon("StarkNet Message", domain hash, account, message hash)
+func (t *LoginTypedData) Hash(account *felt.Felt) (*felt.Felt, error) {
+	domainHash, err := t.Domain.hash()
+	if err != nil {
+		return nil, err
+	}
+	messageHash, err := t.Message.hash()
+	if err != nil {
+		return nil, err
+	}
+	return encodeMessage(domainHash, account, messageHash), nil
+}
+
+func encodeMessage(domainHash *felt.Felt, account *felt.Felt, messageHash *felt.Felt) *felt.Felt {
+	prefix := new(felt.Felt).SetBytes([]byte("StarkNet Message"))
+	return crypto.PoseidonArray(prefix, domainHash, account, messageHash)
+}
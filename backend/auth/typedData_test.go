@@ -0,0 +1,84 @@
+package auth
+
+import (
+	"testing"
+
+	"github.com/NethermindEth/juno/core/felt"
+)
+
+func mustFelt(t *testing.T, value string) *felt.Felt {
+	t.Helper()
+	parsed, err := ParseFelt(value)
+	if err != nil {
+		t.Fatalf("parse %s: %v", value, err)
+	}
+	return parsed
+}
+
+// Domain & message encoding against the starknet.js "example_baseTypes" revision 1 fixture,
+// whose struct hash & message hash come from starknet.js's typedData tests
+func TestEncodeMessageStarknetJs(t *testing.T) {
+	domain := TypedDataDomain{Name: "StarkNet Mail", Version: "1", ChainId: "1", Revision: "1"}
+	domainHash, err := domain.hash()
+	if err != nil {
+		t.Fatal(err)
+	}
+	account := mustFelt(t, "0xCD2a3d9F938E13CD947Ec05AbC7FE734Df8DD826")
+	structHash := mustFelt(t, "0x75db031c1f5bf980cc48f46943b236cb85a95c8f3b3c8203572453075d3d39")
+
+	got := encodeMessage(domainHash, account, structHash)
+	want := mustFelt(t, "0xdb7829db8909c0c5496f5952bcfc4fc894341ce01842537fc4f448743480b6")
+	if !got.Equal(want) {
+		t.Fatalf("message hash %s, want %s", got, want)
+	}
+}
+
+// Login hashes computed by starknet.go's typedData, which passes the starknet.js fixtures
+func TestLoginTypedDataHash(t *testing.T) {
+	tests := []struct {
+		name     string
+		chainId  string
+		nonce    string
+		issuedAt int64
+		account  string
+		want     string
+	}{
+		{
+			name:     "sepolia",
+			chainId:  "SN_SEPOLIA",
+			nonce:    "0x0102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f",
+			issuedAt: 1700000000,
+			account:  "0x22e855eadadbb672fb52137c02675caff6845e26544de9d2cc22b93fa7969ee",
+			want:     "0x689ef02f20a6e243d7d6054d4f0fc5fae0e46e23033a132fa0678e0c66f7676",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			typedData := NewLoginTypedData(test.chainId, test.nonce, test.issuedAt)
+			got, err := typedData.Hash(mustFelt(t, test.account))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if want := mustFelt(t, test.want); !got.Equal(want) {
+				t.Fatalf("hash %s, want %s", got, want)
+			}
+		})
+	}
+}
+
+// The nonce is hashed as a felt, so its formatting doesn't change the hash
+func TestLoginTypedDataHashNonceFormat(t *testing.T) {
+	account := mustFelt(t, "0x22e855eadadbb672fb52137c02675caff6845e26544de9d2cc22b93fa7969ee")
+	padded, err := NewLoginTypedData("SN_SEPOLIA", "0x00ff", 1700000000).Hash(account)
+	if err != nil {
+		t.Fatal(err)
+	}
+	short, err := NewLoginTypedData("SN_SEPOLIA", "0xff", 1700000000).Hash(account)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !padded.Equal(short) {
+		t.Fatalf("hashes differ: %s & %s", padded, short)
+	}
+}
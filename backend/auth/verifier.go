@@ -0,0 +1,147 @@
+package auth
+
+import (
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/NethermindEth/juno/core/crypto"
+	"github.com/NethermindEth/juno/core/felt"
+
+	"github.com/keep-starknet-strange/art-peace/backend/config"
+	"github.com/keep-starknet-strange/art-peace/backend/core"
+)
+
+// Checks an account's signature of a message hash
+// Returns false if the signature is invalid or the verifier doesn't know the account
+type Verifier interface {
+	Verify(account *felt.Felt, publicKey *felt.Felt, hash *felt.Felt, signature []*felt.Felt) (bool, error)
+}
+
+var verifiersLock sync.Mutex
+var verifiers []Verifier
+var verifiersOnce sync.Once
+
+// Add a verifier, ex: one calling isValidSignature on a node for accounts the offline ones don't know
+func RegisterVerifier(verifier Verifier) {
+	verifiersLock.Lock()
+	verifiers = append(verifiers, verifier)
+	verifiersLock.Unlock()
+}
+
+// Valid if any of the verifiers accepts the signature
+func VerifySignature(account *felt.Felt, publicKey *felt.Felt, hash *felt.Felt, signature []*felt.Felt) (bool, error) {
+	verifiersOnce.Do(func() {
+		for _, class := range core.ArtPeaceBackend.BackendConfig.Auth.AccountClasses {
+			verifier, err := NewAccountClassVerifier(class)
+			if err != nil {
+				fmt.Println("Skipping account class", class.ClassHash, err)
+				continue
+			}
+			RegisterVerifier(verifier)
+		}
+	})
+
+	verifiersLock.Lock()
+	registered := append([]Verifier{}, verifiers...)
+	verifiersLock.Unlock()
+
+	var lastErr error
+	for _, verifier := range registered {
+		valid, err := verifier.Verify(account, publicKey, hash, signature)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		if valid {
+			return true, nil
+		}
+	}
+	return false, lastErr
+}
+
+// Account classes the offline verifier knows how to deploy & read signatures of
+const (
+	OpenZeppelinAccount = "openzeppelin"
+	// Argent 0.3 : owner & guardian keys as constructor arguments
+	ArgentAccount = "argent_0.3"
+	// Argent 0.4 : owner & guardian as signer enums
+	ArgentSignerAccount = "argent_0.4"
+)
+
+// Verifies accounts deployed from a known class with their public key as salt, the default of wallets,
+// by recomputing the account address from the public key, so no node is needed
+// Accounts which rotated their key or were deployed differently need another verifier
+type AccountClassVerifier struct {
+	Kind      string
+	ClassHash *felt.Felt
+}
+
+func NewAccountClassVerifier(class config.AccountClassConfig) (*AccountClassVerifier, error) {
+	switch class.Kind {
+	case OpenZeppelinAccount, ArgentAccount, ArgentSignerAccount:
+	default:
+		return nil, fmt.Errorf("unknown account kind %s", class.Kind)
+	}
+	classHash, err := ParseFelt(class.ClassHash)
+	if err != nil {
+		return nil, err
+	}
+	return &AccountClassVerifier{Kind: class.Kind, ClassHash: classHash}, nil
+}
+
+func (v *AccountClassVerifier) calldata(publicKey *felt.Felt) []*felt.Felt {
+	switch v.Kind {
+	case ArgentAccount:
+		return []*felt.Felt{publicKey, new(felt.Felt)}
+	case ArgentSignerAccount:
+		// Starknet signer variant 0 for the owner, None variant 1 for the guardian
+		return []*felt.Felt{new(felt.Felt), publicKey, new(felt.Felt).SetUint64(1)}
+	default:
+		return []*felt.Felt{publicKey}
+	}
+}
+
+// The owner's stark signature out of the account's signature format
+func (v *AccountClassVerifier) starkSignature(publicKey *felt.Felt, signature []*felt.Felt) (*crypto.Signature, bool) {
+	if len(signature) == 2 {
+		return &crypto.Signature{R: *signature[0], S: *signature[1]}, true
+	}
+	switch v.Kind {
+	case ArgentAccount:
+		// Owner signature followed by the guardian's
+		if len(signature) == 4 {
+			return &crypto.Signature{R: *signature[0], S: *signature[1]}, true
+		}
+	case ArgentSignerAccount:
+		// Signer count, then signer variant, public key, r & s for each signer
+		if len(signature) >= 5 && signature[1].IsZero() && signature[2].Equal(publicKey) {
+			return &crypto.Signature{R: *signature[3], S: *signature[4]}, true
+		}
+	}
+	return nil, false
+}
+
+func (v *AccountClassVerifier) Verify(account *felt.Felt, publicKey *felt.Felt, hash *felt.Felt, signature []*felt.Felt) (bool, error) {
+	if !ContractAddress(new(felt.Felt), v.ClassHash, publicKey, v.calldata(publicKey)).Equal(account) {
+		return false, nil
+	}
+	starkSignature, ok := v.starkSignature(publicKey, signature)
+	if !ok {
+		return false, nil
+	}
+	key := crypto.NewPublicKey(publicKey)
+	return key.Verify(starkSignature, hash)
+}
+
+// Addresses are below 2^251 - 256
+var addressBound = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 251), big.NewInt(256))
+
+// Address of a contract deployed with deploy_account or the deploy syscall
+// https://docs.starknet.io/architecture-and-concepts/smart-contracts/contract-address/
+func ContractAddress(deployer *felt.Felt, classHash *felt.Felt, salt *felt.Felt, calldata []*felt.Felt) *felt.Felt {
+	prefix := new(felt.Felt).SetBytes([]byte("STARKNET_CONTRACT_ADDRESS"))
+	address := crypto.PedersenArray(prefix, deployer, salt, classHash, crypto.PedersenArray(calldata...))
+	value := address.BigInt(new(big.Int))
+	return new(felt.Felt).SetBigInt(value.Mod(value, addressBound))
+}
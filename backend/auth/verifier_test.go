@@ -0,0 +1,179 @@
+package auth
+
+import (
+	"testing"
+
+	"github.com/NethermindEth/juno/core/felt"
+)
+
+const (
+	openZeppelinClassHash = "0x061dac032f228abef9c6626f995015233097ae253a7f72d68552db02f2971b8f"
+	argentClassHash       = "0x029927c8af6bccf3f6fda035981e765a7bdbf18a2dc0d630494f8758aa908e2b"
+	argentSignerClassHash = "0x036078334509b514626504edc9fb252328d1a240e4e948bef8d0c08dff45927f"
+)
+
+// Accounts deployed with deploy_account, the address is the one of the deployment
+var deployedAccounts = []struct {
+	name      string
+	kind      string
+	classHash string
+	publicKey string
+	address   string
+}{
+	{
+		// Sepolia tx 0x7a4458b402a172e730c947b293a499d310a7ae6cfb18b5d9774fc10625927e5
+		name:      "openzeppelin",
+		kind:      OpenZeppelinAccount,
+		classHash: openZeppelinClassHash,
+		publicKey: "0x023a851e8aeba201772098e1a1db3448f6238b20f928527242eb383905d91a87",
+		address:   "0x28771beb7a2522a07d2ae6fc1fa5af942e8e863f70e6d7d74f9600ea3d5c242",
+	},
+	{
+		// Mainnet tx 0x9b3d4c1bbdb926a382b7dd07a0ad0ecb6f1481d91a91ede403023db9afd94f, block 588763
+		name:      "argent 0.3",
+		kind:      ArgentAccount,
+		classHash: argentClassHash,
+		publicKey: "0x7e804e99010172c123186ec8ae5cf3ad2b76d2192567b1f9470dbf57bc7c56b",
+		address:   "0x3a89c0b226eb39bb3a30cdc65efb574cf5421a13a1536708153d269d6aa96df",
+	},
+	{
+		// Sepolia tx 0x71648395117861ef59d09920696915a17d1df06a9439119376b0c894ad44ce7, block 122476
+		name:      "argent 0.4",
+		kind:      ArgentSignerAccount,
+		classHash: argentSignerClassHash,
+		publicKey: "0x72ebef11beea5f6bc7a40867f4c13198c674d4d6b4e1bc6b534542c330b62f9",
+		address:   "0x11de9c756a84a99b5765183625cd7ad8eb6a0206ce2904dbe478a1a1e5c0cb1",
+	},
+}
+
+func TestContractAddress(t *testing.T) {
+	for _, account := range deployedAccounts {
+		t.Run(account.name, func(t *testing.T) {
+			publicKey := mustFelt(t, account.publicKey)
+			verifier := &AccountClassVerifier{Kind: account.kind, ClassHash: mustFelt(t, account.classHash)}
+			got := ContractAddress(new(felt.Felt), verifier.ClassHash, publicKey, verifier.calldata(publicKey))
+			if want := mustFelt(t, account.address); !got.Equal(want) {
+				t.Fatalf("address %s, want %s", got, want)
+			}
+		})
+	}
+}
+
+func TestAccountClassVerifier(t *testing.T) {
+	tests := []struct {
+		name      string
+		kind      string
+		classHash string
+		account   string
+		publicKey string
+		hash      string
+		signature []string
+		valid     bool
+	}{
+		{
+			// The deploy_account signature of the argent 0.3 account above, over its tx hash
+			name:      "argent 0.3 deploy",
+			kind:      ArgentAccount,
+			classHash: argentClassHash,
+			account:   "0x3a89c0b226eb39bb3a30cdc65efb574cf5421a13a1536708153d269d6aa96df",
+			publicKey: "0x7e804e99010172c123186ec8ae5cf3ad2b76d2192567b1f9470dbf57bc7c56b",
+			hash:      "0x9b3d4c1bbdb926a382b7dd07a0ad0ecb6f1481d91a91ede403023db9afd94f",
+			signature: []string{
+				"0x128bc91881c11605635c92064aefa211b7339bc5a4f85b9909cf005cf195370",
+				"0x3bfa89786331f490d5bf5564e1420eae5672c622cd7215b6b36fc4275d6d7ff",
+			},
+			valid: true,
+		},
+		{
+			// The deploy_account signature of the argent 0.4 account above, in the signer format
+			name:      "argent 0.4 deploy",
+			kind:      ArgentSignerAccount,
+			classHash: argentSignerClassHash,
+			account:   "0x11de9c756a84a99b5765183625cd7ad8eb6a0206ce2904dbe478a1a1e5c0cb1",
+			publicKey: "0x72ebef11beea5f6bc7a40867f4c13198c674d4d6b4e1bc6b534542c330b62f9",
+			hash:      "0x71648395117861ef59d09920696915a17d1df06a9439119376b0c894ad44ce7",
+			signature: []string{
+				"0x1",
+				"0x0",
+				"0x72ebef11beea5f6bc7a40867f4c13198c674d4d6b4e1bc6b534542c330b62f9",
+				"0x748e650647cba51ef1bd1ac2faf63d21ed4f24d228fc8586213f0d2d637046f",
+				"0x7da98a8a6c77fd1db081a4859683aaa420b70c0a5923a66ef272b9750eb5f92",
+			},
+			valid: true,
+		},
+		{
+			// The login hash of TestLoginTypedDataHash, signed by starknet.go with the private key 0x71d7bb07b9a64f6f78ac4c816aff4da9
+			name:      "openzeppelin login",
+			kind:      OpenZeppelinAccount,
+			classHash: openZeppelinClassHash,
+			account:   "0x22e855eadadbb672fb52137c02675caff6845e26544de9d2cc22b93fa7969ee",
+			publicKey: "0x39d9e6ce352ad4530a0ef5d5a18fd3303c3606a7fa6ac5b620020ad681cc33b",
+			hash:      "0x689ef02f20a6e243d7d6054d4f0fc5fae0e46e23033a132fa0678e0c66f7676",
+			signature: []string{
+				"0x246f4c1356174e85b4b847507ab3267e799e90c8169d8b6e73677d169494f26",
+				"0x70429551c3f4a7d25ca88bd2c7c1aa85d73c953df1b18f3bace8a2597a967b",
+			},
+			valid: true,
+		},
+		{
+			name:      "signature of another hash",
+			kind:      OpenZeppelinAccount,
+			classHash: openZeppelinClassHash,
+			account:   "0x22e855eadadbb672fb52137c02675caff6845e26544de9d2cc22b93fa7969ee",
+			publicKey: "0x39d9e6ce352ad4530a0ef5d5a18fd3303c3606a7fa6ac5b620020ad681cc33b",
+			hash:      "0x689ef02f20a6e243d7d6054d4f0fc5fae0e46e23033a132fa0678e0c66f7677",
+			signature: []string{
+				"0x246f4c1356174e85b4b847507ab3267e799e90c8169d8b6e73677d169494f26",
+				"0x70429551c3f4a7d25ca88bd2c7c1aa85d73c953df1b18f3bace8a2597a967b",
+			},
+			valid: false,
+		},
+		{
+			// A valid signature, but the public key isn't the one the account was deployed with
+			name:      "key of another account",
+			kind:      OpenZeppelinAccount,
+			classHash: openZeppelinClassHash,
+			account:   "0x28771beb7a2522a07d2ae6fc1fa5af942e8e863f70e6d7d74f9600ea3d5c242",
+			publicKey: "0x39d9e6ce352ad4530a0ef5d5a18fd3303c3606a7fa6ac5b620020ad681cc33b",
+			hash:      "0x689ef02f20a6e243d7d6054d4f0fc5fae0e46e23033a132fa0678e0c66f7676",
+			signature: []string{
+				"0x246f4c1356174e85b4b847507ab3267e799e90c8169d8b6e73677d169494f26",
+				"0x70429551c3f4a7d25ca88bd2c7c1aa85d73c953df1b18f3bace8a2597a967b",
+			},
+			valid: false,
+		},
+		{
+			name:      "argent 0.4 signature from another signer",
+			kind:      ArgentSignerAccount,
+			classHash: argentSignerClassHash,
+			account:   "0x11de9c756a84a99b5765183625cd7ad8eb6a0206ce2904dbe478a1a1e5c0cb1",
+			publicKey: "0x72ebef11beea5f6bc7a40867f4c13198c674d4d6b4e1bc6b534542c330b62f9",
+			hash:      "0x71648395117861ef59d09920696915a17d1df06a9439119376b0c894ad44ce7",
+			signature: []string{
+				"0x1",
+				"0x0",
+				"0x39d9e6ce352ad4530a0ef5d5a18fd3303c3606a7fa6ac5b620020ad681cc33b",
+				"0x748e650647cba51ef1bd1ac2faf63d21ed4f24d228fc8586213f0d2d637046f",
+				"0x7da98a8a6c77fd1db081a4859683aaa420b70c0a5923a66ef272b9750eb5f92",
+			},
+			valid: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			verifier := &AccountClassVerifier{Kind: test.kind, ClassHash: mustFelt(t, test.classHash)}
+			signature := make([]*felt.Felt, 0, len(test.signature))
+			for _, value := range test.signature {
+				signature = append(signature, mustFelt(t, value))
+			}
+			valid, err := verifier.Verify(mustFelt(t, test.account), mustFelt(t, test.publicKey), mustFelt(t, test.hash), signature)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if valid != test.valid {
+				t.Fatalf("valid %t, want %t", valid, test.valid)
+			}
+		})
+	}
+}
@@ -0,0 +1,3339 @@
+// Code generated by cmd/openapi from routes/openapi.json. DO NOT EDIT.
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+type AddProtectedRegionRequest struct {
+	Address   string `json:"address"`
+	WorldId   int    `json:"worldId"`
+	Name      string `json:"name"`
+	XStart    int    `json:"xStart"`
+	XEnd      int    `json:"xEnd"`
+	YStart    int    `json:"yStart"`
+	YEnd      int    `json:"yEnd"`
+	StartTime int64  `json:"startTime"`
+	EndTime   int64  `json:"endTime"`
+	Mode      string `json:"mode"`
+}
+
+type CanvasDiff struct {
+	From    int64          `json:"from"`
+	To      int64          `json:"to"`
+	Crop    []int          `json:"crop"`
+	Changes []*PixelChange `json:"changes"`
+}
+
+type Cell struct {
+	X     int `json:"x"`
+	Y     int `json:"y"`
+	Count int `json:"count"`
+}
+
+type ChatMessage struct {
+	Key     int       `json:"key"`
+	WorldId int       `json:"worldId"`
+	Address string    `json:"address"`
+	Message string    `json:"message"`
+	Time    time.Time `json:"time"`
+}
+
+type ClaimParamConfig struct {
+	Type    string `json:"type"`
+	Name    string `json:"name"`
+	Example string `json:"example"`
+	Input   bool   `json:"input"`
+}
+
+type ClaimParams struct {
+	QuestId   int    `json:"questId"`
+	ClaimType string `json:"claimType"`
+	Name      string `json:"name"`
+	Example   string `json:"example"`
+	Input     bool   `json:"input"`
+}
+
+type ClearPixelsRequest struct {
+	XStart  int `json:"xStart"`
+	YStart  int `json:"yStart"`
+	XEnd    int `json:"xEnd"`
+	YEnd    int `json:"yEnd"`
+	WorldId int `json:"worldId"`
+}
+
+type DailyQuest struct {
+	Name        string        `json:"name"`
+	Description string        `json:"description"`
+	Reward      int           `json:"reward"`
+	DayIndex    int           `json:"dayIndex"`
+	QuestId     int           `json:"questId"`
+	ClaimParams []ClaimParams `json:"claimParams"`
+}
+
+type DailyQuestConfig struct {
+	Day    int           `json:"day"`
+	Quests []QuestConfig `json:"quests"`
+}
+
+type DailyUserQuest struct {
+	Name        string        `json:"name"`
+	Description string        `json:"description"`
+	Reward      int           `json:"reward"`
+	DayIndex    int           `json:"dayIndex"`
+	QuestId     int           `json:"questId"`
+	Completed   bool          `json:"completed"`
+	ClaimParams []ClaimParams `json:"claimParams"`
+}
+
+type DeleteChatMessageRequest struct {
+	Address string `json:"address"`
+	WorldId int    `json:"worldId"`
+	Key     int    `json:"key"`
+}
+
+type Error struct {
+	Error     string `json:"error"`
+	Code      string `json:"code"`
+	RequestId string `json:"requestId,omitempty"`
+}
+
+type ExtraPixelJson struct {
+	ExtraPixels []map[string]int `json:"extraPixels"`
+	Timestamp   int              `json:"timestamp"`
+}
+
+type FactionData struct {
+	FactionId int    `json:"factionId"`
+	Name      string `json:"name"`
+	Leader    string `json:"leader"`
+	Members   int    `json:"members"`
+	IsMember  bool   `json:"isMember"`
+	Joinable  bool   `json:"joinable"`
+	Icon      string `json:"icon"`
+	Telegram  string `json:"telegram"`
+	Twitter   string `json:"twitter"`
+	Github    string `json:"github"`
+	Site      string `json:"site"`
+}
+
+type FactionMemberData struct {
+	Username        string `json:"username"`
+	UserAddress     string `json:"userAddress"`
+	TotalAllocation int    `json:"totalAllocation"`
+}
+
+type FactionTemplateData struct {
+	TemplateId int    `json:"templateId"`
+	Hash       string `json:"hash"`
+	Width      int    `json:"width"`
+	Height     int    `json:"height"`
+	Position   int    `json:"position"`
+}
+
+type FactionUserData struct {
+	FactionId  int    `json:"factionId"`
+	Allocation int    `json:"allocation"`
+	Name       string `json:"name"`
+	Leader     string `json:"leader"`
+	Members    int    `json:"members"`
+	Joinable   bool   `json:"joinable"`
+	Icon       string `json:"icon"`
+	Telegram   string `json:"telegram"`
+	Twitter    string `json:"twitter"`
+	Github     string `json:"github"`
+	Site       string `json:"site"`
+}
+
+type FactionsConfig struct {
+	Factions      []FactionsConfigItem `json:"factions"`
+	ChainFactions []string             `json:"chain_factions"`
+}
+
+type FactionsConfigItem struct {
+	Id        int    `json:"id"`
+	Name      string `json:"name"`
+	Icon      string `json:"icon"`
+	Leader    string `json:"leader"`
+	Pool      int    `json:"pool"`
+	PerMember bool   `json:"per_member"`
+	Joinable  bool   `json:"joinable"`
+	Links     struct {
+		Telegram string `json:"telegram"`
+		Twitter  string `json:"twitter"`
+		Github   string `json:"github"`
+		Site     string `json:"site"`
+	} `json:"links"`
+	Members []string `json:"members"`
+}
+
+type GameData struct {
+	Day     int    `json:"day"`
+	EndTime int    `json:"endTime"`
+	Host    string `json:"host"`
+}
+
+type GraphQLRequest struct {
+	Query         string                     `json:"query"`
+	OperationName string                     `json:"operationName,omitempty"`
+	Variables     map[string]json.RawMessage `json:"variables,omitempty"`
+}
+
+type Heatmap struct {
+	Width    int     `json:"width"`
+	Height   int     `json:"height"`
+	CellSize int     `json:"cellSize"`
+	Columns  int     `json:"columns"`
+	Rows     int     `json:"rows"`
+	Max      int     `json:"max"`
+	Total    int     `json:"total"`
+	Cells    []*Cell `json:"cells"`
+}
+
+type LeaderboardEntry struct {
+	Key   string `json:"key"`
+	Score int    `json:"score"`
+}
+
+type LoginMessage struct {
+	Statement string `json:"statement"`
+	Nonce     string `json:"nonce"`
+	IssuedAt  string `json:"issuedAt"`
+}
+
+type LoginRequest struct {
+	Address   string   `json:"address"`
+	Nonce     string   `json:"nonce"`
+	PublicKey string   `json:"publicKey"`
+	Signature []string `json:"signature"`
+}
+
+type LoginTypedData struct {
+	Types       map[string][]TypedDataMember `json:"types"`
+	PrimaryType string                       `json:"primaryType"`
+	Domain      TypedDataDomain              `json:"domain"`
+	Message     LoginMessage                 `json:"message"`
+}
+
+type MainQuest struct {
+	QuestId     int           `json:"questId"`
+	Name        string        `json:"name"`
+	Description string        `json:"description"`
+	Reward      int           `json:"reward"`
+	ClaimParams []ClaimParams `json:"claimParams"`
+}
+
+type MainUserQuest struct {
+	QuestId     int           `json:"questId"`
+	Name        string        `json:"name"`
+	Description string        `json:"description"`
+	Reward      int           `json:"reward"`
+	Completed   bool          `json:"completed"`
+	ClaimParams []ClaimParams `json:"claimParams"`
+}
+
+type MembershipPixelsData struct {
+	FactionId      int        `json:"factionId"`
+	Allocation     int        `json:"allocation"`
+	LastPlacedTime *time.Time `json:"lastPlacedTime"`
+	MemberPixels   int        `json:"memberPixels"`
+}
+
+type MuteChatAddressRequest struct {
+	Address  string `json:"address"`
+	WorldId  int    `json:"worldId"`
+	Target   string `json:"target"`
+	Duration int64  `json:"duration"`
+}
+
+type NFTData struct {
+	TokenId     int       `json:"tokenId"`
+	Position    int       `json:"position"`
+	Width       int       `json:"width"`
+	Height      int       `json:"height"`
+	Name        string    `json:"name"`
+	ImageHash   string    `json:"imageHash"`
+	BlockNumber int       `json:"blockNumber"`
+	DayIndex    int       `json:"dayIndex"`
+	Minter      string    `json:"minter"`
+	Owner       string    `json:"owner"`
+	MintedAt    time.Time `json:"mintedAt"`
+	Likes       int       `json:"likes"`
+	Liked       bool      `json:"liked"`
+	Hotness     int       `json:"hotness,omitempty"`
+}
+
+type Pagination struct {
+	Page       int    `json:"page,omitempty"`
+	PageLength int    `json:"pageLength"`
+	Count      int    `json:"count"`
+	HasNext    bool   `json:"hasNext"`
+	NextPage   *int   `json:"nextPage,omitempty"`
+	NextCursor string `json:"nextCursor,omitempty"`
+}
+
+type PixelChange struct {
+	Position int `json:"position"`
+	X        int `json:"x"`
+	Y        int `json:"y"`
+	OldColor int `json:"oldColor"`
+	NewColor int `json:"newColor"`
+}
+
+type PixelRollback struct {
+	Key               int        `json:"key"`
+	WorldId           int        `json:"worldId"`
+	Addresses         []string   `json:"addresses"`
+	FromTime          *time.Time `json:"fromTime"`
+	ToTime            *time.Time `json:"toTime"`
+	PlacementsRemoved int        `json:"placementsRemoved"`
+	PositionsRestored int        `json:"positionsRestored"`
+	Time              time.Time  `json:"time"`
+}
+
+type PresenceCursor struct {
+	X       int    `json:"x"`
+	Y       int    `json:"y"`
+	Address string `json:"address,omitempty"`
+}
+
+type ProtectedRegion struct {
+	Key       int       `json:"key"`
+	WorldId   int       `json:"worldId"`
+	Name      string    `json:"name"`
+	XStart    int       `json:"xStart"`
+	XEnd      int       `json:"xEnd"`
+	YStart    int       `json:"yStart"`
+	YEnd      int       `json:"yEnd"`
+	StartTime time.Time `json:"startTime"`
+	EndTime   time.Time `json:"endTime"`
+	Mode      string    `json:"mode"`
+	CreatedBy string    `json:"createdBy"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+type ProtectedRegionViolation struct {
+	Key           int       `json:"key"`
+	RegionKey     int       `json:"regionKey"`
+	WorldId       int       `json:"worldId"`
+	Address       string    `json:"address"`
+	Position      int       `json:"position"`
+	Color         int       `json:"color"`
+	Restored      bool      `json:"restored"`
+	RestoredColor *int      `json:"restoredColor"`
+	Time          time.Time `json:"time"`
+}
+
+type QuestConfig struct {
+	Name          string              `json:"name"`
+	Description   string              `json:"description"`
+	Reward        int                 `json:"reward"`
+	QuestContract QuestContractConfig `json:"questContract"`
+}
+
+type QuestContractConfig struct {
+	Type        string             `json:"type"`
+	InitParams  []string           `json:"initParams"`
+	StoreParams []int              `json:"storeParams"`
+	ClaimParams []ClaimParamConfig `json:"claimParams"`
+}
+
+type QuestProgress struct {
+	QuestId  int   `json:"questId"`
+	Progress int   `json:"progress"`
+	Needed   int   `json:"needed"`
+	Calldata []int `json:"calldata"`
+}
+
+type QuestStatus struct {
+	Progress int `json:"progress"`
+	Needed   int `json:"needed"`
+}
+
+type QuestsConfig struct {
+	Daily struct {
+		DailyQuestsCount int                `json:"dailyQuestsCount"`
+		DailyQuests      []DailyQuestConfig `json:"dailyQuests"`
+	} `json:"daily"`
+	Main struct {
+		MainQuests []QuestConfig `json:"mainQuests"`
+	} `json:"main"`
+}
+
+type RemoveProtectedRegionRequest struct {
+	Address string `json:"address"`
+	WorldId int    `json:"worldId"`
+	Key     int    `json:"key"`
+}
+
+type Result struct {
+	Result    string `json:"result"`
+	RequestId string `json:"requestId,omitempty"`
+}
+
+type RollbackPixelsRequest struct {
+	WorldId   int      `json:"worldId"`
+	Addresses []string `json:"addresses"`
+	From      int64    `json:"from"`
+	To        int64    `json:"to"`
+	DryRun    bool     `json:"dryRun"`
+}
+
+type RollbackResult struct {
+	RollbackKey       int                `json:"rollbackKey"`
+	DryRun            bool               `json:"dryRun"`
+	PlacementsRemoved int                `json:"placementsRemoved"`
+	Pixels            []*RolledBackPixel `json:"pixels"`
+	PendingPositions  []int              `json:"pendingPositions"`
+}
+
+type RolledBackPixel struct {
+	Position      int `json:"position"`
+	X             int `json:"x"`
+	Y             int `json:"y"`
+	CurrentColor  int `json:"currentColor"`
+	RestoredColor int `json:"restoredColor"`
+}
+
+type Round3 struct {
+	Width     int `json:"width"`
+	Height    int `json:"height"`
+	Pixels    int `json:"pixels"`
+	Timer     int `json:"timer"`
+	StartTime int `json:"startTime"`
+	EndTime   int `json:"endTime"`
+}
+
+type RoundsConfig struct {
+	Round3 Round3 `json:"round3"`
+}
+
+type SessionResponse struct {
+	Address   string    `json:"address"`
+	Token     string    `json:"token,omitempty"`
+	ExpiresAt time.Time `json:"expiresAt,omitempty"`
+}
+
+type StencilData struct {
+	StencilId int    `json:"stencilId"`
+	WorldId   int    `json:"worldId"`
+	Name      string `json:"name"`
+	Hash      string `json:"hash"`
+	Width     int    `json:"width"`
+	Height    int    `json:"height"`
+	Position  int    `json:"position"`
+	Favorites int    `json:"favorites"`
+	Favorited bool   `json:"favorited"`
+	Hotness   int    `json:"hotness,omitempty"`
+}
+
+type TemplateData struct {
+	Key         int    `json:"key"`
+	Name        string `json:"name"`
+	Hash        string `json:"hash"`
+	Width       int    `json:"width"`
+	Height      int    `json:"height"`
+	Position    int    `json:"position"`
+	Reward      int    `json:"reward"`
+	RewardToken string `json:"rewardToken"`
+}
+
+type TimelapseJob struct {
+	JobId    string     `json:"jobId"`
+	Format   string     `json:"format"`
+	Status   string     `json:"status"`
+	Replayed int        `json:"replayed"`
+	Total    int        `json:"total"`
+	Error    string     `json:"error,omitempty"`
+	Created  time.Time  `json:"created"`
+	Finished *time.Time `json:"finished,omitempty"`
+}
+
+type TypedDataDomain struct {
+	Name     string `json:"name"`
+	Version  string `json:"version"`
+	ChainId  string `json:"chainId"`
+	Revision string `json:"revision"`
+}
+
+type TypedDataMember struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+type UserHighlight struct {
+	Address   string `json:"address"`
+	Surviving int    `json:"surviving"`
+	Positions []int  `json:"positions"`
+}
+
+type UserRewardsData struct {
+	Address string `json:"address"`
+	Amount  int    `json:"amount"`
+	Type    string `json:"type"`
+}
+
+type VotableColor struct {
+	Key   int    `json:"key"`
+	Hex   string `json:"hex"`
+	Votes int    `json:"votes"`
+}
+
+type WorldData struct {
+	WorldId           int        `json:"worldId"`
+	Host              string     `json:"host"`
+	Name              string     `json:"name"`
+	UniqueName        string     `json:"uniqueName"`
+	Width             int        `json:"width"`
+	Height            int        `json:"height"`
+	PixelsPerTime     int        `json:"pixelsPerTime"`
+	TimeBetweenPixels int        `json:"timeBetweenPixels"`
+	StartTime         *time.Time `json:"startTime"`
+	EndTime           *time.Time `json:"endTime"`
+	Favorites         int        `json:"favorites"`
+	Favorited         bool       `json:"favorited"`
+	Hotness           int        `json:"hotness,omitempty"`
+}
+
+type WorldPresence struct {
+	WorldId int              `json:"worldId"`
+	Viewers int              `json:"viewers"`
+	Cursors []PresenceCursor `json:"cursors"`
+}
+
+// GET /
+func (c *Client) Root(ctx context.Context) error {
+	var query url.Values
+	var reqBody requestBody
+	return c.empty(ctx, http.MethodGet, "/", query, reqBody)
+}
+
+type AddChainFactionTemplateDevnetBody struct {
+	FactionId string `json:"factionId,omitempty"`
+	Hash      string `json:"hash,omitempty"`
+	Height    string `json:"height,omitempty"`
+	Position  string `json:"position,omitempty"`
+	Width     string `json:"width,omitempty"`
+}
+
+// POST /add-chain-faction-template-devnet
+func (c *Client) AddChainFactionTemplateDevnet(ctx context.Context, body AddChainFactionTemplateDevnetBody) (string, error) {
+	var query url.Values
+	reqBody := jsonBody(body)
+	return c.result(ctx, http.MethodPost, "/add-chain-faction-template-devnet", query, reqBody)
+}
+
+type AddFactionTemplateDevnetBody struct {
+	FactionId string `json:"factionId,omitempty"`
+	Hash      string `json:"hash,omitempty"`
+	Height    string `json:"height,omitempty"`
+	Position  string `json:"position,omitempty"`
+	Width     string `json:"width,omitempty"`
+}
+
+// POST /add-faction-template-devnet
+func (c *Client) AddFactionTemplateDevnet(ctx context.Context, body AddFactionTemplateDevnetBody) (string, error) {
+	var query url.Values
+	reqBody := jsonBody(body)
+	return c.result(ctx, http.MethodPost, "/add-faction-template-devnet", query, reqBody)
+}
+
+type AddProtectedRegionData struct {
+	Key int `json:"key"`
+}
+
+// POST /add-protected-region
+func (c *Client) AddProtectedRegion(ctx context.Context, body AddProtectedRegionRequest) (AddProtectedRegionData, error) {
+	var query url.Values
+	reqBody := jsonBody(body)
+	return getData[AddProtectedRegionData](c, ctx, http.MethodPost, "/add-protected-region", query, reqBody)
+}
+
+type AddStencilDataBody struct {
+	Height  string `json:"height,omitempty"`
+	Image   string `json:"image,omitempty"`
+	Width   string `json:"width,omitempty"`
+	WorldId string `json:"worldId,omitempty"`
+}
+
+// POST /add-stencil-data
+func (c *Client) AddStencilData(ctx context.Context, body AddStencilDataBody) (string, error) {
+	var query url.Values
+	reqBody := jsonBody(body)
+	return c.result(ctx, http.MethodPost, "/add-stencil-data", query, reqBody)
+}
+
+type AddStencilDevnetBody struct {
+	Hash     string `json:"hash,omitempty"`
+	Height   string `json:"height,omitempty"`
+	Position string `json:"position,omitempty"`
+	Width    string `json:"width,omitempty"`
+	WorldId  string `json:"worldId,omitempty"`
+}
+
+// POST /add-stencil-devnet
+func (c *Client) AddStencilDevnet(ctx context.Context, body AddStencilDevnetBody) (string, error) {
+	var query url.Values
+	reqBody := jsonBody(body)
+	return c.result(ctx, http.MethodPost, "/add-stencil-devnet", query, reqBody)
+}
+
+// Form of AddStencilImg
+type AddStencilImgForm struct {
+	Image   File
+	WorldId string
+}
+
+// POST /add-stencil-img
+func (c *Client) AddStencilImg(ctx context.Context, form AddStencilImgForm) (string, error) {
+	var query url.Values
+	reqBody := formBody(map[string]string{"worldId": form.WorldId}, map[string]File{"image": form.Image})
+	return c.result(ctx, http.MethodPost, "/add-stencil-img", query, reqBody)
+}
+
+type AddTemplateDataBody struct {
+	Height string `json:"height,omitempty"`
+	Image  string `json:"image,omitempty"`
+	Width  string `json:"width,omitempty"`
+}
+
+// POST /add-template-data
+func (c *Client) AddTemplateData(ctx context.Context, body AddTemplateDataBody) (string, error) {
+	var query url.Values
+	reqBody := jsonBody(body)
+	return c.result(ctx, http.MethodPost, "/add-template-data", query, reqBody)
+}
+
+// Form of AddTemplateImg
+type AddTemplateImgForm struct {
+	Image File
+}
+
+// POST /add-template-img
+func (c *Client) AddTemplateImg(ctx context.Context, form AddTemplateImgForm) (string, error) {
+	var query url.Values
+	reqBody := formBody(map[string]string{}, map[string]File{"image": form.Image})
+	return c.result(ctx, http.MethodPost, "/add-template-img", query, reqBody)
+}
+
+// Parameters of BuildTemplateImg
+type BuildTemplateImgParams struct {
+	Start *int
+}
+
+// Form of BuildTemplateImg
+type BuildTemplateImgForm struct {
+	Image File
+}
+
+// curl -F "image=@<path to image>" http://localhost:8080/build-template-img?start=0
+//
+// POST /build-template-img
+func (c *Client) BuildTemplateImg(ctx context.Context, params BuildTemplateImgParams, form BuildTemplateImgForm) error {
+	query := url.Values{}
+	if params.Start != nil {
+		query.Set("start", strconv.Itoa(*params.Start))
+	}
+	reqBody := formBody(map[string]string{}, map[string]File{"image": form.Image})
+	return c.empty(ctx, http.MethodPost, "/build-template-img", query, reqBody)
+}
+
+// Parameters of CanvasDiff
+type CanvasDiffParams struct {
+	WorldId string
+	Round   string
+	From    *int
+	To      *int
+	Scale   *int
+	Grid    string
+	Labels  string
+	Crop    string
+	TokenId string
+	Format  string
+}
+
+// Changes between the canvas at from and the canvas at to ( or now if to is not set ), both rebuilt from the history
+// tokenId restricts the diff to an NFT's region of the main canvas, from defaulting to the NFT's mint
+// ex: /canvas-diff?worldId=13&from=1700000000&to=1700003600&format=png&scale=4
+//
+// GET /canvas-diff
+func (c *Client) CanvasDiff(ctx context.Context, params CanvasDiffParams) (*Binary, error) {
+	query := url.Values{}
+	if params.WorldId != "" {
+		query.Set("worldId", params.WorldId)
+	}
+	if params.Round != "" {
+		query.Set("round", params.Round)
+	}
+	if params.From != nil {
+		query.Set("from", strconv.Itoa(*params.From))
+	}
+	if params.To != nil {
+		query.Set("to", strconv.Itoa(*params.To))
+	}
+	if params.Scale != nil {
+		query.Set("scale", strconv.Itoa(*params.Scale))
+	}
+	if params.Grid != "" {
+		query.Set("grid", params.Grid)
+	}
+	if params.Labels != "" {
+		query.Set("labels", params.Labels)
+	}
+	if params.Crop != "" {
+		query.Set("crop", params.Crop)
+	}
+	if params.TokenId != "" {
+		query.Set("tokenId", params.TokenId)
+	}
+	if params.Format != "" {
+		query.Set("format", params.Format)
+	}
+	var reqBody requestBody
+	return c.binary(ctx, http.MethodGet, "/canvas-diff", query, reqBody)
+}
+
+// Parameters of CanvasPixelsByPosition
+type CanvasPixelsByPositionParams struct {
+	Position int
+}
+
+// GET /canvas/pixels/{position}
+func (c *Client) CanvasPixelsByPosition(ctx context.Context, params CanvasPixelsByPositionParams) (int, error) {
+	var query url.Values
+	var reqBody requestBody
+	return getData[int](c, ctx, http.MethodGet, "/canvas/pixels/"+url.PathEscape(strconv.Itoa(params.Position)), query, reqBody)
+}
+
+type ChangeUsernameDevnetBody struct {
+	Username string `json:"username,omitempty"`
+}
+
+// POST /change-username-devnet
+func (c *Client) ChangeUsernameDevnet(ctx context.Context, body ChangeUsernameDevnetBody) (string, error) {
+	var query url.Values
+	reqBody := jsonBody(body)
+	return c.result(ctx, http.MethodPost, "/change-username-devnet", query, reqBody)
+}
+
+// Parameters of CheckUsernameUnique
+type CheckUsernameUniqueParams struct {
+	Username string
+}
+
+// GET /check-username-unique
+func (c *Client) CheckUsernameUnique(ctx context.Context, params CheckUsernameUniqueParams) (int, error) {
+	query := url.Values{}
+	if params.Username != "" {
+		query.Set("username", params.Username)
+	}
+	var reqBody requestBody
+	return getData[int](c, ctx, http.MethodGet, "/check-username-unique", query, reqBody)
+}
+
+// Parameters of CheckWorldName
+type CheckWorldNameParams struct {
+	UniqueName string
+}
+
+// GET /check-world-name
+func (c *Client) CheckWorldName(ctx context.Context, params CheckWorldNameParams) (bool, error) {
+	query := url.Values{}
+	if params.UniqueName != "" {
+		query.Set("uniqueName", params.UniqueName)
+	}
+	var reqBody requestBody
+	return getData[bool](c, ctx, http.MethodGet, "/check-world-name", query, reqBody)
+}
+
+type ClaimMainQuestDevnetBody struct {
+	Calldata string `json:"calldata,omitempty"`
+	QuestId  string `json:"questId,omitempty"`
+}
+
+// POST /claim-main-quest-devnet
+func (c *Client) ClaimMainQuestDevnet(ctx context.Context, body ClaimMainQuestDevnetBody) (string, error) {
+	var query url.Values
+	reqBody := jsonBody(body)
+	return c.result(ctx, http.MethodPost, "/claim-main-quest-devnet", query, reqBody)
+}
+
+type ClaimTodayQuestDevnetBody struct {
+	Calldata string `json:"calldata,omitempty"`
+	QuestId  string `json:"questId,omitempty"`
+}
+
+// POST /claim-today-quest-devnet
+func (c *Client) ClaimTodayQuestDevnet(ctx context.Context, body ClaimTodayQuestDevnetBody) (string, error) {
+	var query url.Values
+	reqBody := jsonBody(body)
+	return c.result(ctx, http.MethodPost, "/claim-today-quest-devnet", query, reqBody)
+}
+
+// POST /clear-pixels
+func (c *Client) ClearPixels(ctx context.Context, body ClearPixelsRequest) (string, error) {
+	var query url.Values
+	reqBody := jsonBody(body)
+	return c.result(ctx, http.MethodPost, "/clear-pixels", query, reqBody)
+}
+
+type CreateCanvasDevnetBody struct {
+	ColorPalette      string `json:"color_palette,omitempty"`
+	EndTime           string `json:"end_time,omitempty"`
+	Height            string `json:"height,omitempty"`
+	Host              string `json:"host,omitempty"`
+	Name              string `json:"name,omitempty"`
+	PixelsPerTime     string `json:"pixels_per_time,omitempty"`
+	StartTime         string `json:"start_time,omitempty"`
+	TimeBetweenPixels string `json:"time_between_pixels,omitempty"`
+	UniqueName        string `json:"unique_name,omitempty"`
+	Width             string `json:"width,omitempty"`
+}
+
+// POST /create-canvas-devnet
+func (c *Client) CreateCanvasDevnet(ctx context.Context, body CreateCanvasDevnetBody) (string, error) {
+	var query url.Values
+	reqBody := jsonBody(body)
+	return c.result(ctx, http.MethodPost, "/create-canvas-devnet", query, reqBody)
+}
+
+// Parameters of CreateTimelapse
+type CreateTimelapseParams struct {
+	Format          string
+	WorldId         string
+	Round           string
+	Scale           *int
+	Grid            string
+	Labels          string
+	Crop            string
+	From            *int
+	To              *int
+	PixelsPerFrame  *int
+	SecondsPerFrame *int
+	Delay           *int
+}
+
+type CreateTimelapseData struct {
+	JobId string `json:"jobId"`
+}
+
+// Start rendering a timelapse in the background & return its job id to poll
+// ex: curl -X POST "http://localhost:8080/create-timelapse?worldId=13&format=apng&pixelsPerFrame=100&scale=2"
+//
+// POST /create-timelapse
+func (c *Client) CreateTimelapse(ctx context.Context, params CreateTimelapseParams) (CreateTimelapseData, error) {
+	query := url.Values{}
+	if params.Format != "" {
+		query.Set("format", params.Format)
+	}
+	if params.WorldId != "" {
+		query.Set("worldId", params.WorldId)
+	}
+	if params.Round != "" {
+		query.Set("round", params.Round)
+	}
+	if params.Scale != nil {
+		query.Set("scale", strconv.Itoa(*params.Scale))
+	}
+	if params.Grid != "" {
+		query.Set("grid", params.Grid)
+	}
+	if params.Labels != "" {
+		query.Set("labels", params.Labels)
+	}
+	if params.Crop != "" {
+		query.Set("crop", params.Crop)
+	}
+	if params.From != nil {
+		query.Set("from", strconv.Itoa(*params.From))
+	}
+	if params.To != nil {
+		query.Set("to", strconv.Itoa(*params.To))
+	}
+	if params.PixelsPerFrame != nil {
+		query.Set("pixelsPerFrame", strconv.Itoa(*params.PixelsPerFrame))
+	}
+	if params.SecondsPerFrame != nil {
+		query.Set("secondsPerFrame", strconv.Itoa(*params.SecondsPerFrame))
+	}
+	if params.Delay != nil {
+		query.Set("delay", strconv.Itoa(*params.Delay))
+	}
+	var reqBody requestBody
+	return getData[CreateTimelapseData](c, ctx, http.MethodPost, "/create-timelapse", query, reqBody)
+}
+
+// POST /delete-chat-message
+func (c *Client) DeleteChatMessage(ctx context.Context, body DeleteChatMessageRequest) (string, error) {
+	var query url.Values
+	reqBody := jsonBody(body)
+	return c.result(ctx, http.MethodPost, "/delete-chat-message", query, reqBody)
+}
+
+type DeleteStencilBody struct {
+	Hash string `json:"hash,omitempty"`
+}
+
+// POST /delete-stencil
+func (c *Client) DeleteStencil(ctx context.Context, body DeleteStencilBody) (string, error) {
+	var query url.Values
+	reqBody := jsonBody(body)
+	return c.result(ctx, http.MethodPost, "/delete-stencil", query, reqBody)
+}
+
+// Parameters of ExportCanvas
+type ExportCanvasParams struct {
+	Format  string
+	WorldId string
+	Round   string
+	Scale   *int
+	Grid    string
+	Labels  string
+	Crop    string
+}
+
+// Export a canvas as an image
+// ex: /export-canvas?worldId=13&format=png&scale=4&crop=0,0,64,64&grid=true&labels=true
+//
+// GET /export-canvas
+func (c *Client) ExportCanvas(ctx context.Context, params ExportCanvasParams) (*Binary, error) {
+	query := url.Values{}
+	if params.Format != "" {
+		query.Set("format", params.Format)
+	}
+	if params.WorldId != "" {
+		query.Set("worldId", params.WorldId)
+	}
+	if params.Round != "" {
+		query.Set("round", params.Round)
+	}
+	if params.Scale != nil {
+		query.Set("scale", strconv.Itoa(*params.Scale))
+	}
+	if params.Grid != "" {
+		query.Set("grid", params.Grid)
+	}
+	if params.Labels != "" {
+		query.Set("labels", params.Labels)
+	}
+	if params.Crop != "" {
+		query.Set("crop", params.Crop)
+	}
+	var reqBody requestBody
+	return c.binary(ctx, http.MethodGet, "/export-canvas", query, reqBody)
+}
+
+type FavoriteStencilDevnetBody struct {
+	StencilId string `json:"stencilId,omitempty"`
+	WorldId   string `json:"worldId,omitempty"`
+}
+
+// POST /favorite-stencil-devnet
+func (c *Client) FavoriteStencilDevnet(ctx context.Context, body FavoriteStencilDevnetBody) (string, error) {
+	var query url.Values
+	reqBody := jsonBody(body)
+	return c.result(ctx, http.MethodPost, "/favorite-stencil-devnet", query, reqBody)
+}
+
+type FavoriteWorldDevnetBody struct {
+	WorldId string `json:"worldId,omitempty"`
+}
+
+// POST /favorite-world-devnet
+func (c *Client) FavoriteWorldDevnet(ctx context.Context, body FavoriteWorldDevnetBody) (string, error) {
+	var query url.Values
+	reqBody := jsonBody(body)
+	return c.result(ctx, http.MethodPost, "/favorite-world-devnet", query, reqBody)
+}
+
+// Parameters of GetAuthNonce
+type GetAuthNonceParams struct {
+	Address string
+}
+
+// GET /get-auth-nonce
+func (c *Client) GetAuthNonce(ctx context.Context, params GetAuthNonceParams) (LoginTypedData, error) {
+	query := url.Values{}
+	if params.Address != "" {
+		query.Set("address", params.Address)
+	}
+	var reqBody requestBody
+	return getData[LoginTypedData](c, ctx, http.MethodGet, "/get-auth-nonce", query, reqBody)
+}
+
+// Parameters of GetCanvas
+type GetCanvasParams struct {
+	Round string
+}
+
+// GET /get-canvas
+func (c *Client) GetCanvas(ctx context.Context, params GetCanvasParams) (*Binary, error) {
+	query := url.Values{}
+	if params.Round != "" {
+		query.Set("round", params.Round)
+	}
+	var reqBody requestBody
+	return c.binary(ctx, http.MethodGet, "/get-canvas", query, reqBody)
+}
+
+// GET /get-canvas-nft-address
+func (c *Client) GetCanvasNftAddress(ctx context.Context) (string, error) {
+	var query url.Values
+	var reqBody requestBody
+	return getData[string](c, ctx, http.MethodGet, "/get-canvas-nft-address", query, reqBody)
+}
+
+// Parameters of GetChainFactionMembers
+type GetChainFactionMembersParams struct {
+	FactionId  *int
+	Page       *int
+	PageLength *int
+	Cursor     string
+}
+
+// GET /get-chain-faction-members
+func (c *Client) GetChainFactionMembers(ctx context.Context, params GetChainFactionMembersParams) (*Page[FactionMemberData], error) {
+	query := url.Values{}
+	if params.FactionId != nil {
+		query.Set("factionId", strconv.Itoa(*params.FactionId))
+	}
+	if params.Page != nil {
+		query.Set("page", strconv.Itoa(*params.Page))
+	}
+	if params.PageLength != nil {
+		query.Set("pageLength", strconv.Itoa(*params.PageLength))
+	}
+	if params.Cursor != "" {
+		query.Set("cursor", params.Cursor)
+	}
+	var reqBody requestBody
+	return getPage[FactionMemberData](c, ctx, http.MethodGet, "/get-chain-faction-members", query, reqBody)
+}
+
+// Parameters of GetChainFactionPixels
+type GetChainFactionPixelsParams struct {
+	Address string
+}
+
+// GET /get-chain-faction-pixels
+func (c *Client) GetChainFactionPixels(ctx context.Context, params GetChainFactionPixelsParams) ([]MembershipPixelsData, error) {
+	query := url.Values{}
+	if params.Address != "" {
+		query.Set("address", params.Address)
+	}
+	var reqBody requestBody
+	return getData[[]MembershipPixelsData](c, ctx, http.MethodGet, "/get-chain-faction-pixels", query, reqBody)
+}
+
+// Parameters of GetChainFactionTemplates
+type GetChainFactionTemplatesParams struct {
+	FactionId *int
+}
+
+// GET /get-chain-faction-templates
+func (c *Client) GetChainFactionTemplates(ctx context.Context, params GetChainFactionTemplatesParams) ([]FactionTemplateData, error) {
+	query := url.Values{}
+	if params.FactionId != nil {
+		query.Set("factionId", strconv.Itoa(*params.FactionId))
+	}
+	var reqBody requestBody
+	return getData[[]FactionTemplateData](c, ctx, http.MethodGet, "/get-chain-faction-templates", query, reqBody)
+}
+
+// Parameters of GetChainFactions
+type GetChainFactionsParams struct {
+	Address string
+}
+
+// GET /get-chain-factions
+func (c *Client) GetChainFactions(ctx context.Context, params GetChainFactionsParams) ([]FactionData, error) {
+	query := url.Values{}
+	if params.Address != "" {
+		query.Set("address", params.Address)
+	}
+	var reqBody requestBody
+	return getData[[]FactionData](c, ctx, http.MethodGet, "/get-chain-factions", query, reqBody)
+}
+
+// Parameters of GetChatMessages
+type GetChatMessagesParams struct {
+	WorldId    *int
+	Page       *int
+	PageLength *int
+	Cursor     string
+}
+
+// ex: /get-chat-messages?worldId=13&page=1&pageLength=25
+// Newest messages first
+//
+// GET /get-chat-messages
+func (c *Client) GetChatMessages(ctx context.Context, params GetChatMessagesParams) (*Page[ChatMessage], error) {
+	query := url.Values{}
+	if params.WorldId != nil {
+		query.Set("worldId", strconv.Itoa(*params.WorldId))
+	}
+	if params.Page != nil {
+		query.Set("page", strconv.Itoa(*params.Page))
+	}
+	if params.PageLength != nil {
+		query.Set("pageLength", strconv.Itoa(*params.PageLength))
+	}
+	if params.Cursor != "" {
+		query.Set("cursor", params.Cursor)
+	}
+	var reqBody requestBody
+	return getPage[ChatMessage](c, ctx, http.MethodGet, "/get-chat-messages", query, reqBody)
+}
+
+// Parameters of GetColor
+type GetColorParams struct {
+	Id string
+}
+
+// GET /get-color
+func (c *Client) GetColor(ctx context.Context, params GetColorParams) (string, error) {
+	query := url.Values{}
+	if params.Id != "" {
+		query.Set("id", params.Id)
+	}
+	var reqBody requestBody
+	return getData[string](c, ctx, http.MethodGet, "/get-color", query, reqBody)
+}
+
+// GET /get-colors
+func (c *Client) GetColors(ctx context.Context) ([]string, error) {
+	var query url.Values
+	var reqBody requestBody
+	return getData[[]string](c, ctx, http.MethodGet, "/get-colors", query, reqBody)
+}
+
+// Parameters of GetCompletedDailyQuests
+type GetCompletedDailyQuestsParams struct {
+	Address string
+}
+
+// GET /get-completed-daily-quests
+func (c *Client) GetCompletedDailyQuests(ctx context.Context, params GetCompletedDailyQuestsParams) ([]DailyQuest, error) {
+	query := url.Values{}
+	if params.Address != "" {
+		query.Set("address", params.Address)
+	}
+	var reqBody requestBody
+	return getData[[]DailyQuest](c, ctx, http.MethodGet, "/get-completed-daily-quests", query, reqBody)
+}
+
+// Parameters of GetCompletedMainQuests
+type GetCompletedMainQuestsParams struct {
+	Address string
+}
+
+// GET /get-completed-main-quests
+func (c *Client) GetCompletedMainQuests(ctx context.Context, params GetCompletedMainQuestsParams) ([]MainQuest, error) {
+	query := url.Values{}
+	if params.Address != "" {
+		query.Set("address", params.Address)
+	}
+	var reqBody requestBody
+	return getData[[]MainQuest](c, ctx, http.MethodGet, "/get-completed-main-quests", query, reqBody)
+}
+
+// GET /get-contract-address
+func (c *Client) GetContractAddress(ctx context.Context) (string, error) {
+	var query url.Values
+	var reqBody requestBody
+	return getData[string](c, ctx, http.MethodGet, "/get-contract-address", query, reqBody)
+}
+
+// Parameters of GetDailyQuestProgress
+type GetDailyQuestProgressParams struct {
+	Address  string
+	DayIndex *int
+}
+
+// GET /get-daily-quest-progress
+func (c *Client) GetDailyQuestProgress(ctx context.Context, params GetDailyQuestProgressParams) ([]QuestProgress, error) {
+	query := url.Values{}
+	if params.Address != "" {
+		query.Set("address", params.Address)
+	}
+	if params.DayIndex != nil {
+		query.Set("dayIndex", strconv.Itoa(*params.DayIndex))
+	}
+	var reqBody requestBody
+	return getData[[]QuestProgress](c, ctx, http.MethodGet, "/get-daily-quest-progress", query, reqBody)
+}
+
+// GET /get-daily-quests
+func (c *Client) GetDailyQuests(ctx context.Context) ([]DailyQuest, error) {
+	var query url.Values
+	var reqBody requestBody
+	return getData[[]DailyQuest](c, ctx, http.MethodGet, "/get-daily-quests", query, reqBody)
+}
+
+// Parameters of GetExtraPixels
+type GetExtraPixelsParams struct {
+	Address string
+}
+
+// GET /get-extra-pixels
+func (c *Client) GetExtraPixels(ctx context.Context, params GetExtraPixelsParams) (int, error) {
+	query := url.Values{}
+	if params.Address != "" {
+		query.Set("address", params.Address)
+	}
+	var reqBody requestBody
+	return getData[int](c, ctx, http.MethodGet, "/get-extra-pixels", query, reqBody)
+}
+
+// Parameters of GetFactionMembers
+type GetFactionMembersParams struct {
+	FactionId  *int
+	Page       *int
+	PageLength *int
+	Cursor     string
+}
+
+// GET /get-faction-members
+func (c *Client) GetFactionMembers(ctx context.Context, params GetFactionMembersParams) (*Page[FactionMemberData], error) {
+	query := url.Values{}
+	if params.FactionId != nil {
+		query.Set("factionId", strconv.Itoa(*params.FactionId))
+	}
+	if params.Page != nil {
+		query.Set("page", strconv.Itoa(*params.Page))
+	}
+	if params.PageLength != nil {
+		query.Set("pageLength", strconv.Itoa(*params.PageLength))
+	}
+	if params.Cursor != "" {
+		query.Set("cursor", params.Cursor)
+	}
+	var reqBody requestBody
+	return getPage[FactionMemberData](c, ctx, http.MethodGet, "/get-faction-members", query, reqBody)
+}
+
+// Parameters of GetFactionPixels
+type GetFactionPixelsParams struct {
+	Address string
+}
+
+// GET /get-faction-pixels
+func (c *Client) GetFactionPixels(ctx context.Context, params GetFactionPixelsParams) ([]MembershipPixelsData, error) {
+	query := url.Values{}
+	if params.Address != "" {
+		query.Set("address", params.Address)
+	}
+	var reqBody requestBody
+	return getData[[]MembershipPixelsData](c, ctx, http.MethodGet, "/get-faction-pixels", query, reqBody)
+}
+
+// Parameters of GetFactionTemplates
+type GetFactionTemplatesParams struct {
+	FactionId *int
+}
+
+// TODO: Pagination
+//
+// GET /get-faction-templates
+func (c *Client) GetFactionTemplates(ctx context.Context, params GetFactionTemplatesParams) ([]FactionTemplateData, error) {
+	query := url.Values{}
+	if params.FactionId != nil {
+		query.Set("factionId", strconv.Itoa(*params.FactionId))
+	}
+	var reqBody requestBody
+	return getData[[]FactionTemplateData](c, ctx, http.MethodGet, "/get-faction-templates", query, reqBody)
+}
+
+// Parameters of GetFactions
+type GetFactionsParams struct {
+	Address    string
+	Page       *int
+	PageLength *int
+	Cursor     string
+}
+
+// GET /get-factions
+func (c *Client) GetFactions(ctx context.Context, params GetFactionsParams) (*Page[FactionData], error) {
+	query := url.Values{}
+	if params.Address != "" {
+		query.Set("address", params.Address)
+	}
+	if params.Page != nil {
+		query.Set("page", strconv.Itoa(*params.Page))
+	}
+	if params.PageLength != nil {
+		query.Set("pageLength", strconv.Itoa(*params.PageLength))
+	}
+	if params.Cursor != "" {
+		query.Set("cursor", params.Cursor)
+	}
+	var reqBody requestBody
+	return getPage[FactionData](c, ctx, http.MethodGet, "/get-factions", query, reqBody)
+}
+
+// GET /get-factory-contract-address
+func (c *Client) GetFactoryContractAddress(ctx context.Context) (string, error) {
+	var query url.Values
+	var reqBody requestBody
+	return getData[string](c, ctx, http.MethodGet, "/get-factory-contract-address", query, reqBody)
+}
+
+// Parameters of GetFavoriteStencils
+type GetFavoriteStencilsParams struct {
+	WorldId    *int
+	Address    string
+	Page       *int
+	PageLength *int
+	Cursor     string
+}
+
+// GET /get-favorite-stencils
+func (c *Client) GetFavoriteStencils(ctx context.Context, params GetFavoriteStencilsParams) (*Page[StencilData], error) {
+	query := url.Values{}
+	if params.WorldId != nil {
+		query.Set("worldId", strconv.Itoa(*params.WorldId))
+	}
+	if params.Address != "" {
+		query.Set("address", params.Address)
+	}
+	if params.Page != nil {
+		query.Set("page", strconv.Itoa(*params.Page))
+	}
+	if params.PageLength != nil {
+		query.Set("pageLength", strconv.Itoa(*params.PageLength))
+	}
+	if params.Cursor != "" {
+		query.Set("cursor", params.Cursor)
+	}
+	var reqBody requestBody
+	return getPage[StencilData](c, ctx, http.MethodGet, "/get-favorite-stencils", query, reqBody)
+}
+
+// Parameters of GetFavoriteWorlds
+type GetFavoriteWorldsParams struct {
+	Address    string
+	Page       *int
+	PageLength *int
+	Cursor     string
+}
+
+// GET /get-favorite-worlds
+func (c *Client) GetFavoriteWorlds(ctx context.Context, params GetFavoriteWorldsParams) (*Page[WorldData], error) {
+	query := url.Values{}
+	if params.Address != "" {
+		query.Set("address", params.Address)
+	}
+	if params.Page != nil {
+		query.Set("page", strconv.Itoa(*params.Page))
+	}
+	if params.PageLength != nil {
+		query.Set("pageLength", strconv.Itoa(*params.PageLength))
+	}
+	if params.Cursor != "" {
+		query.Set("cursor", params.Cursor)
+	}
+	var reqBody requestBody
+	return getPage[WorldData](c, ctx, http.MethodGet, "/get-favorite-worlds", query, reqBody)
+}
+
+// GET /get-game-data
+func (c *Client) GetGameData(ctx context.Context) (GameData, error) {
+	var query url.Values
+	var reqBody requestBody
+	return getData[GameData](c, ctx, http.MethodGet, "/get-game-data", query, reqBody)
+}
+
+// Parameters of GetHomeWorlds
+type GetHomeWorldsParams struct {
+	Address string
+}
+
+// GET /get-home-worlds
+func (c *Client) GetHomeWorlds(ctx context.Context, params GetHomeWorldsParams) ([]WorldData, error) {
+	query := url.Values{}
+	if params.Address != "" {
+		query.Set("address", params.Address)
+	}
+	var reqBody requestBody
+	return getData[[]WorldData](c, ctx, http.MethodGet, "/get-home-worlds", query, reqBody)
+}
+
+// Parameters of GetHotNfts
+type GetHotNftsParams struct {
+	Address    string
+	HotLimit   *int
+	Page       *int
+	PageLength *int
+	Cursor     string
+}
+
+// GET /get-hot-nfts
+func (c *Client) GetHotNfts(ctx context.Context, params GetHotNftsParams) (*Page[NFTData], error) {
+	query := url.Values{}
+	if params.Address != "" {
+		query.Set("address", params.Address)
+	}
+	if params.HotLimit != nil {
+		query.Set("hotLimit", strconv.Itoa(*params.HotLimit))
+	}
+	if params.Page != nil {
+		query.Set("page", strconv.Itoa(*params.Page))
+	}
+	if params.PageLength != nil {
+		query.Set("pageLength", strconv.Itoa(*params.PageLength))
+	}
+	if params.Cursor != "" {
+		query.Set("cursor", params.Cursor)
+	}
+	var reqBody requestBody
+	return getPage[NFTData](c, ctx, http.MethodGet, "/get-hot-nfts", query, reqBody)
+}
+
+// Parameters of GetHotStencils
+type GetHotStencilsParams struct {
+	WorldId    *int
+	Address    string
+	HotLimit   *int
+	Page       *int
+	PageLength *int
+	Cursor     string
+}
+
+// GET /get-hot-stencils
+func (c *Client) GetHotStencils(ctx context.Context, params GetHotStencilsParams) (*Page[StencilData], error) {
+	query := url.Values{}
+	if params.WorldId != nil {
+		query.Set("worldId", strconv.Itoa(*params.WorldId))
+	}
+	if params.Address != "" {
+		query.Set("address", params.Address)
+	}
+	if params.HotLimit != nil {
+		query.Set("hotLimit", strconv.Itoa(*params.HotLimit))
+	}
+	if params.Page != nil {
+		query.Set("page", strconv.Itoa(*params.Page))
+	}
+	if params.PageLength != nil {
+		query.Set("pageLength", strconv.Itoa(*params.PageLength))
+	}
+	if params.Cursor != "" {
+		query.Set("cursor", params.Cursor)
+	}
+	var reqBody requestBody
+	return getPage[StencilData](c, ctx, http.MethodGet, "/get-hot-stencils", query, reqBody)
+}
+
+// Parameters of GetHotWorlds
+type GetHotWorldsParams struct {
+	Address    string
+	HotLimit   *int
+	Page       *int
+	PageLength *int
+	Cursor     string
+}
+
+// GET /get-hot-worlds
+func (c *Client) GetHotWorlds(ctx context.Context, params GetHotWorldsParams) (*Page[WorldData], error) {
+	query := url.Values{}
+	if params.Address != "" {
+		query.Set("address", params.Address)
+	}
+	if params.HotLimit != nil {
+		query.Set("hotLimit", strconv.Itoa(*params.HotLimit))
+	}
+	if params.Page != nil {
+		query.Set("page", strconv.Itoa(*params.Page))
+	}
+	if params.PageLength != nil {
+		query.Set("pageLength", strconv.Itoa(*params.PageLength))
+	}
+	if params.Cursor != "" {
+		query.Set("cursor", params.Cursor)
+	}
+	var reqBody requestBody
+	return getPage[WorldData](c, ctx, http.MethodGet, "/get-hot-worlds", query, reqBody)
+}
+
+// Parameters of GetLastPlacedTime
+type GetLastPlacedTimeParams struct {
+	Address string
+}
+
+// GET /get-last-placed-time
+func (c *Client) GetLastPlacedTime(ctx context.Context, params GetLastPlacedTimeParams) (string, error) {
+	query := url.Values{}
+	if params.Address != "" {
+		query.Set("address", params.Address)
+	}
+	var reqBody requestBody
+	return getData[string](c, ctx, http.MethodGet, "/get-last-placed-time", query, reqBody)
+}
+
+// Parameters of GetLikedNfts
+type GetLikedNftsParams struct {
+	Address    string
+	Page       *int
+	PageLength *int
+	Cursor     string
+}
+
+// GET /get-liked-nfts
+func (c *Client) GetLikedNfts(ctx context.Context, params GetLikedNftsParams) (*Page[NFTData], error) {
+	query := url.Values{}
+	if params.Address != "" {
+		query.Set("address", params.Address)
+	}
+	if params.Page != nil {
+		query.Set("page", strconv.Itoa(*params.Page))
+	}
+	if params.PageLength != nil {
+		query.Set("pageLength", strconv.Itoa(*params.PageLength))
+	}
+	if params.Cursor != "" {
+		query.Set("cursor", params.Cursor)
+	}
+	var reqBody requestBody
+	return getPage[NFTData](c, ctx, http.MethodGet, "/get-liked-nfts", query, reqBody)
+}
+
+// Parameters of GetMainQuestProgress
+type GetMainQuestProgressParams struct {
+	Address string
+}
+
+// GET /get-main-quest-progress
+func (c *Client) GetMainQuestProgress(ctx context.Context, params GetMainQuestProgressParams) ([]QuestProgress, error) {
+	query := url.Values{}
+	if params.Address != "" {
+		query.Set("address", params.Address)
+	}
+	var reqBody requestBody
+	return getData[[]QuestProgress](c, ctx, http.MethodGet, "/get-main-quest-progress", query, reqBody)
+}
+
+// GET /get-main-quests
+func (c *Client) GetMainQuests(ctx context.Context) ([]MainQuest, error) {
+	var query url.Values
+	var reqBody requestBody
+	return getData[[]MainQuest](c, ctx, http.MethodGet, "/get-main-quests", query, reqBody)
+}
+
+// Parameters of GetMainUserQuests
+type GetMainUserQuestsParams struct {
+	Address string
+}
+
+// GET /get-main-user-quests
+func (c *Client) GetMainUserQuests(ctx context.Context, params GetMainUserQuestsParams) ([]MainUserQuest, error) {
+	query := url.Values{}
+	if params.Address != "" {
+		query.Set("address", params.Address)
+	}
+	var reqBody requestBody
+	return getData[[]MainUserQuest](c, ctx, http.MethodGet, "/get-main-user-quests", query, reqBody)
+}
+
+// Parameters of GetMyChainFactions
+type GetMyChainFactionsParams struct {
+	Address string
+}
+
+// GET /get-my-chain-factions
+func (c *Client) GetMyChainFactions(ctx context.Context, params GetMyChainFactionsParams) ([]FactionData, error) {
+	query := url.Values{}
+	if params.Address != "" {
+		query.Set("address", params.Address)
+	}
+	var reqBody requestBody
+	return getData[[]FactionData](c, ctx, http.MethodGet, "/get-my-chain-factions", query, reqBody)
+}
+
+// Parameters of GetMyFactions
+type GetMyFactionsParams struct {
+	Address string
+}
+
+// GET /get-my-factions
+func (c *Client) GetMyFactions(ctx context.Context, params GetMyFactionsParams) ([]FactionUserData, error) {
+	query := url.Values{}
+	if params.Address != "" {
+		query.Set("address", params.Address)
+	}
+	var reqBody requestBody
+	return getData[[]FactionUserData](c, ctx, http.MethodGet, "/get-my-factions", query, reqBody)
+}
+
+// Parameters of GetMyNfts
+type GetMyNftsParams struct {
+	Address    string
+	Page       *int
+	PageLength *int
+	Cursor     string
+}
+
+// GET /get-my-nfts
+func (c *Client) GetMyNfts(ctx context.Context, params GetMyNftsParams) (*Page[NFTData], error) {
+	query := url.Values{}
+	if params.Address != "" {
+		query.Set("address", params.Address)
+	}
+	if params.Page != nil {
+		query.Set("page", strconv.Itoa(*params.Page))
+	}
+	if params.PageLength != nil {
+		query.Set("pageLength", strconv.Itoa(*params.PageLength))
+	}
+	if params.Cursor != "" {
+		query.Set("cursor", params.Cursor)
+	}
+	var reqBody requestBody
+	return getPage[NFTData](c, ctx, http.MethodGet, "/get-my-nfts", query, reqBody)
+}
+
+// Parameters of GetNewNfts
+type GetNewNftsParams struct {
+	Address    string
+	Page       *int
+	PageLength *int
+	Cursor     string
+}
+
+// GET /get-new-nfts
+func (c *Client) GetNewNfts(ctx context.Context, params GetNewNftsParams) (*Page[NFTData], error) {
+	query := url.Values{}
+	if params.Address != "" {
+		query.Set("address", params.Address)
+	}
+	if params.Page != nil {
+		query.Set("page", strconv.Itoa(*params.Page))
+	}
+	if params.PageLength != nil {
+		query.Set("pageLength", strconv.Itoa(*params.PageLength))
+	}
+	if params.Cursor != "" {
+		query.Set("cursor", params.Cursor)
+	}
+	var reqBody requestBody
+	return getPage[NFTData](c, ctx, http.MethodGet, "/get-new-nfts", query, reqBody)
+}
+
+// Parameters of GetNewStencils
+type GetNewStencilsParams struct {
+	WorldId    *int
+	Address    string
+	Page       *int
+	PageLength *int
+	Cursor     string
+}
+
+// GET /get-new-stencils
+func (c *Client) GetNewStencils(ctx context.Context, params GetNewStencilsParams) (*Page[StencilData], error) {
+	query := url.Values{}
+	if params.WorldId != nil {
+		query.Set("worldId", strconv.Itoa(*params.WorldId))
+	}
+	if params.Address != "" {
+		query.Set("address", params.Address)
+	}
+	if params.Page != nil {
+		query.Set("page", strconv.Itoa(*params.Page))
+	}
+	if params.PageLength != nil {
+		query.Set("pageLength", strconv.Itoa(*params.PageLength))
+	}
+	if params.Cursor != "" {
+		query.Set("cursor", params.Cursor)
+	}
+	var reqBody requestBody
+	return getPage[StencilData](c, ctx, http.MethodGet, "/get-new-stencils", query, reqBody)
+}
+
+// Parameters of GetNewWorlds
+type GetNewWorldsParams struct {
+	Address    string
+	Page       *int
+	PageLength *int
+	Cursor     string
+}
+
+// GET /get-new-worlds
+func (c *Client) GetNewWorlds(ctx context.Context, params GetNewWorldsParams) (*Page[WorldData], error) {
+	query := url.Values{}
+	if params.Address != "" {
+		query.Set("address", params.Address)
+	}
+	if params.Page != nil {
+		query.Set("page", strconv.Itoa(*params.Page))
+	}
+	if params.PageLength != nil {
+		query.Set("pageLength", strconv.Itoa(*params.PageLength))
+	}
+	if params.Cursor != "" {
+		query.Set("cursor", params.Cursor)
+	}
+	var reqBody requestBody
+	return getPage[WorldData](c, ctx, http.MethodGet, "/get-new-worlds", query, reqBody)
+}
+
+// Parameters of GetNft
+type GetNftParams struct {
+	TokenId string
+}
+
+// GET /get-nft
+func (c *Client) GetNft(ctx context.Context, params GetNftParams) (NFTData, error) {
+	query := url.Values{}
+	if params.TokenId != "" {
+		query.Set("tokenId", params.TokenId)
+	}
+	var reqBody requestBody
+	return getData[NFTData](c, ctx, http.MethodGet, "/get-nft", query, reqBody)
+}
+
+// Parameters of GetNftLikes
+type GetNftLikesParams struct {
+	NftKey string
+}
+
+// GET /get-nft-likes
+func (c *Client) GetNftLikes(ctx context.Context, params GetNftLikesParams) (int, error) {
+	query := url.Values{}
+	if params.NftKey != "" {
+		query.Set("nft_key", params.NftKey)
+	}
+	var reqBody requestBody
+	return getData[int](c, ctx, http.MethodGet, "/get-nft-likes", query, reqBody)
+}
+
+// Parameters of GetNftPixelData
+type GetNftPixelDataParams struct {
+	TokenId string
+}
+
+type GetNftPixelDataData struct {
+	Width     int   `json:"width"`
+	Height    int   `json:"height"`
+	PixelData []int `json:"pixelData"`
+}
+
+// GET /get-nft-pixel-data
+func (c *Client) GetNftPixelData(ctx context.Context, params GetNftPixelDataParams) (GetNftPixelDataData, error) {
+	query := url.Values{}
+	if params.TokenId != "" {
+		query.Set("tokenId", params.TokenId)
+	}
+	var reqBody requestBody
+	return getData[GetNftPixelDataData](c, ctx, http.MethodGet, "/get-nft-pixel-data", query, reqBody)
+}
+
+// Parameters of GetNfts
+type GetNftsParams struct {
+	Address    string
+	Page       *int
+	PageLength *int
+	Cursor     string
+}
+
+// GET /get-nfts
+func (c *Client) GetNfts(ctx context.Context, params GetNftsParams) (*Page[NFTData], error) {
+	query := url.Values{}
+	if params.Address != "" {
+		query.Set("address", params.Address)
+	}
+	if params.Page != nil {
+		query.Set("page", strconv.Itoa(*params.Page))
+	}
+	if params.PageLength != nil {
+		query.Set("pageLength", strconv.Itoa(*params.PageLength))
+	}
+	if params.Cursor != "" {
+		query.Set("cursor", params.Cursor)
+	}
+	var reqBody requestBody
+	return getPage[NFTData](c, ctx, http.MethodGet, "/get-nfts", query, reqBody)
+}
+
+// Parameters of GetPixel
+type GetPixelParams struct {
+	Position *int
+}
+
+// GET /get-pixel
+func (c *Client) GetPixel(ctx context.Context, params GetPixelParams) (int, error) {
+	query := url.Values{}
+	if params.Position != nil {
+		query.Set("position", strconv.Itoa(*params.Position))
+	}
+	var reqBody requestBody
+	return getData[int](c, ctx, http.MethodGet, "/get-pixel", query, reqBody)
+}
+
+// Parameters of GetPixelCount
+type GetPixelCountParams struct {
+	Address string
+}
+
+// GET /get-pixel-count
+func (c *Client) GetPixelCount(ctx context.Context, params GetPixelCountParams) (int, error) {
+	query := url.Values{}
+	if params.Address != "" {
+		query.Set("address", params.Address)
+	}
+	var reqBody requestBody
+	return getData[int](c, ctx, http.MethodGet, "/get-pixel-count", query, reqBody)
+}
+
+// Parameters of GetPixelInfo
+type GetPixelInfoParams struct {
+	Position *int
+}
+
+// GET /get-pixel-info
+func (c *Client) GetPixelInfo(ctx context.Context, params GetPixelInfoParams) (string, error) {
+	query := url.Values{}
+	if params.Position != nil {
+		query.Set("position", strconv.Itoa(*params.Position))
+	}
+	var reqBody requestBody
+	return getData[string](c, ctx, http.MethodGet, "/get-pixel-info", query, reqBody)
+}
+
+// Parameters of GetPixelRollbacks
+type GetPixelRollbacksParams struct {
+	WorldId *int
+}
+
+// GET /get-pixel-rollbacks
+func (c *Client) GetPixelRollbacks(ctx context.Context, params GetPixelRollbacksParams) ([]PixelRollback, error) {
+	query := url.Values{}
+	if params.WorldId != nil {
+		query.Set("worldId", strconv.Itoa(*params.WorldId))
+	}
+	var reqBody requestBody
+	return getData[[]PixelRollback](c, ctx, http.MethodGet, "/get-pixel-rollbacks", query, reqBody)
+}
+
+// Parameters of GetProtectedRegionViolations
+type GetProtectedRegionViolationsParams struct {
+	WorldId    *int
+	Page       *int
+	PageLength *int
+	Cursor     string
+}
+
+// GET /get-protected-region-violations
+func (c *Client) GetProtectedRegionViolations(ctx context.Context, params GetProtectedRegionViolationsParams) (*Page[ProtectedRegionViolation], error) {
+	query := url.Values{}
+	if params.WorldId != nil {
+		query.Set("worldId", strconv.Itoa(*params.WorldId))
+	}
+	if params.Page != nil {
+		query.Set("page", strconv.Itoa(*params.Page))
+	}
+	if params.PageLength != nil {
+		query.Set("pageLength", strconv.Itoa(*params.PageLength))
+	}
+	if params.Cursor != "" {
+		query.Set("cursor", params.Cursor)
+	}
+	var reqBody requestBody
+	return getPage[ProtectedRegionViolation](c, ctx, http.MethodGet, "/get-protected-region-violations", query, reqBody)
+}
+
+// Parameters of GetProtectedRegions
+type GetProtectedRegionsParams struct {
+	WorldId *int
+	Active  string
+}
+
+// ex: /get-protected-regions?worldId=13&active=true
+//
+// GET /get-protected-regions
+func (c *Client) GetProtectedRegions(ctx context.Context, params GetProtectedRegionsParams) ([]ProtectedRegion, error) {
+	query := url.Values{}
+	if params.WorldId != nil {
+		query.Set("worldId", strconv.Itoa(*params.WorldId))
+	}
+	if params.Active != "" {
+		query.Set("active", params.Active)
+	}
+	var reqBody requestBody
+	return getData[[]ProtectedRegion](c, ctx, http.MethodGet, "/get-protected-regions", query, reqBody)
+}
+
+// GET /get-rounds-config
+func (c *Client) GetRoundsConfig(ctx context.Context) (RoundsConfig, error) {
+	var query url.Values
+	var reqBody requestBody
+	return getData[RoundsConfig](c, ctx, http.MethodGet, "/get-rounds-config", query, reqBody)
+}
+
+// GET /get-session
+func (c *Client) GetSession(ctx context.Context) (SessionResponse, error) {
+	var query url.Values
+	var reqBody requestBody
+	return getData[SessionResponse](c, ctx, http.MethodGet, "/get-session", query, reqBody)
+}
+
+// Parameters of GetStencil
+type GetStencilParams struct {
+	StencilId string
+	WorldId   string
+}
+
+// GET /get-stencil
+func (c *Client) GetStencil(ctx context.Context, params GetStencilParams) (StencilData, error) {
+	query := url.Values{}
+	if params.StencilId != "" {
+		query.Set("stencilId", params.StencilId)
+	}
+	if params.WorldId != "" {
+		query.Set("worldId", params.WorldId)
+	}
+	var reqBody requestBody
+	return getData[StencilData](c, ctx, http.MethodGet, "/get-stencil", query, reqBody)
+}
+
+// Parameters of GetStencilOwner
+type GetStencilOwnerParams struct {
+	StencilId string
+	WorldId   string
+}
+
+// GET /get-stencil-owner
+func (c *Client) GetStencilOwner(ctx context.Context, params GetStencilOwnerParams) (string, error) {
+	query := url.Values{}
+	if params.StencilId != "" {
+		query.Set("stencilId", params.StencilId)
+	}
+	if params.WorldId != "" {
+		query.Set("worldId", params.WorldId)
+	}
+	var reqBody requestBody
+	return getData[string](c, ctx, http.MethodGet, "/get-stencil-owner", query, reqBody)
+}
+
+// Parameters of GetStencilPixelData
+type GetStencilPixelDataParams struct {
+	Hash    string
+	WorldId *int
+}
+
+type GetStencilPixelDataData struct {
+	Width     int   `json:"width"`
+	Height    int   `json:"height"`
+	PixelData []int `json:"pixelData"`
+}
+
+// GET /get-stencil-pixel-data
+func (c *Client) GetStencilPixelData(ctx context.Context, params GetStencilPixelDataParams) (GetStencilPixelDataData, error) {
+	query := url.Values{}
+	if params.Hash != "" {
+		query.Set("hash", params.Hash)
+	}
+	if params.WorldId != nil {
+		query.Set("worldId", strconv.Itoa(*params.WorldId))
+	}
+	var reqBody requestBody
+	return getData[GetStencilPixelDataData](c, ctx, http.MethodGet, "/get-stencil-pixel-data", query, reqBody)
+}
+
+// Parameters of GetStencils
+type GetStencilsParams struct {
+	WorldId    *int
+	Address    string
+	Page       *int
+	PageLength *int
+	Cursor     string
+}
+
+// GET /get-stencils
+func (c *Client) GetStencils(ctx context.Context, params GetStencilsParams) (*Page[StencilData], error) {
+	query := url.Values{}
+	if params.WorldId != nil {
+		query.Set("worldId", strconv.Itoa(*params.WorldId))
+	}
+	if params.Address != "" {
+		query.Set("address", params.Address)
+	}
+	if params.Page != nil {
+		query.Set("page", strconv.Itoa(*params.Page))
+	}
+	if params.PageLength != nil {
+		query.Set("pageLength", strconv.Itoa(*params.PageLength))
+	}
+	if params.Cursor != "" {
+		query.Set("cursor", params.Cursor)
+	}
+	var reqBody requestBody
+	return getPage[StencilData](c, ctx, http.MethodGet, "/get-stencils", query, reqBody)
+}
+
+// Parameters of GetTemplatePixelData
+type GetTemplatePixelDataParams struct {
+	Hash string
+}
+
+type GetTemplatePixelDataData struct {
+	Width     int   `json:"width"`
+	Height    int   `json:"height"`
+	PixelData []int `json:"pixelData"`
+}
+
+// GET /get-template-pixel-data
+func (c *Client) GetTemplatePixelData(ctx context.Context, params GetTemplatePixelDataParams) (GetTemplatePixelDataData, error) {
+	query := url.Values{}
+	if params.Hash != "" {
+		query.Set("hash", params.Hash)
+	}
+	var reqBody requestBody
+	return getData[GetTemplatePixelDataData](c, ctx, http.MethodGet, "/get-template-pixel-data", query, reqBody)
+}
+
+// GET /get-templates
+func (c *Client) GetTemplates(ctx context.Context) ([]TemplateData, error) {
+	var query url.Values
+	var reqBody requestBody
+	return getData[[]TemplateData](c, ctx, http.MethodGet, "/get-templates", query, reqBody)
+}
+
+// Parameters of GetTimelapse
+type GetTimelapseParams struct {
+	JobId string
+}
+
+// GET /get-timelapse
+func (c *Client) GetTimelapse(ctx context.Context, params GetTimelapseParams) (*Binary, error) {
+	query := url.Values{}
+	if params.JobId != "" {
+		query.Set("jobId", params.JobId)
+	}
+	var reqBody requestBody
+	return c.binary(ctx, http.MethodGet, "/get-timelapse", query, reqBody)
+}
+
+// Parameters of GetTimelapseStatus
+type GetTimelapseStatusParams struct {
+	JobId string
+}
+
+// GET /get-timelapse-status
+func (c *Client) GetTimelapseStatus(ctx context.Context, params GetTimelapseStatusParams) (TimelapseJob, error) {
+	query := url.Values{}
+	if params.JobId != "" {
+		query.Set("jobId", params.JobId)
+	}
+	var reqBody requestBody
+	return getData[TimelapseJob](c, ctx, http.MethodGet, "/get-timelapse-status", query, reqBody)
+}
+
+// Parameters of GetTodayQuestProgress
+type GetTodayQuestProgressParams struct {
+	Address string
+}
+
+// GET /get-today-quest-progress
+func (c *Client) GetTodayQuestProgress(ctx context.Context, params GetTodayQuestProgressParams) ([]QuestProgress, error) {
+	query := url.Values{}
+	if params.Address != "" {
+		query.Set("address", params.Address)
+	}
+	var reqBody requestBody
+	return getData[[]QuestProgress](c, ctx, http.MethodGet, "/get-today-quest-progress", query, reqBody)
+}
+
+// GET /get-today-start-time
+func (c *Client) GetTodayStartTime(ctx context.Context) (string, error) {
+	var query url.Values
+	var reqBody requestBody
+	return getData[string](c, ctx, http.MethodGet, "/get-today-start-time", query, reqBody)
+}
+
+// Get today's quests based on the current day index.
+//
+// GET /get-todays-quests
+func (c *Client) GetTodaysQuests(ctx context.Context) ([]DailyQuest, error) {
+	var query url.Values
+	var reqBody requestBody
+	return getData[[]DailyQuest](c, ctx, http.MethodGet, "/get-todays-quests", query, reqBody)
+}
+
+// Parameters of GetTodaysUserQuests
+type GetTodaysUserQuestsParams struct {
+	Address string
+}
+
+// GET /get-todays-user-quests
+func (c *Client) GetTodaysUserQuests(ctx context.Context, params GetTodaysUserQuestsParams) ([]DailyUserQuest, error) {
+	query := url.Values{}
+	if params.Address != "" {
+		query.Set("address", params.Address)
+	}
+	var reqBody requestBody
+	return getData[[]DailyUserQuest](c, ctx, http.MethodGet, "/get-todays-user-quests", query, reqBody)
+}
+
+// Parameters of GetTopNfts
+type GetTopNftsParams struct {
+	Address    string
+	Page       *int
+	PageLength *int
+	Cursor     string
+}
+
+// GET /get-top-nfts
+func (c *Client) GetTopNfts(ctx context.Context, params GetTopNftsParams) (*Page[NFTData], error) {
+	query := url.Values{}
+	if params.Address != "" {
+		query.Set("address", params.Address)
+	}
+	if params.Page != nil {
+		query.Set("page", strconv.Itoa(*params.Page))
+	}
+	if params.PageLength != nil {
+		query.Set("pageLength", strconv.Itoa(*params.PageLength))
+	}
+	if params.Cursor != "" {
+		query.Set("cursor", params.Cursor)
+	}
+	var reqBody requestBody
+	return getPage[NFTData](c, ctx, http.MethodGet, "/get-top-nfts", query, reqBody)
+}
+
+// Parameters of GetTopStencils
+type GetTopStencilsParams struct {
+	WorldId    *int
+	Address    string
+	Page       *int
+	PageLength *int
+	Cursor     string
+}
+
+// GET /get-top-stencils
+func (c *Client) GetTopStencils(ctx context.Context, params GetTopStencilsParams) (*Page[StencilData], error) {
+	query := url.Values{}
+	if params.WorldId != nil {
+		query.Set("worldId", strconv.Itoa(*params.WorldId))
+	}
+	if params.Address != "" {
+		query.Set("address", params.Address)
+	}
+	if params.Page != nil {
+		query.Set("page", strconv.Itoa(*params.Page))
+	}
+	if params.PageLength != nil {
+		query.Set("pageLength", strconv.Itoa(*params.PageLength))
+	}
+	if params.Cursor != "" {
+		query.Set("cursor", params.Cursor)
+	}
+	var reqBody requestBody
+	return getPage[StencilData](c, ctx, http.MethodGet, "/get-top-stencils", query, reqBody)
+}
+
+// Parameters of GetTopWorlds
+type GetTopWorldsParams struct {
+	Address    string
+	Page       *int
+	PageLength *int
+	Cursor     string
+}
+
+// GET /get-top-worlds
+func (c *Client) GetTopWorlds(ctx context.Context, params GetTopWorldsParams) (*Page[WorldData], error) {
+	query := url.Values{}
+	if params.Address != "" {
+		query.Set("address", params.Address)
+	}
+	if params.Page != nil {
+		query.Set("page", strconv.Itoa(*params.Page))
+	}
+	if params.PageLength != nil {
+		query.Set("pageLength", strconv.Itoa(*params.PageLength))
+	}
+	if params.Cursor != "" {
+		query.Set("cursor", params.Cursor)
+	}
+	var reqBody requestBody
+	return getPage[WorldData](c, ctx, http.MethodGet, "/get-top-worlds", query, reqBody)
+}
+
+// Parameters of GetUserQuestStatus
+type GetUserQuestStatusParams struct {
+	Address  string
+	Type     string
+	QuestId  *int
+	DayIndex *int
+}
+
+// GET /get-user-quest-status
+func (c *Client) GetUserQuestStatus(ctx context.Context, params GetUserQuestStatusParams) (QuestStatus, error) {
+	query := url.Values{}
+	if params.Address != "" {
+		query.Set("address", params.Address)
+	}
+	if params.Type != "" {
+		query.Set("type", params.Type)
+	}
+	if params.QuestId != nil {
+		query.Set("questId", strconv.Itoa(*params.QuestId))
+	}
+	if params.DayIndex != nil {
+		query.Set("dayIndex", strconv.Itoa(*params.DayIndex))
+	}
+	var reqBody requestBody
+	return getData[QuestStatus](c, ctx, http.MethodGet, "/get-user-quest-status", query, reqBody)
+}
+
+// Parameters of GetUserRewards
+type GetUserRewardsParams struct {
+	Address string
+}
+
+// GET /get-user-rewards
+func (c *Client) GetUserRewards(ctx context.Context, params GetUserRewardsParams) ([]UserRewardsData, error) {
+	query := url.Values{}
+	if params.Address != "" {
+		query.Set("address", params.Address)
+	}
+	var reqBody requestBody
+	return getData[[]UserRewardsData](c, ctx, http.MethodGet, "/get-user-rewards", query, reqBody)
+}
+
+// Parameters of GetUserVote
+type GetUserVoteParams struct {
+	Address string
+}
+
+// GET /get-user-vote
+func (c *Client) GetUserVote(ctx context.Context, params GetUserVoteParams) (int, error) {
+	query := url.Values{}
+	if params.Address != "" {
+		query.Set("address", params.Address)
+	}
+	var reqBody requestBody
+	return getData[int](c, ctx, http.MethodGet, "/get-user-vote", query, reqBody)
+}
+
+// Parameters of GetUsername
+type GetUsernameParams struct {
+	Address string
+}
+
+// GET /get-username
+func (c *Client) GetUsername(ctx context.Context, params GetUsernameParams) (string, error) {
+	query := url.Values{}
+	if params.Address != "" {
+		query.Set("address", params.Address)
+	}
+	var reqBody requestBody
+	return getData[string](c, ctx, http.MethodGet, "/get-username", query, reqBody)
+}
+
+// GET /get-username-store-address
+func (c *Client) GetUsernameStoreAddress(ctx context.Context) (string, error) {
+	var query url.Values
+	var reqBody requestBody
+	return getData[string](c, ctx, http.MethodGet, "/get-username-store-address", query, reqBody)
+}
+
+// Parameters of GetWorld
+type GetWorldParams struct {
+	WorldId string
+	Address string
+}
+
+// GET /get-world
+func (c *Client) GetWorld(ctx context.Context, params GetWorldParams) (WorldData, error) {
+	query := url.Values{}
+	if params.WorldId != "" {
+		query.Set("worldId", params.WorldId)
+	}
+	if params.Address != "" {
+		query.Set("address", params.Address)
+	}
+	var reqBody requestBody
+	return getData[WorldData](c, ctx, http.MethodGet, "/get-world", query, reqBody)
+}
+
+// Parameters of GetWorldCanvas
+type GetWorldCanvasParams struct {
+	WorldId string
+}
+
+// GET /get-world-canvas
+func (c *Client) GetWorldCanvas(ctx context.Context, params GetWorldCanvasParams) (*Binary, error) {
+	query := url.Values{}
+	if params.WorldId != "" {
+		query.Set("worldId", params.WorldId)
+	}
+	var reqBody requestBody
+	return c.binary(ctx, http.MethodGet, "/get-world-canvas", query, reqBody)
+}
+
+// Parameters of GetWorldId
+type GetWorldIdParams struct {
+	WorldName string
+}
+
+// GET /get-world-id
+func (c *Client) GetWorldId(ctx context.Context, params GetWorldIdParams) (int, error) {
+	query := url.Values{}
+	if params.WorldName != "" {
+		query.Set("worldName", params.WorldName)
+	}
+	var reqBody requestBody
+	return getData[int](c, ctx, http.MethodGet, "/get-world-id", query, reqBody)
+}
+
+// Parameters of GetWorlds
+type GetWorldsParams struct {
+	Address    string
+	Page       *int
+	PageLength *int
+	Cursor     string
+}
+
+// GET /get-worlds
+func (c *Client) GetWorlds(ctx context.Context, params GetWorldsParams) (*Page[WorldData], error) {
+	query := url.Values{}
+	if params.Address != "" {
+		query.Set("address", params.Address)
+	}
+	if params.Page != nil {
+		query.Set("page", strconv.Itoa(*params.Page))
+	}
+	if params.PageLength != nil {
+		query.Set("pageLength", strconv.Itoa(*params.PageLength))
+	}
+	if params.Cursor != "" {
+		query.Set("cursor", params.Cursor)
+	}
+	var reqBody requestBody
+	return getPage[WorldData](c, ctx, http.MethodGet, "/get-worlds", query, reqBody)
+}
+
+// Parameters of GetWorldsColors
+type GetWorldsColorsParams struct {
+	WorldId string
+}
+
+// GET /get-worlds-colors
+func (c *Client) GetWorldsColors(ctx context.Context, params GetWorldsColorsParams) ([]string, error) {
+	query := url.Values{}
+	if params.WorldId != "" {
+		query.Set("worldId", params.WorldId)
+	}
+	var reqBody requestBody
+	return getData[[]string](c, ctx, http.MethodGet, "/get-worlds-colors", query, reqBody)
+}
+
+// Parameters of GetWorldsExtraPixels
+type GetWorldsExtraPixelsParams struct {
+	WorldId string
+	Address string
+}
+
+// GET /get-worlds-extra-pixels
+func (c *Client) GetWorldsExtraPixels(ctx context.Context, params GetWorldsExtraPixelsParams) (int, error) {
+	query := url.Values{}
+	if params.WorldId != "" {
+		query.Set("worldId", params.WorldId)
+	}
+	if params.Address != "" {
+		query.Set("address", params.Address)
+	}
+	var reqBody requestBody
+	return getData[int](c, ctx, http.MethodGet, "/get-worlds-extra-pixels", query, reqBody)
+}
+
+// Parameters of GetWorldsLastPlacedTime
+type GetWorldsLastPlacedTimeParams struct {
+	WorldId string
+	Address string
+}
+
+// GET /get-worlds-last-placed-time
+func (c *Client) GetWorldsLastPlacedTime(ctx context.Context, params GetWorldsLastPlacedTimeParams) (string, error) {
+	query := url.Values{}
+	if params.WorldId != "" {
+		query.Set("worldId", params.WorldId)
+	}
+	if params.Address != "" {
+		query.Set("address", params.Address)
+	}
+	var reqBody requestBody
+	return getData[string](c, ctx, http.MethodGet, "/get-worlds-last-placed-time", query, reqBody)
+}
+
+// Parameters of GetWorldsPixelCount
+type GetWorldsPixelCountParams struct {
+	WorldId string
+	Address string
+}
+
+// GET /get-worlds-pixel-count
+func (c *Client) GetWorldsPixelCount(ctx context.Context, params GetWorldsPixelCountParams) (int, error) {
+	query := url.Values{}
+	if params.WorldId != "" {
+		query.Set("worldId", params.WorldId)
+	}
+	if params.Address != "" {
+		query.Set("address", params.Address)
+	}
+	var reqBody requestBody
+	return getData[int](c, ctx, http.MethodGet, "/get-worlds-pixel-count", query, reqBody)
+}
+
+// Parameters of GetWorldsPixelInfo
+type GetWorldsPixelInfoParams struct {
+	WorldId  string
+	Position *int
+}
+
+// GET /get-worlds-pixel-info
+func (c *Client) GetWorldsPixelInfo(ctx context.Context, params GetWorldsPixelInfoParams) (string, error) {
+	query := url.Values{}
+	if params.WorldId != "" {
+		query.Set("worldId", params.WorldId)
+	}
+	if params.Position != nil {
+		query.Set("position", strconv.Itoa(*params.Position))
+	}
+	var reqBody requestBody
+	return getData[string](c, ctx, http.MethodGet, "/get-worlds-pixel-info", query, reqBody)
+}
+
+// Parameters of Graphql
+type GraphqlParams struct {
+	Variables     string
+	Query         string
+	OperationName string
+}
+
+// Query in the query, operationName & variables parameters, variables as JSON
+//
+// GET /graphql
+func (c *Client) Graphql(ctx context.Context, params GraphqlParams) (*Binary, error) {
+	query := url.Values{}
+	if params.Variables != "" {
+		query.Set("variables", params.Variables)
+	}
+	if params.Query != "" {
+		query.Set("query", params.Query)
+	}
+	if params.OperationName != "" {
+		query.Set("operationName", params.OperationName)
+	}
+	var reqBody requestBody
+	return c.binary(ctx, http.MethodGet, "/graphql", query, reqBody)
+}
+
+// POST /graphql
+func (c *Client) PostGraphql(ctx context.Context, body GraphQLRequest) (*Binary, error) {
+	var query url.Values
+	reqBody := jsonBody(body)
+	return c.binary(ctx, http.MethodPost, "/graphql", query, reqBody)
+}
+
+// Parameters of Heatmap
+type HeatmapParams struct {
+	WorldId string
+	Round   string
+	From    *int
+	To      *int
+	Cell    *int
+	Format  string
+	Scale   *int
+}
+
+// ex: /heatmap?worldId=13&from=1700000000&to=1700003600&cell=8&format=png&scale=2
+//
+// GET /heatmap
+func (c *Client) Heatmap(ctx context.Context, params HeatmapParams) (*Binary, error) {
+	query := url.Values{}
+	if params.WorldId != "" {
+		query.Set("worldId", params.WorldId)
+	}
+	if params.Round != "" {
+		query.Set("round", params.Round)
+	}
+	if params.From != nil {
+		query.Set("from", strconv.Itoa(*params.From))
+	}
+	if params.To != nil {
+		query.Set("to", strconv.Itoa(*params.To))
+	}
+	if params.Cell != nil {
+		query.Set("cell", strconv.Itoa(*params.Cell))
+	}
+	if params.Format != "" {
+		query.Set("format", params.Format)
+	}
+	if params.Scale != nil {
+		query.Set("scale", strconv.Itoa(*params.Scale))
+	}
+	var reqBody requestBody
+	return c.binary(ctx, http.MethodGet, "/heatmap", query, reqBody)
+}
+
+// Parameters of HeatmapFaction
+type HeatmapFactionParams struct {
+	FactionId      *int
+	ChainFactionId *int
+	WorldId        string
+	Round          string
+	From           *int
+	To             *int
+	Cell           *int
+	Format         string
+	Scale          *int
+}
+
+// GET /heatmap-faction
+func (c *Client) HeatmapFaction(ctx context.Context, params HeatmapFactionParams) (*Binary, error) {
+	query := url.Values{}
+	if params.FactionId != nil {
+		query.Set("factionId", strconv.Itoa(*params.FactionId))
+	}
+	if params.ChainFactionId != nil {
+		query.Set("chainFactionId", strconv.Itoa(*params.ChainFactionId))
+	}
+	if params.WorldId != "" {
+		query.Set("worldId", params.WorldId)
+	}
+	if params.Round != "" {
+		query.Set("round", params.Round)
+	}
+	if params.From != nil {
+		query.Set("from", strconv.Itoa(*params.From))
+	}
+	if params.To != nil {
+		query.Set("to", strconv.Itoa(*params.To))
+	}
+	if params.Cell != nil {
+		query.Set("cell", strconv.Itoa(*params.Cell))
+	}
+	if params.Format != "" {
+		query.Set("format", params.Format)
+	}
+	if params.Scale != nil {
+		query.Set("scale", strconv.Itoa(*params.Scale))
+	}
+	var reqBody requestBody
+	return c.binary(ctx, http.MethodGet, "/heatmap-faction", query, reqBody)
+}
+
+// Parameters of HeatmapUser
+type HeatmapUserParams struct {
+	Address string
+	WorldId string
+	Round   string
+	From    *int
+	To      *int
+	Cell    *int
+	Format  string
+	Scale   *int
+}
+
+// GET /heatmap-user
+func (c *Client) HeatmapUser(ctx context.Context, params HeatmapUserParams) (*Binary, error) {
+	query := url.Values{}
+	if params.Address != "" {
+		query.Set("address", params.Address)
+	}
+	if params.WorldId != "" {
+		query.Set("worldId", params.WorldId)
+	}
+	if params.Round != "" {
+		query.Set("round", params.Round)
+	}
+	if params.From != nil {
+		query.Set("from", strconv.Itoa(*params.From))
+	}
+	if params.To != nil {
+		query.Set("to", strconv.Itoa(*params.To))
+	}
+	if params.Cell != nil {
+		query.Set("cell", strconv.Itoa(*params.Cell))
+	}
+	if params.Format != "" {
+		query.Set("format", params.Format)
+	}
+	if params.Scale != nil {
+		query.Set("scale", strconv.Itoa(*params.Scale))
+	}
+	var reqBody requestBody
+	return c.binary(ctx, http.MethodGet, "/heatmap-user", query, reqBody)
+}
+
+// Parameters of HighlightUser
+type HighlightUserParams struct {
+	Format  string
+	Address string
+	WorldId string
+	Round   string
+	Scale   *int
+	Grid    string
+	Labels  string
+	Crop    string
+}
+
+// Render the canvas with the address's surviving pixels highlighted & everything else dimmed
+// ex: /highlight-user?address=0x...&worldId=13&format=png&scale=4
+//
+// GET /highlight-user
+func (c *Client) HighlightUser(ctx context.Context, params HighlightUserParams) (*Binary, error) {
+	query := url.Values{}
+	if params.Format != "" {
+		query.Set("format", params.Format)
+	}
+	if params.Address != "" {
+		query.Set("address", params.Address)
+	}
+	if params.WorldId != "" {
+		query.Set("worldId", params.WorldId)
+	}
+	if params.Round != "" {
+		query.Set("round", params.Round)
+	}
+	if params.Scale != nil {
+		query.Set("scale", strconv.Itoa(*params.Scale))
+	}
+	if params.Grid != "" {
+		query.Set("grid", params.Grid)
+	}
+	if params.Labels != "" {
+		query.Set("labels", params.Labels)
+	}
+	if params.Crop != "" {
+		query.Set("crop", params.Crop)
+	}
+	var reqBody requestBody
+	return c.binary(ctx, http.MethodGet, "/highlight-user", query, reqBody)
+}
+
+// Parameters of PostHighlightUser
+type PostHighlightUserParams struct {
+	Format          string
+	Address         string
+	WorldId         string
+	Round           string
+	Scale           *int
+	Grid            string
+	Labels          string
+	Crop            string
+	From            *int
+	To              *int
+	PixelsPerFrame  *int
+	SecondsPerFrame *int
+}
+
+type PostHighlightUserData struct {
+	JobId string `json:"jobId"`
+}
+
+// Queue a timelapse job animating the address's placements over the dimmed canvas, polled like /create-timelapse jobs
+// ex: curl -X POST "http://localhost:8080/highlight-user?address=0x...&worldId=13&format=gif&scale=4"
+//
+// POST /highlight-user
+func (c *Client) PostHighlightUser(ctx context.Context, params PostHighlightUserParams) (PostHighlightUserData, error) {
+	query := url.Values{}
+	if params.Format != "" {
+		query.Set("format", params.Format)
+	}
+	if params.Address != "" {
+		query.Set("address", params.Address)
+	}
+	if params.WorldId != "" {
+		query.Set("worldId", params.WorldId)
+	}
+	if params.Round != "" {
+		query.Set("round", params.Round)
+	}
+	if params.Scale != nil {
+		query.Set("scale", strconv.Itoa(*params.Scale))
+	}
+	if params.Grid != "" {
+		query.Set("grid", params.Grid)
+	}
+	if params.Labels != "" {
+		query.Set("labels", params.Labels)
+	}
+	if params.Crop != "" {
+		query.Set("crop", params.Crop)
+	}
+	if params.From != nil {
+		query.Set("from", strconv.Itoa(*params.From))
+	}
+	if params.To != nil {
+		query.Set("to", strconv.Itoa(*params.To))
+	}
+	if params.PixelsPerFrame != nil {
+		query.Set("pixelsPerFrame", strconv.Itoa(*params.PixelsPerFrame))
+	}
+	if params.SecondsPerFrame != nil {
+		query.Set("secondsPerFrame", strconv.Itoa(*params.SecondsPerFrame))
+	}
+	var reqBody requestBody
+	return getData[PostHighlightUserData](c, ctx, http.MethodPost, "/highlight-user", query, reqBody)
+}
+
+// POST /increase-day-devnet
+func (c *Client) IncreaseDayDevnet(ctx context.Context) (string, error) {
+	var query url.Values
+	var reqBody requestBody
+	return c.result(ctx, http.MethodPost, "/increase-day-devnet", query, reqBody)
+}
+
+// POST /init-canvas
+func (c *Client) InitCanvas(ctx context.Context) (string, error) {
+	var query url.Values
+	var reqBody requestBody
+	return c.result(ctx, http.MethodPost, "/init-canvas", query, reqBody)
+}
+
+// POST /init-colors
+func (c *Client) InitColors(ctx context.Context, body []string) (string, error) {
+	var query url.Values
+	reqBody := jsonBody(body)
+	return c.result(ctx, http.MethodPost, "/init-colors", query, reqBody)
+}
+
+// POST /init-factions
+func (c *Client) InitFactions(ctx context.Context, body FactionsConfig) (string, error) {
+	var query url.Values
+	reqBody := jsonBody(body)
+	return c.result(ctx, http.MethodPost, "/init-factions", query, reqBody)
+}
+
+// POST /init-quests
+func (c *Client) InitQuests(ctx context.Context, body QuestsConfig) (string, error) {
+	var query url.Values
+	reqBody := jsonBody(body)
+	return c.result(ctx, http.MethodPost, "/init-quests", query, reqBody)
+}
+
+// POST /init-votable-colors
+func (c *Client) InitVotableColors(ctx context.Context, body []string) (string, error) {
+	var query url.Values
+	reqBody := jsonBody(body)
+	return c.result(ctx, http.MethodPost, "/init-votable-colors", query, reqBody)
+}
+
+type JoinChainFactionDevnetBody struct {
+	ChainId string `json:"chainId,omitempty"`
+}
+
+// POST /join-chain-faction-devnet
+func (c *Client) JoinChainFactionDevnet(ctx context.Context, body JoinChainFactionDevnetBody) (string, error) {
+	var query url.Values
+	reqBody := jsonBody(body)
+	return c.result(ctx, http.MethodPost, "/join-chain-faction-devnet", query, reqBody)
+}
+
+type JoinFactionDevnetBody struct {
+	FactionId string `json:"factionId,omitempty"`
+}
+
+// POST /join-faction-devnet
+func (c *Client) JoinFactionDevnet(ctx context.Context, body JoinFactionDevnetBody) (string, error) {
+	var query url.Values
+	reqBody := jsonBody(body)
+	return c.result(ctx, http.MethodPost, "/join-faction-devnet", query, reqBody)
+}
+
+// Parameters of LeaderboardPixels
+type LeaderboardPixelsParams struct {
+	Page              *int
+	PageLength        *int
+	Cursor            string
+	MinSupportedWorld *int
+	TimeCutoff        *int
+}
+
+// Get the leaderboard for total pixels placed by user
+//
+// GET /leaderboard-pixels
+func (c *Client) LeaderboardPixels(ctx context.Context, params LeaderboardPixelsParams) (*Page[LeaderboardEntry], error) {
+	query := url.Values{}
+	if params.Page != nil {
+		query.Set("page", strconv.Itoa(*params.Page))
+	}
+	if params.PageLength != nil {
+		query.Set("pageLength", strconv.Itoa(*params.PageLength))
+	}
+	if params.Cursor != "" {
+		query.Set("cursor", params.Cursor)
+	}
+	if params.MinSupportedWorld != nil {
+		query.Set("minSupportedWorld", strconv.Itoa(*params.MinSupportedWorld))
+	}
+	if params.TimeCutoff != nil {
+		query.Set("timeCutoff", strconv.Itoa(*params.TimeCutoff))
+	}
+	var reqBody requestBody
+	return getPage[LeaderboardEntry](c, ctx, http.MethodGet, "/leaderboard-pixels", query, reqBody)
+}
+
+// Parameters of LeaderboardPixelsUser
+type LeaderboardPixelsUserParams struct {
+	Address           string
+	MinSupportedWorld *int
+	TimeCutoff        *int
+}
+
+// Get the leaderboard for total pixels placed by specific user
+//
+// GET /leaderboard-pixels-user
+func (c *Client) LeaderboardPixelsUser(ctx context.Context, params LeaderboardPixelsUserParams) (int, error) {
+	query := url.Values{}
+	if params.Address != "" {
+		query.Set("address", params.Address)
+	}
+	if params.MinSupportedWorld != nil {
+		query.Set("minSupportedWorld", strconv.Itoa(*params.MinSupportedWorld))
+	}
+	if params.TimeCutoff != nil {
+		query.Set("timeCutoff", strconv.Itoa(*params.TimeCutoff))
+	}
+	var reqBody requestBody
+	return getData[int](c, ctx, http.MethodGet, "/leaderboard-pixels-user", query, reqBody)
+}
+
+// Parameters of LeaderboardPixelsWorld
+type LeaderboardPixelsWorldParams struct {
+	WorldId    string
+	Page       *int
+	PageLength *int
+	Cursor     string
+	TimeCutoff *int
+}
+
+// Get the leaderboard for total pixels placed on specific world
+//
+// GET /leaderboard-pixels-world
+func (c *Client) LeaderboardPixelsWorld(ctx context.Context, params LeaderboardPixelsWorldParams) (*Page[LeaderboardEntry], error) {
+	query := url.Values{}
+	if params.WorldId != "" {
+		query.Set("worldId", params.WorldId)
+	}
+	if params.Page != nil {
+		query.Set("page", strconv.Itoa(*params.Page))
+	}
+	if params.PageLength != nil {
+		query.Set("pageLength", strconv.Itoa(*params.PageLength))
+	}
+	if params.Cursor != "" {
+		query.Set("cursor", params.Cursor)
+	}
+	if params.TimeCutoff != nil {
+		query.Set("timeCutoff", strconv.Itoa(*params.TimeCutoff))
+	}
+	var reqBody requestBody
+	return getPage[LeaderboardEntry](c, ctx, http.MethodGet, "/leaderboard-pixels-world", query, reqBody)
+}
+
+// Parameters of LeaderboardPixelsWorldUser
+type LeaderboardPixelsWorldUserParams struct {
+	WorldId    string
+	Address    string
+	TimeCutoff *int
+}
+
+// Get the leaderboard for total pixels placed by specific user on specific world
+//
+// GET /leaderboard-pixels-world-user
+func (c *Client) LeaderboardPixelsWorldUser(ctx context.Context, params LeaderboardPixelsWorldUserParams) (int, error) {
+	query := url.Values{}
+	if params.WorldId != "" {
+		query.Set("worldId", params.WorldId)
+	}
+	if params.Address != "" {
+		query.Set("address", params.Address)
+	}
+	if params.TimeCutoff != nil {
+		query.Set("timeCutoff", strconv.Itoa(*params.TimeCutoff))
+	}
+	var reqBody requestBody
+	return getData[int](c, ctx, http.MethodGet, "/leaderboard-pixels-world-user", query, reqBody)
+}
+
+// Parameters of LeaderboardWorlds
+type LeaderboardWorldsParams struct {
+	Page              *int
+	PageLength        *int
+	Cursor            string
+	MinSupportedWorld *int
+	TimeCutoff        *int
+}
+
+// Get the leaderboard for total pixels on each world
+//
+// GET /leaderboard-worlds
+func (c *Client) LeaderboardWorlds(ctx context.Context, params LeaderboardWorldsParams) (*Page[LeaderboardEntry], error) {
+	query := url.Values{}
+	if params.Page != nil {
+		query.Set("page", strconv.Itoa(*params.Page))
+	}
+	if params.PageLength != nil {
+		query.Set("pageLength", strconv.Itoa(*params.PageLength))
+	}
+	if params.Cursor != "" {
+		query.Set("cursor", params.Cursor)
+	}
+	if params.MinSupportedWorld != nil {
+		query.Set("minSupportedWorld", strconv.Itoa(*params.MinSupportedWorld))
+	}
+	if params.TimeCutoff != nil {
+		query.Set("timeCutoff", strconv.Itoa(*params.TimeCutoff))
+	}
+	var reqBody requestBody
+	return getPage[LeaderboardEntry](c, ctx, http.MethodGet, "/leaderboard-worlds", query, reqBody)
+}
+
+// POST /leave-faction-devnet
+func (c *Client) LeaveFactionDevnet(ctx context.Context) (string, error) {
+	var query url.Values
+	var reqBody requestBody
+	return c.result(ctx, http.MethodPost, "/leave-faction-devnet", query, reqBody)
+}
+
+type LikeNftDevnetBody struct {
+	TokenId string `json:"tokenId,omitempty"`
+}
+
+// POST /like-nft-devnet
+func (c *Client) LikeNftDevnet(ctx context.Context, body LikeNftDevnetBody) (string, error) {
+	var query url.Values
+	reqBody := jsonBody(body)
+	return c.result(ctx, http.MethodPost, "/like-nft-devnet", query, reqBody)
+}
+
+// POST /login
+func (c *Client) Login(ctx context.Context, body LoginRequest) (SessionResponse, error) {
+	var query url.Values
+	reqBody := jsonBody(body)
+	return getData[SessionResponse](c, ctx, http.MethodPost, "/login", query, reqBody)
+}
+
+// POST /logout
+func (c *Client) Logout(ctx context.Context) (string, error) {
+	var query url.Values
+	var reqBody requestBody
+	return c.result(ctx, http.MethodPost, "/logout", query, reqBody)
+}
+
+type MintNftDevnetBody struct {
+	Height   string `json:"height,omitempty"`
+	Name     string `json:"name,omitempty"`
+	Position string `json:"position,omitempty"`
+	Width    string `json:"width,omitempty"`
+}
+
+// POST /mint-nft-devnet
+func (c *Client) MintNftDevnet(ctx context.Context, body MintNftDevnetBody) (string, error) {
+	var query url.Values
+	reqBody := jsonBody(body)
+	return c.result(ctx, http.MethodPost, "/mint-nft-devnet", query, reqBody)
+}
+
+// POST /mute-chat-address
+func (c *Client) MuteChatAddress(ctx context.Context, body MuteChatAddressRequest) (string, error) {
+	var query url.Values
+	reqBody := jsonBody(body)
+	return c.result(ctx, http.MethodPost, "/mute-chat-address", query, reqBody)
+}
+
+type NewUsernameDevnetBody struct {
+	Username string `json:"username,omitempty"`
+}
+
+// POST /new-username-devnet
+func (c *Client) NewUsernameDevnet(ctx context.Context, body NewUsernameDevnetBody) (string, error) {
+	var query url.Values
+	reqBody := jsonBody(body)
+	return c.result(ctx, http.MethodPost, "/new-username-devnet", query, reqBody)
+}
+
+// Parameters of NftsByTokenId
+type NftsByTokenIdParams struct {
+	TokenId string
+}
+
+// GET /nfts/{tokenId}
+func (c *Client) NftsByTokenId(ctx context.Context, params NftsByTokenIdParams) (NFTData, error) {
+	var query url.Values
+	var reqBody requestBody
+	return getData[NFTData](c, ctx, http.MethodGet, "/nfts/"+url.PathEscape(params.TokenId), query, reqBody)
+}
+
+// GET /openapi.json
+func (c *Client) OpenapiJson(ctx context.Context) (*Binary, error) {
+	var query url.Values
+	var reqBody requestBody
+	return c.binary(ctx, http.MethodGet, "/openapi.json", query, reqBody)
+}
+
+// POST /place-extra-pixels-devnet
+func (c *Client) PlaceExtraPixelsDevnet(ctx context.Context, body ExtraPixelJson) (string, error) {
+	var query url.Values
+	reqBody := jsonBody(body)
+	return c.result(ctx, http.MethodPost, "/place-extra-pixels-devnet", query, reqBody)
+}
+
+type PlacePixelDevnetBody struct {
+	Color     string `json:"color,omitempty"`
+	Position  string `json:"position,omitempty"`
+	Timestamp string `json:"timestamp,omitempty"`
+}
+
+// POST /place-pixel-devnet
+func (c *Client) PlacePixelDevnet(ctx context.Context, body PlacePixelDevnetBody) (string, error) {
+	var query url.Values
+	reqBody := jsonBody(body)
+	return c.result(ctx, http.MethodPost, "/place-pixel-devnet", query, reqBody)
+}
+
+type PlacePixelRedisBody struct {
+	Color    int `json:"color,omitempty"`
+	Position int `json:"position,omitempty"`
+}
+
+// POST /place-pixel-redis
+func (c *Client) PlacePixelRedis(ctx context.Context, body PlacePixelRedisBody) (string, error) {
+	var query url.Values
+	reqBody := jsonBody(body)
+	return c.result(ctx, http.MethodPost, "/place-pixel-redis", query, reqBody)
+}
+
+type PlaceWorldPixelDevnetBody struct {
+	Color     string `json:"color,omitempty"`
+	Position  string `json:"position,omitempty"`
+	Timestamp string `json:"timestamp,omitempty"`
+	WorldId   string `json:"worldId,omitempty"`
+}
+
+// POST /place-world-pixel-devnet
+func (c *Client) PlaceWorldPixelDevnet(ctx context.Context, body PlaceWorldPixelDevnetBody) (string, error) {
+	var query url.Values
+	reqBody := jsonBody(body)
+	return c.result(ctx, http.MethodPost, "/place-world-pixel-devnet", query, reqBody)
+}
+
+type RemoveChainFactionTemplateDevnetBody struct {
+	TemplateId string `json:"templateId,omitempty"`
+}
+
+// POST /remove-chain-faction-template-devnet
+func (c *Client) RemoveChainFactionTemplateDevnet(ctx context.Context, body RemoveChainFactionTemplateDevnetBody) (string, error) {
+	var query url.Values
+	reqBody := jsonBody(body)
+	return c.result(ctx, http.MethodPost, "/remove-chain-faction-template-devnet", query, reqBody)
+}
+
+type RemoveFactionTemplateDevnetBody struct {
+	TemplateId string `json:"templateId,omitempty"`
+}
+
+// POST /remove-faction-template-devnet
+func (c *Client) RemoveFactionTemplateDevnet(ctx context.Context, body RemoveFactionTemplateDevnetBody) (string, error) {
+	var query url.Values
+	reqBody := jsonBody(body)
+	return c.result(ctx, http.MethodPost, "/remove-faction-template-devnet", query, reqBody)
+}
+
+// POST /remove-protected-region
+func (c *Client) RemoveProtectedRegion(ctx context.Context, body RemoveProtectedRegionRequest) (string, error) {
+	var query url.Values
+	reqBody := jsonBody(body)
+	return c.result(ctx, http.MethodPost, "/remove-protected-region", query, reqBody)
+}
+
+type RemoveStencilDevnetBody struct {
+	StencilId string `json:"stencilId,omitempty"`
+	WorldId   string `json:"worldId,omitempty"`
+}
+
+// POST /remove-stencil-devnet
+func (c *Client) RemoveStencilDevnet(ctx context.Context, body RemoveStencilDevnetBody) (string, error) {
+	var query url.Values
+	reqBody := jsonBody(body)
+	return c.result(ctx, http.MethodPost, "/remove-stencil-devnet", query, reqBody)
+}
+
+// Restore every position touched by the addresses in the window to the color it would have without their placements
+// ex: curl -X POST -d '{"worldId":13,"addresses":["0x..."],"from":1700000000,"to":1700003600,"dryRun":true}' http://localhost:8080/rollback-pixels
+//
+// POST /rollback-pixels
+func (c *Client) RollbackPixels(ctx context.Context, body RollbackPixelsRequest) (RollbackResult, error) {
+	var query url.Values
+	reqBody := jsonBody(body)
+	return getData[RollbackResult](c, ctx, http.MethodPost, "/rollback-pixels", query, reqBody)
+}
+
+// POST /set-canvas-nft-address
+func (c *Client) SetCanvasNftAddress(ctx context.Context, body string) (string, error) {
+	var query url.Values
+	reqBody := textBody(body)
+	return c.result(ctx, http.MethodPost, "/set-canvas-nft-address", query, reqBody)
+}
+
+// POST /set-contract-address
+func (c *Client) SetContractAddress(ctx context.Context, body string) (string, error) {
+	var query url.Values
+	reqBody := textBody(body)
+	return c.result(ctx, http.MethodPost, "/set-contract-address", query, reqBody)
+}
+
+// POST /set-factory-contract-address
+func (c *Client) SetFactoryContractAddress(ctx context.Context, body string) (string, error) {
+	var query url.Values
+	reqBody := textBody(body)
+	return c.result(ctx, http.MethodPost, "/set-factory-contract-address", query, reqBody)
+}
+
+// POST /set-username-store-address
+func (c *Client) SetUsernameStoreAddress(ctx context.Context, body string) (string, error) {
+	var query url.Values
+	reqBody := textBody(body)
+	return c.result(ctx, http.MethodPost, "/set-username-store-address", query, reqBody)
+}
+
+type UnfavoriteStencilDevnetBody struct {
+	StencilId string `json:"stencilId,omitempty"`
+	WorldId   string `json:"worldId,omitempty"`
+}
+
+// POST /unfavorite-stencil-devnet
+func (c *Client) UnfavoriteStencilDevnet(ctx context.Context, body UnfavoriteStencilDevnetBody) (string, error) {
+	var query url.Values
+	reqBody := jsonBody(body)
+	return c.result(ctx, http.MethodPost, "/unfavorite-stencil-devnet", query, reqBody)
+}
+
+type UnfavoriteWorldDevnetBody struct {
+	WorldId string `json:"worldId,omitempty"`
+}
+
+// POST /unfavorite-world-devnet
+func (c *Client) UnfavoriteWorldDevnet(ctx context.Context, body UnfavoriteWorldDevnetBody) (string, error) {
+	var query url.Values
+	reqBody := jsonBody(body)
+	return c.result(ctx, http.MethodPost, "/unfavorite-world-devnet", query, reqBody)
+}
+
+type UnlikeNftDevnetBody struct {
+	TokenId string `json:"tokenId,omitempty"`
+}
+
+// POST /unlike-nft-devnet
+func (c *Client) UnlikeNftDevnet(ctx context.Context, body UnlikeNftDevnetBody) (string, error) {
+	var query url.Values
+	reqBody := jsonBody(body)
+	return c.result(ctx, http.MethodPost, "/unlike-nft-devnet", query, reqBody)
+}
+
+// POST /unmute-chat-address
+func (c *Client) UnmuteChatAddress(ctx context.Context, body MuteChatAddressRequest) (string, error) {
+	var query url.Values
+	reqBody := jsonBody(body)
+	return c.result(ctx, http.MethodPost, "/unmute-chat-address", query, reqBody)
+}
+
+// Form of UploadFactionIcon
+type UploadFactionIconForm struct {
+	Icon File
+}
+
+// POST /upload-faction-icon
+func (c *Client) UploadFactionIcon(ctx context.Context, form UploadFactionIconForm) (string, error) {
+	var query url.Values
+	reqBody := formBody(map[string]string{}, map[string]File{"icon": form.Icon})
+	return c.result(ctx, http.MethodPost, "/upload-faction-icon", query, reqBody)
+}
+
+// GET /votable-colors
+func (c *Client) VotableColors(ctx context.Context) ([]VotableColor, error) {
+	var query url.Values
+	var reqBody requestBody
+	return getData[[]VotableColor](c, ctx, http.MethodGet, "/votable-colors", query, reqBody)
+}
+
+type VoteColorDevnetBody struct {
+	ColorIndex int `json:"colorIndex,omitempty"`
+}
+
+// POST /vote-color-devnet
+func (c *Client) VoteColorDevnet(ctx context.Context, body VoteColorDevnetBody) (string, error) {
+	var query url.Values
+	reqBody := jsonBody(body)
+	return c.result(ctx, http.MethodPost, "/vote-color-devnet", query, reqBody)
+}
+
+// Parameters of WorldPresence
+type WorldPresenceParams struct {
+	WorldId *int
+}
+
+// GET /world-presence
+func (c *Client) WorldPresence(ctx context.Context, params WorldPresenceParams) (WorldPresence, error) {
+	query := url.Values{}
+	if params.WorldId != nil {
+		query.Set("worldId", strconv.Itoa(*params.WorldId))
+	}
+	var reqBody requestBody
+	return getData[WorldPresence](c, ctx, http.MethodGet, "/world-presence", query, reqBody)
+}
+
+// Parameters of WorldsByWorldIdCanvas
+type WorldsByWorldIdCanvasParams struct {
+	WorldId string
+}
+
+// GET /worlds/{worldId}/canvas
+func (c *Client) WorldsByWorldIdCanvas(ctx context.Context, params WorldsByWorldIdCanvasParams) (*Binary, error) {
+	var query url.Values
+	var reqBody requestBody
+	return c.binary(ctx, http.MethodGet, "/worlds/"+url.PathEscape(params.WorldId)+"/canvas", query, reqBody)
+}
+
+// Parameters of WorldsByWorldIdChat
+type WorldsByWorldIdChatParams struct {
+	WorldId    int
+	Page       *int
+	PageLength *int
+	Cursor     string
+}
+
+// ex: /get-chat-messages?worldId=13&page=1&pageLength=25
+// Newest messages first
+//
+// GET /worlds/{worldId}/chat
+func (c *Client) WorldsByWorldIdChat(ctx context.Context, params WorldsByWorldIdChatParams) (*Page[ChatMessage], error) {
+	query := url.Values{}
+	if params.Page != nil {
+		query.Set("page", strconv.Itoa(*params.Page))
+	}
+	if params.PageLength != nil {
+		query.Set("pageLength", strconv.Itoa(*params.PageLength))
+	}
+	if params.Cursor != "" {
+		query.Set("cursor", params.Cursor)
+	}
+	var reqBody requestBody
+	return getPage[ChatMessage](c, ctx, http.MethodGet, "/worlds/"+url.PathEscape(strconv.Itoa(params.WorldId))+"/chat", query, reqBody)
+}
+
+// Parameters of WorldsByWorldIdExport
+type WorldsByWorldIdExportParams struct {
+	WorldId string
+	Format  string
+	Round   string
+	Scale   *int
+	Grid    string
+	Labels  string
+	Crop    string
+}
+
+// Export a canvas as an image
+// ex: /export-canvas?worldId=13&format=png&scale=4&crop=0,0,64,64&grid=true&labels=true
+//
+// GET /worlds/{worldId}/export
+func (c *Client) WorldsByWorldIdExport(ctx context.Context, params WorldsByWorldIdExportParams) (*Binary, error) {
+	query := url.Values{}
+	if params.Format != "" {
+		query.Set("format", params.Format)
+	}
+	if params.Round != "" {
+		query.Set("round", params.Round)
+	}
+	if params.Scale != nil {
+		query.Set("scale", strconv.Itoa(*params.Scale))
+	}
+	if params.Grid != "" {
+		query.Set("grid", params.Grid)
+	}
+	if params.Labels != "" {
+		query.Set("labels", params.Labels)
+	}
+	if params.Crop != "" {
+		query.Set("crop", params.Crop)
+	}
+	var reqBody requestBody
+	return c.binary(ctx, http.MethodGet, "/worlds/"+url.PathEscape(params.WorldId)+"/export", query, reqBody)
+}
+
+// Parameters of WorldsByWorldIdPixelsByPosition
+type WorldsByWorldIdPixelsByPositionParams struct {
+	WorldId  string
+	Position int
+}
+
+// GET /worlds/{worldId}/pixels/{position}
+func (c *Client) WorldsByWorldIdPixelsByPosition(ctx context.Context, params WorldsByWorldIdPixelsByPositionParams) (string, error) {
+	var query url.Values
+	var reqBody requestBody
+	return getData[string](c, ctx, http.MethodGet, "/worlds/"+url.PathEscape(params.WorldId)+"/pixels/"+url.PathEscape(strconv.Itoa(params.Position)), query, reqBody)
+}
+
+// Parameters of WorldsByWorldIdPresence
+type WorldsByWorldIdPresenceParams struct {
+	WorldId int
+}
+
+// GET /worlds/{worldId}/presence
+func (c *Client) WorldsByWorldIdPresence(ctx context.Context, params WorldsByWorldIdPresenceParams) (WorldPresence, error) {
+	var query url.Values
+	var reqBody requestBody
+	return getData[WorldPresence](c, ctx, http.MethodGet, "/worlds/"+url.PathEscape(strconv.Itoa(params.WorldId))+"/presence", query, reqBody)
+}
+
+// Parameters of WorldsByWorldIdProtectedRegionViolations
+type WorldsByWorldIdProtectedRegionViolationsParams struct {
+	WorldId    int
+	Page       *int
+	PageLength *int
+	Cursor     string
+}
+
+// GET /worlds/{worldId}/protected-region-violations
+func (c *Client) WorldsByWorldIdProtectedRegionViolations(ctx context.Context, params WorldsByWorldIdProtectedRegionViolationsParams) (*Page[ProtectedRegionViolation], error) {
+	query := url.Values{}
+	if params.Page != nil {
+		query.Set("page", strconv.Itoa(*params.Page))
+	}
+	if params.PageLength != nil {
+		query.Set("pageLength", strconv.Itoa(*params.PageLength))
+	}
+	if params.Cursor != "" {
+		query.Set("cursor", params.Cursor)
+	}
+	var reqBody requestBody
+	return getPage[ProtectedRegionViolation](c, ctx, http.MethodGet, "/worlds/"+url.PathEscape(strconv.Itoa(params.WorldId))+"/protected-region-violations", query, reqBody)
+}
+
+// Parameters of WorldsByWorldIdProtectedRegions
+type WorldsByWorldIdProtectedRegionsParams struct {
+	WorldId int
+	Active  string
+}
+
+// ex: /get-protected-regions?worldId=13&active=true
+//
+// GET /worlds/{worldId}/protected-regions
+func (c *Client) WorldsByWorldIdProtectedRegions(ctx context.Context, params WorldsByWorldIdProtectedRegionsParams) ([]ProtectedRegion, error) {
+	query := url.Values{}
+	if params.Active != "" {
+		query.Set("active", params.Active)
+	}
+	var reqBody requestBody
+	return getData[[]ProtectedRegion](c, ctx, http.MethodGet, "/worlds/"+url.PathEscape(strconv.Itoa(params.WorldId))+"/protected-regions", query, reqBody)
+}
@@ -0,0 +1,217 @@
+// Package client calls the art/peace backend with the routes' own types
+// The routes & types are generated in api.gen.go, see go generate ./routes
+//
+//	c := client.New("http://localhost:8080")
+//	worlds, err := c.GetWorlds(ctx, client.GetWorldsParams{PageLength: client.Int(10)})
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+type Client struct {
+	BaseURL    string
+	HTTPClient *http.Client
+	// Session token from Login, sent as a bearer token
+	Token string
+	// Admin API key, sent in the X-Api-Key header
+	ApiKey string
+}
+
+func New(baseURL string) *Client {
+	return &Client{BaseURL: strings.TrimSuffix(baseURL, "/"), HTTPClient: http.DefaultClient}
+}
+
+// Error response of the backend, the code is stable & the message is for people
+type ResponseError struct {
+	Status int
+	Body   Error
+}
+
+func (e *ResponseError) Error() string {
+	return fmt.Sprintf("art-peace: %d %s: %s", e.Status, e.Body.Code, e.Body.Error)
+}
+
+// Page of a list route
+type Page[T any] struct {
+	Items      []T
+	Pagination Pagination
+	RequestId  string
+}
+
+// Response that isn't a JSON envelope, like rendered images
+type Binary struct {
+	ContentType string
+	Body        []byte
+}
+
+// File sent in a multipart form
+type File struct {
+	Name    string
+	Content io.Reader
+}
+
+// Pointers for optional query parameters
+func Int(value int) *int {
+	return &value
+}
+
+func Bool(value bool) *bool {
+	return &value
+}
+
+func Float(value float64) *float64 {
+	return &value
+}
+
+type requestBody struct {
+	reader      io.Reader
+	contentType string
+	err         error
+}
+
+func jsonBody(body interface{}) requestBody {
+	data, err := json.Marshal(body)
+	return requestBody{reader: bytes.NewReader(data), contentType: "application/json", err: err}
+}
+
+func textBody(body string) requestBody {
+	return requestBody{reader: strings.NewReader(body), contentType: "text/plain"}
+}
+
+func formBody(values map[string]string, files map[string]File) requestBody {
+	var buf bytes.Buffer
+	writer := multipart.NewWriter(&buf)
+	for name, value := range values {
+		if value == "" {
+			continue
+		}
+		if err := writer.WriteField(name, value); err != nil {
+			return requestBody{err: err}
+		}
+	}
+	for name, file := range files {
+		if file.Content == nil {
+			continue
+		}
+		part, err := writer.CreateFormFile(name, file.Name)
+		if err != nil {
+			return requestBody{err: err}
+		}
+		if _, err := io.Copy(part, file.Content); err != nil {
+			return requestBody{err: err}
+		}
+	}
+	if err := writer.Close(); err != nil {
+		return requestBody{err: err}
+	}
+	return requestBody{reader: &buf, contentType: writer.FormDataContentType()}
+}
+
+// Send a request, error responses are returned as *ResponseError
+func (c *Client) do(ctx context.Context, method string, path string, query url.Values, body requestBody) (*http.Response, error) {
+	if body.err != nil {
+		return nil, body.err
+	}
+	target := c.BaseURL + path
+	if len(query) > 0 {
+		target += "?" + query.Encode()
+	}
+	req, err := http.NewRequestWithContext(ctx, method, target, body.reader)
+	if err != nil {
+		return nil, err
+	}
+	if body.contentType != "" {
+		req.Header.Set("Content-Type", body.contentType)
+	}
+	if c.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.Token)
+	}
+	if c.ApiKey != "" {
+		req.Header.Set("X-Api-Key", c.ApiKey)
+	}
+
+	httpClient := c.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	res, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode >= http.StatusBadRequest {
+		defer res.Body.Close()
+		responseErr := &ResponseError{Status: res.StatusCode}
+		if err := json.NewDecoder(res.Body).Decode(&responseErr.Body); err != nil {
+			responseErr.Body.Error = http.StatusText(res.StatusCode)
+		}
+		return nil, responseErr
+	}
+	return res, nil
+}
+
+func (c *Client) decode(ctx context.Context, method string, path string, query url.Values, body requestBody, value interface{}) error {
+	res, err := c.do(ctx, method, path, query, body)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	return json.NewDecoder(res.Body).Decode(value)
+}
+
+func (c *Client) empty(ctx context.Context, method string, path string, query url.Values, body requestBody) error {
+	res, err := c.do(ctx, method, path, query, body)
+	if err != nil {
+		return err
+	}
+	return res.Body.Close()
+}
+
+func (c *Client) result(ctx context.Context, method string, path string, query url.Values, body requestBody) (string, error) {
+	var result Result
+	if err := c.decode(ctx, method, path, query, body, &result); err != nil {
+		return "", err
+	}
+	return result.Result, nil
+}
+
+func (c *Client) binary(ctx context.Context, method string, path string, query url.Values, body requestBody) (*Binary, error) {
+	res, err := c.do(ctx, method, path, query, body)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	data, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+	return &Binary{ContentType: res.Header.Get("Content-Type"), Body: data}, nil
+}
+
+func getData[T any](c *Client, ctx context.Context, method string, path string, query url.Values, body requestBody) (T, error) {
+	var envelope struct {
+		Data T `json:"data"`
+	}
+	err := c.decode(ctx, method, path, query, body, &envelope)
+	return envelope.Data, err
+}
+
+func getPage[T any](c *Client, ctx context.Context, method string, path string, query url.Values, body requestBody) (*Page[T], error) {
+	var envelope struct {
+		Data       []T        `json:"data"`
+		Pagination Pagination `json:"pagination"`
+		RequestId  string     `json:"requestId"`
+	}
+	if err := c.decode(ctx, method, path, query, body, &envelope); err != nil {
+		return nil, err
+	}
+	return &Page[T]{Items: envelope.Data, Pagination: envelope.Pagination, RequestId: envelope.RequestId}, nil
+}
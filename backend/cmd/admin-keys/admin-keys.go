@@ -0,0 +1,107 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/keep-starknet-strange/art-peace/backend/auth"
+	"github.com/keep-starknet-strange/art-peace/backend/config"
+	"github.com/keep-starknet-strange/art-peace/backend/core"
+)
+
+// Manage admin API keys
+//
+//	admin-keys mint -name alice -role moderator
+//	admin-keys mint -name host-13 -role world-host -world 13
+//	admin-keys revoke -key 4
+//	admin-keys list
+func usage() {
+	fmt.Println("Usage: admin-keys <mint|revoke|list> [flags]")
+	fmt.Println("Roles:", auth.RoleSuperAdmin+", "+auth.RoleModerator+", "+auth.RoleWorldHost)
+	os.Exit(2)
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+	}
+
+	command := flag.NewFlagSet(os.Args[1], flag.ExitOnError)
+	databaseConfigFilename := command.String("database-config", config.DefaultDatabaseConfigPath, "Database config file")
+	backendConfigFilename := command.String("backend-config", config.DefaultBackendConfigPath, "Backend config file")
+	name := command.String("name", "", "Name of the key holder, for mint")
+	role := command.String("role", "", "Role of the key, for mint")
+	worldId := command.Int("world", -1, "World managed by a world-host key, for mint")
+	key := command.Int("key", 0, "Key id, for revoke")
+
+	switch os.Args[1] {
+	case "mint", "revoke", "list":
+	default:
+		usage()
+	}
+	command.Parse(os.Args[2:])
+
+	databaseConfig, err := config.LoadDatabaseConfig(*databaseConfigFilename)
+	if err != nil {
+		panic(err)
+	}
+
+	backendConfig, err := config.LoadBackendConfig(*backendConfigFilename)
+	if err != nil {
+		panic(err)
+	}
+
+	databases := core.NewDatabases(databaseConfig)
+	defer databases.Close()
+
+	core.ArtPeaceBackend = core.NewBackend(databases, nil, nil, backendConfig, false)
+
+	switch os.Args[1] {
+	case "mint":
+		if strings.TrimSpace(*name) == "" {
+			fmt.Println("Missing -name")
+			os.Exit(1)
+		}
+		var world *int
+		if *worldId >= 0 {
+			world = worldId
+		}
+		secret, apiKey, err := auth.MintApiKey(*name, *role, world)
+		if err != nil {
+			fmt.Println("Failed to mint key:", err)
+			os.Exit(1)
+		}
+		fmt.Printf("Minted key %d for %s with role %s\n", apiKey.Key, apiKey.Name, apiKey.Role)
+		fmt.Println("Send it in the X-Api-Key header, it won't be shown again:")
+		fmt.Println(secret)
+	case "revoke":
+		if *key <= 0 {
+			fmt.Println("Missing -key")
+			os.Exit(1)
+		}
+		if err := auth.RevokeApiKey(*key); err != nil {
+			fmt.Println("Failed to revoke key:", err)
+			os.Exit(1)
+		}
+		fmt.Println("Revoked key", *key)
+	case "list":
+		apiKeys, err := auth.ListApiKeys()
+		if err != nil {
+			fmt.Println("Failed to list keys:", err)
+			os.Exit(1)
+		}
+		for _, apiKey := range apiKeys {
+			world := "-"
+			if apiKey.WorldId != nil {
+				world = fmt.Sprint(*apiKey.WorldId)
+			}
+			status := "active"
+			if apiKey.RevokedAt != nil {
+				status = "revoked " + apiKey.RevokedAt.Format("2006-01-02")
+			}
+			fmt.Printf("%d\t%s\t%s...\t%s\tworld %s\t%s\n", apiKey.Key, apiKey.Name, apiKey.Prefix, apiKey.Role, world, status)
+		}
+	}
+}
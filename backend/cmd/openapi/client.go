@@ -0,0 +1,304 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Go client generated from the document, the hand written part is in client/client.go
+func generateClient(spec *Spec) ([]byte, error) {
+	var body bytes.Buffer
+
+	names := make([]string, 0, len(spec.Components.Schemas))
+	for name := range spec.Components.Schemas {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(&body, "type %s %s\n\n", name, goType(spec.Components.Schemas[name]))
+	}
+
+	paths := make([]string, 0, len(spec.Paths))
+	for path := range spec.Paths {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		methods := make([]string, 0, len(spec.Paths[path]))
+		for method := range spec.Paths[path] {
+			methods = append(methods, method)
+		}
+		sort.Strings(methods)
+		for _, method := range methods {
+			writeOperation(&body, strings.ToUpper(method), path, spec.Paths[path][method])
+		}
+	}
+
+	var out bytes.Buffer
+	out.WriteString("// Code generated by cmd/openapi from routes/openapi.json. DO NOT EDIT.\n\n")
+	out.WriteString("package client\n\nimport (\n")
+	for _, pkg := range []string{"context", "encoding/json", "net/http", "net/url", "strconv", "time"} {
+		name := pkg[strings.LastIndex(pkg, "/")+1:]
+		if regexp.MustCompile(`\b` + name + `\.`).Match(body.Bytes()) {
+			fmt.Fprintf(&out, "\t%q\n", pkg)
+		}
+	}
+	out.WriteString(")\n\n")
+	out.Write(body.Bytes())
+	return format.Source(out.Bytes())
+}
+
+func goType(schema *Schema) string {
+	if len(schema.AllOf) == 1 {
+		return pointerType(goType(schema.AllOf[0]), schema.Nullable)
+	}
+	if schema.Ref != "" {
+		return strings.TrimPrefix(schema.Ref, "#/components/schemas/")
+	}
+
+	var t string
+	switch schema.Type {
+	case "string":
+		switch schema.Format {
+		case "date-time":
+			t = "time.Time"
+		case "byte", "binary":
+			t = "[]byte"
+		default:
+			t = "string"
+		}
+	case "integer":
+		t = "int"
+		if schema.Format == "int64" {
+			t = "int64"
+		}
+	case "number":
+		t = "float64"
+	case "boolean":
+		t = "bool"
+	case "array":
+		t = "[]" + goType(schema.Items)
+	case "object":
+		if len(schema.Properties) == 0 && schema.AdditionalProperties != nil {
+			t = "map[string]" + goType(schema.AdditionalProperties)
+		} else {
+			t = structType(schema)
+		}
+	default:
+		t = "json.RawMessage"
+	}
+	return pointerType(t, schema.Nullable)
+}
+
+// Objects described in place get a type named after their operation
+func declaredType(buf *bytes.Buffer, name string, schema *Schema) string {
+	t := goType(schema)
+	if strings.HasPrefix(t, "struct {") {
+		fmt.Fprintf(buf, "type %s %s\n\n", name, t)
+		return name
+	}
+	if strings.HasPrefix(t, "[]struct {") {
+		fmt.Fprintf(buf, "type %sItem %s\n\n", name, strings.TrimPrefix(t, "[]"))
+		return "[]" + name + "Item"
+	}
+	return t
+}
+
+// Lists, maps & raw values are already nullable
+func pointerType(t string, nullable bool) string {
+	if !nullable || strings.HasPrefix(t, "[]") || strings.HasPrefix(t, "map[") || t == "json.RawMessage" {
+		return t
+	}
+	return "*" + t
+}
+
+func structType(schema *Schema) string {
+	var buf strings.Builder
+	buf.WriteString("struct {\n")
+	for _, property := range schema.Properties {
+		tag := property.Name
+		if !contains(schema.Required, property.Name) {
+			tag += ",omitempty"
+		}
+		fmt.Fprintf(&buf, "\t%s %s `json:%q`\n", exportedName(property.Name), goType(property.Schema), tag)
+	}
+	buf.WriteString("}")
+	return buf.String()
+}
+
+func contains(values []string, value string) bool {
+	for _, existing := range values {
+		if existing == value {
+			return true
+		}
+	}
+	return false
+}
+
+func writeOperation(buf *bytes.Buffer, method string, path string, op *Operation) {
+	name := exportedName(op.OperationId)
+	ok := op.Responses["200"]
+	if ok == nil {
+		// Websockets are left to websocket clients
+		return
+	}
+	for contentType := range ok.Content {
+		if contentType == "text/event-stream" {
+			return
+		}
+	}
+	if op.Parameters != nil && op.Parameters[len(op.Parameters)-1].Name == "file" {
+		// Static files are fetched by their URL
+		return
+	}
+
+	args := []string{"ctx context.Context"}
+	if len(op.Parameters) > 0 {
+		fmt.Fprintf(buf, "// Parameters of %s\ntype %sParams struct {\n", name, name)
+		for _, p := range op.Parameters {
+			fmt.Fprintf(buf, "\t%s %s\n", exportedName(p.Name), paramType(p))
+		}
+		buf.WriteString("}\n\n")
+		args = append(args, "params "+name+"Params")
+	}
+
+	bodyType := ""
+	if op.RequestBody != nil {
+		for contentType, media := range op.RequestBody.Content {
+			switch contentType {
+			case "application/json":
+				bodyType = declaredType(buf, name+"Body", media.Schema)
+				args = append(args, "body "+bodyType)
+			case "text/plain":
+				bodyType = "string"
+				args = append(args, "body string")
+			case "multipart/form-data":
+				bodyType = "form"
+				fmt.Fprintf(buf, "// Form of %s\ntype %sForm struct {\n", name, name)
+				for _, property := range media.Schema.Properties {
+					fieldType := "string"
+					if property.Schema.Format == "binary" {
+						fieldType = "File"
+					}
+					fmt.Fprintf(buf, "\t%s %s\n", exportedName(property.Name), fieldType)
+				}
+				buf.WriteString("}\n\n")
+				args = append(args, "form "+name+"Form")
+			}
+		}
+	}
+
+	// Responses in more than one format are returned as they are
+	returnType, call, dataType := "error", "c.empty", ""
+	if media, isJson := ok.Content["application/json"]; isJson && len(ok.Content) == 1 {
+		switch {
+		case media.Schema.Ref == "#/components/schemas/Result":
+			returnType, call = "(string, error)", "c.result"
+		case op.Paginated:
+			dataType = declaredType(buf, name+"Item", media.Schema.Properties[0].Schema.Items)
+			returnType, call = "(*Page["+dataType+"], error)", "getPage"
+		case len(media.Schema.Properties) > 0:
+			dataType = declaredType(buf, name+"Data", media.Schema.Properties[0].Schema)
+			returnType, call = "("+dataType+", error)", "getData"
+		default:
+			returnType, call = "(*Binary, error)", "c.binary"
+		}
+	} else if len(ok.Content) > 0 {
+		returnType, call = "(*Binary, error)", "c.binary"
+	}
+
+	if op.Description != "" {
+		for _, line := range strings.Split(op.Description, "\n") {
+			fmt.Fprintf(buf, "// %s\n", line)
+		}
+		buf.WriteString("//\n")
+	}
+	fmt.Fprintf(buf, "// %s %s\n", method, path)
+	fmt.Fprintf(buf, "func (c *Client) %s(%s) %s {\n", name, strings.Join(args, ", "), returnType)
+
+	// Path & query
+	pathExpr := fmt.Sprintf("%q", path)
+	hasQuery := false
+	for _, p := range op.Parameters {
+		if p.In == "path" {
+			pathExpr = strings.Replace(pathExpr, "{"+p.Name+"}", `" + url.PathEscape(`+paramString(p, "params."+exportedName(p.Name))+`) + "`, 1)
+			continue
+		}
+		hasQuery = true
+	}
+	pathExpr = strings.TrimSuffix(strings.TrimPrefix(pathExpr, `"" + `), ` + ""`)
+	if hasQuery {
+		buf.WriteString("\tquery := url.Values{}\n")
+		for _, p := range op.Parameters {
+			if p.In != "query" {
+				continue
+			}
+			field := "params." + exportedName(p.Name)
+			switch {
+			case p.Schema.Type == "array":
+				fmt.Fprintf(buf, "\tfor _, value := range %s {\n\t\tquery.Add(%q, value)\n\t}\n", field, p.Name)
+			case p.Schema.Type == "string":
+				fmt.Fprintf(buf, "\tif %s != \"\" {\n\t\tquery.Set(%q, %s)\n\t}\n", field, p.Name, field)
+			default:
+				fmt.Fprintf(buf, "\tif %s != nil {\n\t\tquery.Set(%q, %s)\n\t}\n", field, p.Name, paramString(p, "*"+field))
+			}
+		}
+	} else {
+		buf.WriteString("\tvar query url.Values\n")
+	}
+
+	// Body
+	switch bodyType {
+	case "":
+		buf.WriteString("\tvar reqBody requestBody\n")
+	case "string":
+		buf.WriteString("\treqBody := textBody(body)\n")
+	case "form":
+		buf.WriteString("\treqBody := formBody(map[string]string{")
+		files := make([]string, 0)
+		for _, property := range op.RequestBody.Content["multipart/form-data"].Schema.Properties {
+			if property.Schema.Format == "binary" {
+				files = append(files, fmt.Sprintf("%q: form.%s", property.Name, exportedName(property.Name)))
+				continue
+			}
+			fmt.Fprintf(buf, "%q: form.%s, ", property.Name, exportedName(property.Name))
+		}
+		fmt.Fprintf(buf, "}, map[string]File{%s})\n", strings.Join(files, ", "))
+	default:
+		buf.WriteString("\treqBody := jsonBody(body)\n")
+	}
+
+	methodExpr := "http.Method" + method[:1] + strings.ToLower(method[1:])
+	switch call {
+	case "getPage", "getData":
+		fmt.Fprintf(buf, "\treturn %s[%s](c, ctx, %s, %s, query, reqBody)\n", call, dataType, methodExpr, pathExpr)
+	default:
+		fmt.Fprintf(buf, "\treturn %s(ctx, %s, %s, query, reqBody)\n", call, methodExpr, pathExpr)
+	}
+	buf.WriteString("}\n\n")
+}
+
+// Query parameters are optional, only set ones are sent
+func paramType(p *Parameter) string {
+	t := goType(p.Schema)
+	if p.In == "path" || p.Schema.Type == "string" || p.Schema.Type == "array" {
+		return t
+	}
+	return "*" + t
+}
+
+func paramString(p *Parameter, value string) string {
+	switch p.Schema.Type {
+	case "integer":
+		return "strconv.Itoa(" + value + ")"
+	case "number":
+		return "strconv.FormatFloat(" + value + ", 'f', -1, 64)"
+	case "boolean":
+		return "strconv.FormatBool(" + value + ")"
+	}
+	return value
+}
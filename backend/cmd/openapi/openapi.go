@@ -0,0 +1,297 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	routeutils "github.com/keep-starknet-strange/art-peace/backend/routes/utils"
+)
+
+// Generate routes/openapi.json & the client package from the routes package
+// Handlers are type checked, so parameters, bodies & responses come from the code itself
+//
+//	go run ./cmd/openapi          write both files
+//	go run ./cmd/openapi -check   fail when they are out of date
+//	go run ./cmd/openapi -print   write the document to stdout, for the routes tests
+func main() {
+	dir := flag.String("dir", ".", "Backend module directory")
+	check := flag.Bool("check", false, "Check the generated files are up to date instead of writing them")
+	stdout := flag.Bool("print", false, "Write the document to stdout instead of the files")
+	flag.Parse()
+
+	a, err := loadRoutes(*dir)
+	if err != nil {
+		fmt.Println("Failed to load routes:", err)
+		os.Exit(1)
+	}
+	spec := buildSpec(a, a.routes())
+
+	specJson, err := marshalSpec(spec)
+	if err != nil {
+		fmt.Println("Failed to marshal OpenAPI document:", err)
+		os.Exit(1)
+	}
+	if *stdout {
+		os.Stdout.Write(specJson)
+		return
+	}
+	clientGo, err := generateClient(spec)
+	if err != nil {
+		fmt.Println("Failed to generate client:", err)
+		os.Exit(1)
+	}
+
+	outputs := []struct {
+		path    string
+		content []byte
+	}{
+		{filepath.Join(*dir, "routes", "openapi.json"), specJson},
+		{filepath.Join(*dir, "client", "api.gen.go"), clientGo},
+	}
+	stale := false
+	for _, output := range outputs {
+		path, content := output.path, output.content
+		if *check {
+			existing, err := os.ReadFile(path)
+			if err != nil || !bytes.Equal(existing, content) {
+				fmt.Println("Out of date:", path)
+				stale = true
+			}
+			continue
+		}
+		if err := os.WriteFile(path, content, 0644); err != nil {
+			fmt.Println("Failed to write", path, err)
+			os.Exit(1)
+		}
+	}
+	if stale {
+		fmt.Println("Run go generate ./routes to update them")
+		os.Exit(1)
+	}
+}
+
+type listedPackage struct {
+	ImportPath string
+	Dir        string
+	Export     string
+	GoFiles    []string
+}
+
+// Type check the routes package from source, with its dependencies read from the build cache
+func loadRoutes(dir string) (*analyzer, error) {
+	cmd := exec.Command("go", "list", "-export", "-deps", "-json", "./routes")
+	cmd.Dir = dir
+	cmd.Stderr = os.Stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, err
+	}
+
+	exports := make(map[string]string)
+	var routesPkg listedPackage
+	decoder := json.NewDecoder(bytes.NewReader(out))
+	for {
+		var pkg listedPackage
+		if err := decoder.Decode(&pkg); err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+		exports[pkg.ImportPath] = pkg.Export
+		if pkg.ImportPath == routesPath {
+			routesPkg = pkg
+		}
+	}
+
+	fset := token.NewFileSet()
+	files := make([]*ast.File, 0, len(routesPkg.GoFiles))
+	for _, name := range routesPkg.GoFiles {
+		file, err := parser.ParseFile(fset, filepath.Join(routesPkg.Dir, name), nil, parser.ParseComments)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, file)
+	}
+
+	info := &types.Info{
+		Types:     make(map[ast.Expr]types.TypeAndValue),
+		Defs:      make(map[*ast.Ident]types.Object),
+		Uses:      make(map[*ast.Ident]types.Object),
+		Instances: make(map[*ast.Ident]types.Instance),
+	}
+	config := &types.Config{
+		Importer: importer.ForCompiler(fset, "gc", func(path string) (io.ReadCloser, error) {
+			export, ok := exports[path]
+			if !ok || export == "" {
+				return nil, fmt.Errorf("no export data for %s", path)
+			}
+			return os.Open(export)
+		}),
+	}
+	pkg, err := config.Check(routesPath, fset, files, info)
+	if err != nil {
+		return nil, err
+	}
+	return newAnalyzer(fset, pkg, info, files), nil
+}
+
+// List routes don't count their items, clients page until hasNext is false
+const paginationDescription = "Page of a list route. There is no total: count is the number of items in this page, and hasNext tells if there is a next page."
+
+func buildSpec(a *analyzer, routes []*route) *Spec {
+	// Envelopes first, so they keep their names
+	for _, imported := range a.pkg.Imports() {
+		if imported.Path() != modulePath+"/routes/response" {
+			continue
+		}
+		for _, name := range []string{"Error", "Result", "Pagination"} {
+			a.schemas.schema(imported.Scope().Lookup(name).Type())
+		}
+	}
+
+	spec := &Spec{
+		OpenAPI: "3.0.3",
+		Info: Info{
+			Title:       "art/peace backend",
+			Description: "Generated from the routes package by cmd/openapi, do not edit.",
+			Version:     "1",
+		},
+		Paths: make(map[string]map[string]*Operation),
+		Components: Components{
+			Responses: map[string]*Response{
+				"Error": {
+					Description: "Error with a stable code",
+					Content:     map[string]*MediaType{"application/json": {Schema: refSchema("Error")}},
+				},
+			},
+			SecuritySchemes: map[string]*SecurityScheme{
+				"session": {
+					Type:         "http",
+					Scheme:       "bearer",
+					BearerFormat: "session token",
+					Description:  "Token returned by /login",
+				},
+				"apiKey": {
+					Type:        "apiKey",
+					In:          "header",
+					Name:        routeutils.ApiKeyHeader,
+					Description: "Admin API key",
+				},
+			},
+		},
+	}
+
+	for _, rt := range routes {
+		path := routeutils.OpenAPIPath(rt.Pattern)
+		if spec.Paths[path] == nil {
+			spec.Paths[path] = make(map[string]*Operation)
+		}
+		spec.Paths[path][strings.ToLower(rt.Method)] = buildOperation(rt)
+	}
+	// Paths with more than one method name the others after their method, ex: postGraphql
+	for _, operations := range spec.Paths {
+		if len(operations) < 2 {
+			continue
+		}
+		for method, op := range operations {
+			if method != "get" {
+				op.OperationId = method + exportedName(op.OperationId)
+			}
+		}
+	}
+	spec.Components.Schemas = a.schemas.components
+	spec.Components.Schemas["Pagination"].Description = paginationDescription
+	return spec
+}
+
+func buildOperation(rt *route) *Operation {
+	op := &Operation{
+		OperationId:   operationId(rt.Pattern),
+		Tags:          []string{rt.Tag},
+		Description:   rt.Doc,
+		Responses:     make(map[string]*Response),
+		Permission:    rt.Permission,
+		RateLimit:     rt.RateLimit,
+		NonProduction: rt.NonProduction,
+		Paginated:     rt.Paginated,
+		RequestBody:   rt.Body,
+	}
+	for _, p := range rt.Params {
+		op.Parameters = append(op.Parameters, &Parameter{
+			Name:     p.Name,
+			In:       p.In,
+			Required: p.In == "path",
+			Schema:   p.Schema,
+		})
+	}
+
+	ok := &Response{Description: "OK", Content: make(map[string]*MediaType)}
+	switch {
+	case rt.Data != nil:
+		envelope := &Schema{
+			Type:       "object",
+			Properties: Properties{{Name: "data", Schema: rt.Data}},
+			Required:   []string{"data"},
+		}
+		if rt.Paginated {
+			envelope.Properties = append(envelope.Properties, Property{Name: "pagination", Schema: refSchema("Pagination")})
+			envelope.Required = append(envelope.Required, "pagination")
+		}
+		envelope.Properties = append(envelope.Properties, Property{Name: "requestId", Schema: &Schema{Type: "string"}})
+		ok.Content["application/json"] = &MediaType{Schema: envelope}
+	case rt.Result:
+		ok.Content["application/json"] = &MediaType{Schema: refSchema("Result")}
+	}
+	for _, contentType := range rt.Content {
+		if _, exists := ok.Content[contentType]; exists {
+			continue
+		}
+		switch {
+		case contentType == "application/json":
+			ok.Content[contentType] = &MediaType{Schema: &Schema{}}
+		case contentType == "text/event-stream":
+			ok.Content[contentType] = &MediaType{Schema: &Schema{Type: "string"}}
+		default:
+			ok.Content[contentType] = &MediaType{Schema: &Schema{Type: "string", Format: "binary"}}
+		}
+	}
+	if rt.WebSocket {
+		op.Responses["101"] = &Response{Description: "Switching to the websocket protocol"}
+	} else {
+		op.Responses["200"] = ok
+	}
+	op.Responses["default"] = &Response{Ref: "#/components/responses/Error"}
+
+	switch {
+	case rt.Permission != "":
+		op.Security = []map[string][]string{{"apiKey": {}}}
+	case rt.Session:
+		op.Security = []map[string][]string{{"session": {}}}
+	case rt.ApiKey:
+		op.Security = []map[string][]string{{"session": {}}, {"apiKey": {}}}
+	}
+	return op
+}
+
+func marshalSpec(spec *Spec) ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(spec); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,797 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+const (
+	modulePath     = "github.com/keep-starknet-strange/art-peace/backend"
+	routesPath     = modulePath + "/routes"
+	routeutilsPath = modulePath + "/routes/utils"
+)
+
+// A route registered with routeutils, with what its handler reads & writes
+type route struct {
+	Method  string
+	Pattern string
+	Tag     string
+	Doc     string
+
+	Permission    string
+	RateLimit     string
+	Session       bool
+	ApiKey        bool
+	NonProduction bool
+
+	Params    []*param
+	Body      *RequestBody
+	Data      *Schema
+	Paginated bool
+	Result    bool
+	// Content types of responses that aren't JSON envelopes
+	Content   []string
+	WebSocket bool
+}
+
+type param struct {
+	Name   string
+	In     string
+	Schema *Schema
+}
+
+func (rt *route) param(name string) *param {
+	for _, p := range rt.Params {
+		if p.Name == name {
+			return p
+		}
+	}
+	p := &param{Name: name, In: "query", Schema: &Schema{Type: "string"}}
+	rt.Params = append(rt.Params, p)
+	return p
+}
+
+func (rt *route) content(contentType string) {
+	for _, existing := range rt.Content {
+		if existing == contentType {
+			return
+		}
+	}
+	rt.Content = append(rt.Content, contentType)
+}
+
+// Type checked routes package, with the declarations handlers are followed through
+type analyzer struct {
+	fset    *token.FileSet
+	pkg     *types.Package
+	info    *types.Info
+	files   []*ast.File
+	schemas *schemas
+
+	funcs map[*types.Func]*ast.FuncDecl
+	vars  map[types.Object]ast.Expr
+	// Right hand side of every assignment & the index of the value it gives
+	defs map[types.Object]definition
+}
+
+type definition struct {
+	expr  ast.Expr
+	index int
+}
+
+func newAnalyzer(fset *token.FileSet, pkg *types.Package, info *types.Info, files []*ast.File) *analyzer {
+	a := &analyzer{
+		fset:    fset,
+		pkg:     pkg,
+		info:    info,
+		files:   files,
+		schemas: newSchemas(),
+		funcs:   make(map[*types.Func]*ast.FuncDecl),
+		vars:    make(map[types.Object]ast.Expr),
+		defs:    make(map[types.Object]definition),
+	}
+	for _, file := range files {
+		for _, decl := range file.Decls {
+			switch decl := decl.(type) {
+			case *ast.FuncDecl:
+				if fn, ok := info.Defs[decl.Name].(*types.Func); ok {
+					a.funcs[fn] = decl
+				}
+			case *ast.GenDecl:
+				for _, spec := range decl.Specs {
+					if value, ok := spec.(*ast.ValueSpec); ok && len(value.Values) == len(value.Names) {
+						for idx, name := range value.Names {
+							a.vars[info.Defs[name]] = value.Values[idx]
+						}
+					}
+				}
+			}
+		}
+		ast.Inspect(file, func(node ast.Node) bool {
+			switch node := node.(type) {
+			case *ast.AssignStmt:
+				for idx, lhs := range node.Lhs {
+					ident, ok := lhs.(*ast.Ident)
+					if !ok {
+						continue
+					}
+					obj := info.ObjectOf(ident)
+					if obj == nil {
+						continue
+					}
+					if len(node.Rhs) == len(node.Lhs) {
+						a.defs[obj] = definition{expr: node.Rhs[idx]}
+					} else if len(node.Rhs) == 1 {
+						a.defs[obj] = definition{expr: node.Rhs[0], index: idx}
+					}
+				}
+			case *ast.ValueSpec:
+				for idx, name := range node.Names {
+					if len(node.Values) == len(node.Names) {
+						a.defs[info.Defs[name]] = definition{expr: node.Values[idx]}
+					} else if len(node.Values) == 1 {
+						a.defs[info.Defs[name]] = definition{expr: node.Values[0], index: idx}
+					}
+				}
+			}
+			return true
+		})
+	}
+	return a
+}
+
+// Function or method called, nil for conversions, builtins & function values
+func (a *analyzer) callee(call *ast.CallExpr) *types.Func {
+	fun := ast.Unparen(call.Fun)
+	switch index := fun.(type) {
+	case *ast.IndexExpr:
+		fun = index.X
+	case *ast.IndexListExpr:
+		fun = index.X
+	}
+	var ident *ast.Ident
+	switch fun := fun.(type) {
+	case *ast.Ident:
+		ident = fun
+	case *ast.SelectorExpr:
+		ident = fun.Sel
+	default:
+		return nil
+	}
+	fn, _ := a.info.Uses[ident].(*types.Func)
+	return fn
+}
+
+func isFunc(fn *types.Func, pkgPath string, names ...string) bool {
+	if fn == nil || fn.Pkg() == nil || fn.Pkg().Path() != pkgPath {
+		return false
+	}
+	for _, name := range names {
+		if fn.Name() == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Method of a named type, ex: (net/url.Values).Get
+func isMethod(fn *types.Func, typePath string, name string) bool {
+	if fn == nil || fn.Name() != name {
+		return false
+	}
+	signature, ok := fn.Type().(*types.Signature)
+	if !ok || signature.Recv() == nil {
+		return false
+	}
+	recv := signature.Recv().Type()
+	if pointer, ok := recv.(*types.Pointer); ok {
+		recv = pointer.Elem()
+	}
+	named, ok := recv.(*types.Named)
+	return ok && named.Obj().Pkg() != nil && named.Obj().Pkg().Path()+"."+named.Obj().Name() == typePath
+}
+
+// Type argument of a generic call, ex: T of core.PostgresQueryJson[T]
+func (a *analyzer) typeArg(call *ast.CallExpr) types.Type {
+	var ident *ast.Ident
+	switch fun := ast.Unparen(call.Fun).(type) {
+	case *ast.IndexExpr:
+		ident = selectorIdent(fun.X)
+	case *ast.IndexListExpr:
+		ident = selectorIdent(fun.X)
+	default:
+		ident = selectorIdent(fun)
+	}
+	if ident == nil {
+		return nil
+	}
+	instance, ok := a.info.Instances[ident]
+	if !ok || instance.TypeArgs.Len() == 0 {
+		return nil
+	}
+	return instance.TypeArgs.At(0)
+}
+
+func selectorIdent(expr ast.Expr) *ast.Ident {
+	switch expr := expr.(type) {
+	case *ast.Ident:
+		return expr
+	case *ast.SelectorExpr:
+		return expr.Sel
+	}
+	return nil
+}
+
+func (a *analyzer) stringValue(expr ast.Expr) (string, bool) {
+	value := a.info.Types[expr].Value
+	if value == nil || value.Kind() != constant.String {
+		return "", false
+	}
+	return constant.StringVal(value), true
+}
+
+// Object an expression refers to, through parentheses & dereferences
+func (a *analyzer) object(expr ast.Expr) types.Object {
+	for {
+		switch inner := expr.(type) {
+		case *ast.ParenExpr:
+			expr = inner.X
+			continue
+		case *ast.StarExpr:
+			expr = inner.X
+			continue
+		case *ast.Ident:
+			return a.info.ObjectOf(inner)
+		case *ast.SelectorExpr:
+			return a.info.ObjectOf(inner.Sel)
+		}
+		return nil
+	}
+}
+
+// Routes registered by the package's routeutils.Get, Post & Handle calls
+func (a *analyzer) routes() []*route {
+	routes := make([]*route, 0)
+	for _, file := range a.files {
+		tag := strings.TrimSuffix(filepath.Base(a.fset.File(file.Pos()).Name()), ".go")
+		ast.Inspect(file, func(node ast.Node) bool {
+			call, ok := node.(*ast.CallExpr)
+			if !ok {
+				return true
+			}
+			fn := a.callee(call)
+			if !isFunc(fn, routeutilsPath, "Get", "Post", "Handle") {
+				return true
+			}
+
+			args := call.Args
+			rt := &route{Tag: tag}
+			if fn.Name() == "Handle" {
+				method, _ := a.stringValue(args[0])
+				rt.Method = method
+				args = args[1:]
+			} else {
+				rt.Method = strings.ToUpper(fn.Name())
+			}
+			rt.Pattern, _ = a.stringValue(args[0])
+			for _, middleware := range args[2:] {
+				a.middleware(rt, middleware)
+			}
+			a.handler(rt, args[1])
+			routes = append(routes, rt)
+			return true
+		})
+	}
+	sort.Slice(routes, func(i, j int) bool {
+		if routes[i].Pattern == routes[j].Pattern {
+			return routes[i].Method < routes[j].Method
+		}
+		return routes[i].Pattern < routes[j].Pattern
+	})
+	return routes
+}
+
+func (a *analyzer) middleware(rt *route, expr ast.Expr) {
+	if call, ok := ast.Unparen(expr).(*ast.CallExpr); ok {
+		fn := a.callee(call)
+		if isFunc(fn, routeutilsPath, "RequirePermission") && len(call.Args) == 1 {
+			rt.Permission, _ = a.stringValue(call.Args[0])
+		}
+		if isFunc(fn, routeutilsPath, "RateLimit") && len(call.Args) == 1 {
+			rt.RateLimit, _ = a.stringValue(call.Args[0])
+		}
+		return
+	}
+
+	obj := a.object(expr)
+	if obj == nil || obj.Pkg() == nil {
+		return
+	}
+	// Middleware kept in a package variable, ex: renderRateLimit
+	if value, ok := a.vars[obj]; ok && obj.Pkg() == a.pkg {
+		a.middleware(rt, value)
+		return
+	}
+	if obj.Pkg().Path() != routeutilsPath {
+		return
+	}
+	switch obj.Name() {
+	case "Auth":
+		rt.Session = true
+	case "AuditApiKey":
+		rt.ApiKey = true
+	case "NonProduction":
+		rt.NonProduction = true
+	}
+}
+
+func (a *analyzer) handler(rt *route, expr ast.Expr) {
+	var doc string
+	var body *ast.BlockStmt
+	switch handler := ast.Unparen(expr).(type) {
+	case *ast.FuncLit:
+		body = handler.Body
+	case *ast.Ident:
+		if fn, ok := a.info.Uses[handler].(*types.Func); ok && a.funcs[fn] != nil {
+			body = a.funcs[fn].Body
+			doc = a.funcs[fn].Doc.Text()
+		}
+	}
+	if body == nil {
+		// Any other http.Handler is a file server under the pattern
+		rt.content("application/octet-stream")
+		rt.param("file").In = "path"
+		return
+	}
+	rt.Doc = strings.TrimSpace(doc)
+
+	h := &handlerAnalysis{analyzer: a, route: rt, visited: make(map[*ast.BlockStmt]bool), params: make(map[types.Object]*param)}
+	h.walk(body)
+	h.finish()
+}
+
+// State while walking a handler & the package functions it calls
+type handlerAnalysis struct {
+	*analyzer
+	route   *route
+	visited map[*ast.BlockStmt]bool
+	// Variables holding a query parameter, to type them from their conversions
+	params map[types.Object]*param
+
+	data       *Schema
+	literal    *Schema
+	setHeaders bool
+	rawWrite   bool
+	mapBodies  []types.Object
+}
+
+func (h *handlerAnalysis) walk(body *ast.BlockStmt) {
+	if h.visited[body] {
+		return
+	}
+	h.visited[body] = true
+
+	ast.Inspect(body, func(node ast.Node) bool {
+		switch node := node.(type) {
+		case *ast.AssignStmt:
+			// x := r.URL.Query().Get("x")
+			if len(node.Lhs) == 1 && len(node.Rhs) == 1 {
+				if p := h.queryParam(node.Rhs[0]); p != nil {
+					if obj := h.object(node.Lhs[0]); obj != nil {
+						h.params[obj] = p
+					}
+				}
+			}
+		case *ast.IndexExpr:
+			// query["x"] is a repeated parameter
+			if isNamed(h.info.TypeOf(node.X), "net/url.Values") {
+				if name, ok := h.stringValue(node.Index); ok {
+					h.route.param(name).Schema = &Schema{Type: "array", Items: &Schema{Type: "string"}}
+				}
+			}
+		case *ast.CallExpr:
+			h.call(node)
+		}
+		return true
+	})
+}
+
+func isNamed(t types.Type, path string) bool {
+	named, ok := types.Unalias(t).(*types.Named)
+	return ok && named.Obj().Pkg() != nil && named.Obj().Pkg().Path()+"."+named.Obj().Name() == path
+}
+
+// Parameter read by r.URL.Query().Get("x") or query.Get("x")
+func (h *handlerAnalysis) queryParam(expr ast.Expr) *param {
+	call, ok := ast.Unparen(expr).(*ast.CallExpr)
+	if !ok || len(call.Args) != 1 || !isMethod(h.callee(call), "net/url.Values", "Get") {
+		return nil
+	}
+	name, ok := h.stringValue(call.Args[0])
+	if !ok {
+		return nil
+	}
+	return h.route.param(name)
+}
+
+func (h *handlerAnalysis) call(call *ast.CallExpr) {
+	fn := h.callee(call)
+	if fn == nil {
+		return
+	}
+
+	h.queryParam(call)
+	if isFunc(fn, "strconv", "Atoi", "ParseInt", "ParseUint", "ParseFloat", "ParseBool") && len(call.Args) > 0 {
+		p := h.queryParam(call.Args[0])
+		if p == nil {
+			p = h.params[h.object(call.Args[0])]
+		}
+		if p != nil && p.Schema.Type == "string" {
+			switch fn.Name() {
+			case "ParseFloat":
+				p.Schema = &Schema{Type: "number"}
+			case "ParseBool":
+				p.Schema = &Schema{Type: "boolean"}
+			default:
+				p.Schema = &Schema{Type: "integer"}
+			}
+		}
+	}
+
+	switch {
+	case isFunc(fn, routeutilsPath, "ParsePagination", "ParsePaginationWithDefault"):
+		h.route.param("page").Schema = &Schema{Type: "integer"}
+		h.route.param("pageLength").Schema = &Schema{Type: "integer"}
+		h.route.param("cursor").Schema = &Schema{Type: "string"}
+	case isFunc(fn, routeutilsPath, "ReadJsonBody"):
+		h.jsonBody(call)
+	case isFunc(fn, routeutilsPath, "WriteResultJson"):
+		h.route.Result = true
+	case isFunc(fn, routeutilsPath, "WriteStringDataJson"):
+		h.setData(&Schema{Type: "string"}, false)
+	case isFunc(fn, routeutilsPath, "WriteDataJson") && len(call.Args) == 2:
+		if schema, literal := h.dataSchema(call.Args[1]); schema != nil {
+			h.setData(schema, literal)
+		}
+	case isFunc(fn, routeutilsPath, "WritePageJson") && len(call.Args) == 4:
+		h.route.Paginated = true
+		items := h.bytesSchema(call.Args[1])
+		if items == nil || items.Type != "array" {
+			items = &Schema{Type: "array", Items: &Schema{}}
+		}
+		h.setData(items, false)
+	case isFunc(fn, routeutilsPath, "WriteImage") && len(call.Args) == 3:
+		contentType, ok := h.stringValue(call.Args[1])
+		if !ok {
+			contentType = "application/octet-stream"
+		}
+		h.route.content(contentType)
+	case isFunc(fn, routeutilsPath, "SetupHeaders"):
+		h.setHeaders = true
+	case isMethod(fn, "net/http.ResponseWriter", "Write"):
+		h.rawWrite = true
+	case isFunc(fn, "io", "ReadAll") && len(call.Args) == 1 && h.isRequestBody(call.Args[0]):
+		h.route.Body = &RequestBody{Required: true, Content: map[string]*MediaType{
+			"text/plain": {Schema: &Schema{Type: "string"}},
+		}}
+	case isMethod(fn, "net/http.Request", "FormFile") && len(call.Args) == 1:
+		if name, ok := h.stringValue(call.Args[0]); ok {
+			h.formField(name, &Schema{Type: "string", Format: "binary"})
+		}
+	case isMethod(fn, "net/http.Request", "FormValue") && len(call.Args) == 1:
+		if name, ok := h.stringValue(call.Args[0]); ok {
+			h.formField(name, &Schema{Type: "string"})
+		}
+	case isMethod(fn, "github.com/gorilla/websocket.Upgrader", "Upgrade"):
+		h.route.WebSocket = true
+	case fn.Name() == "RegisterStream" && fn.Pkg() != nil && fn.Pkg().Path() == modulePath+"/core":
+		h.route.content("text/event-stream")
+	case fn.Pkg() == h.pkg && h.funcs[fn] != nil:
+		// Follow package helpers, they often read parameters or write the response
+		h.walk(h.funcs[fn].Body)
+	}
+}
+
+func (h *handlerAnalysis) isRequestBody(expr ast.Expr) bool {
+	selector, ok := ast.Unparen(expr).(*ast.SelectorExpr)
+	return ok && selector.Sel.Name == "Body" && isNamed(derefType(h.info.TypeOf(selector.X)), "net/http.Request")
+}
+
+func derefType(t types.Type) types.Type {
+	if pointer, ok := t.(*types.Pointer); ok {
+		return pointer.Elem()
+	}
+	return t
+}
+
+func (h *handlerAnalysis) jsonBody(call *ast.CallExpr) {
+	bodyType := h.typeArg(call)
+	if bodyType == nil {
+		return
+	}
+	// Bodies read as maps are described by the keys the handler reads
+	if m, ok := bodyType.Underlying().(*types.Map); ok {
+		for obj, def := range h.defs {
+			if def.expr == ast.Expr(call) && def.index == 0 {
+				h.mapBodies = append(h.mapBodies, obj)
+			}
+		}
+		if len(h.mapBodies) > 0 {
+			h.route.Body = &RequestBody{Required: true, Content: map[string]*MediaType{
+				"application/json": {Schema: &Schema{Type: "object", AdditionalProperties: h.schemas.schema(m.Elem())}},
+			}}
+			return
+		}
+	}
+	h.route.Body = &RequestBody{Required: true, Content: map[string]*MediaType{
+		"application/json": {Schema: h.schemas.schema(bodyType)},
+	}}
+}
+
+func (h *handlerAnalysis) formField(name string, schema *Schema) {
+	if h.route.Body == nil || h.route.Body.Content["multipart/form-data"] == nil {
+		h.route.Body = &RequestBody{Required: true, Content: map[string]*MediaType{
+			"multipart/form-data": {Schema: &Schema{Type: "object"}},
+		}}
+	}
+	form := h.route.Body.Content["multipart/form-data"].Schema
+	for _, property := range form.Properties {
+		if property.Name == name {
+			return
+		}
+	}
+	form.Properties = append(form.Properties, Property{Name: name, Schema: schema})
+	if schema.Format == "binary" {
+		form.Required = append(form.Required, name)
+	}
+}
+
+// Literal data, like the "0" written when nothing is stored, only counts when nothing else is written
+func (h *handlerAnalysis) setData(schema *Schema, literal bool) {
+	if literal {
+		if h.literal == nil {
+			h.literal = schema
+		}
+		return
+	}
+	if h.data == nil {
+		h.data = schema
+		return
+	}
+	// Handlers writing different shapes, ex: a resource or the job rendering it
+	h.data = oneOf(h.data, schema)
+}
+
+func oneOf(current *Schema, schema *Schema) *Schema {
+	variants := current.OneOf
+	if variants == nil {
+		variants = []*Schema{current}
+	}
+	schemaJson, _ := json.Marshal(schema)
+	for _, variant := range variants {
+		if variantJson, _ := json.Marshal(variant); bytes.Equal(variantJson, schemaJson) {
+			return current
+		}
+	}
+	return &Schema{OneOf: append(variants, schema)}
+}
+
+// Schema of the value given to WriteDataJson & if it came from a literal
+func (h *handlerAnalysis) dataSchema(expr ast.Expr) (*Schema, bool) {
+	expr = ast.Unparen(expr)
+	if value, ok := h.stringValue(expr); ok {
+		return literalSchema(value), true
+	}
+	switch expr := expr.(type) {
+	case *ast.CallExpr:
+		if tv, ok := h.info.Types[expr.Fun]; ok && tv.IsType() && len(expr.Args) == 1 {
+			if schema := h.bytesSchema(expr.Args[0]); schema != nil {
+				return schema, false
+			}
+			return h.dataSchema(expr.Args[0])
+		}
+		fn := h.callee(expr)
+		switch {
+		case isFunc(fn, "strconv", "Itoa", "FormatInt", "FormatUint"):
+			return &Schema{Type: "integer"}, false
+		case isFunc(fn, "strconv", "FormatBool"):
+			return &Schema{Type: "boolean"}, false
+		case isFunc(fn, "strconv", "FormatFloat"):
+			return &Schema{Type: "number"}, false
+		}
+	case *ast.BinaryExpr:
+		// "\"0x" + address + "\""
+		if left, ok := h.stringValue(expr.X); ok && strings.HasPrefix(left, "\"") {
+			return &Schema{Type: "string"}, false
+		}
+	case *ast.Ident, *ast.StarExpr:
+		if def, ok := h.defs[h.object(expr)]; ok && def.index == 0 {
+			if schema, literal := h.dataSchema(def.expr); schema != nil {
+				return schema, literal
+			}
+		}
+		if obj := h.object(expr); obj != nil {
+			// Values read from Postgres, ex: *core.PostgresQueryOne[int]
+			// Strings holding valid JSON are sent as JSON, so a literal written next to them says more
+			schema := h.schemas.schema(derefType(obj.Type()))
+			return schema, schema.Type == "string"
+		}
+	}
+	return nil, false
+}
+
+func literalSchema(value string) *Schema {
+	var decoded interface{}
+	if err := json.Unmarshal([]byte(value), &decoded); err != nil {
+		return &Schema{Type: "string"}
+	}
+	switch decoded := decoded.(type) {
+	case float64:
+		if decoded == float64(int64(decoded)) {
+			return &Schema{Type: "integer"}
+		}
+		return &Schema{Type: "number"}
+	case string:
+		return &Schema{Type: "string"}
+	case bool:
+		return &Schema{Type: "boolean"}
+	}
+	// Empty lists & null say nothing about the items
+	return nil
+}
+
+// Schema of JSON bytes, from json.Marshal or the Postgres JSON queries
+func (h *handlerAnalysis) bytesSchema(expr ast.Expr) *Schema {
+	def, ok := h.defs[h.object(expr)]
+	if !ok || def.index != 0 {
+		return nil
+	}
+	call, ok := ast.Unparen(def.expr).(*ast.CallExpr)
+	if !ok {
+		return nil
+	}
+	fn := h.callee(call)
+	switch {
+	case isFunc(fn, "encoding/json", "Marshal", "MarshalIndent") && len(call.Args) > 0:
+		return h.valueSchema(call.Args[0])
+	case isFunc(fn, modulePath+"/core", "PostgresQueryJson"):
+		if t := h.typeArg(call); t != nil {
+			return &Schema{Type: "array", Items: h.schemas.schema(t)}
+		}
+	case isFunc(fn, modulePath+"/core", "PostgresQueryOneJson"):
+		if t := h.typeArg(call); t != nil {
+			return h.schemas.schema(t)
+		}
+	}
+	return nil
+}
+
+// Schema of a marshalled value, maps built in place are described by their keys
+func (h *handlerAnalysis) valueSchema(expr ast.Expr) *Schema {
+	if lit, ok := ast.Unparen(expr).(*ast.CompositeLit); ok {
+		if m, ok := h.info.TypeOf(lit).Underlying().(*types.Map); ok {
+			schema := &Schema{Type: "object"}
+			for _, elt := range lit.Elts {
+				kv, ok := elt.(*ast.KeyValueExpr)
+				if !ok {
+					continue
+				}
+				key, ok := h.stringValue(kv.Key)
+				if !ok {
+					return h.schemas.schema(m)
+				}
+				schema.Properties = append(schema.Properties, Property{Name: key, Schema: h.schemas.schema(m.Elem())})
+				schema.Required = append(schema.Required, key)
+			}
+			return schema
+		}
+	}
+	t := h.info.TypeOf(expr)
+	if t == nil {
+		return nil
+	}
+	// Marshalling a pointer gives the value it points to
+	return h.schemas.schema(derefType(t))
+}
+
+func (h *handlerAnalysis) finish() {
+	rt := h.route
+	if h.data == nil {
+		h.data = h.literal
+	}
+	if h.data != nil {
+		rt.Data = h.data
+	}
+
+	// Keys read from map bodies, ex: (*jsonBody)["username"]
+	if len(h.mapBodies) > 0 {
+		schema := rt.Body.Content["application/json"].Schema
+		keys := &Schema{Type: "object"}
+		for body := range h.visited {
+			ast.Inspect(body, func(node ast.Node) bool {
+				index, ok := node.(*ast.IndexExpr)
+				if !ok {
+					return true
+				}
+				key, ok := h.stringValue(index.Index)
+				if !ok {
+					return true
+				}
+				for _, obj := range h.mapBodies {
+					if h.object(index.X) == obj {
+						keys.addProperty(key, schema.AdditionalProperties)
+					}
+				}
+				return true
+			})
+		}
+		if len(keys.Properties) > 0 {
+			sort.Slice(keys.Properties, func(i, j int) bool {
+				return keys.Properties[i].Name < keys.Properties[j].Name
+			})
+			rt.Body.Content["application/json"].Schema = keys
+		}
+	}
+
+	if rt.Data == nil && !rt.Result && len(rt.Content) == 0 {
+		// Bodies written directly, like the canvas bitfield
+		if h.rawWrite && h.setHeaders {
+			rt.content("application/json")
+		} else if h.rawWrite {
+			rt.content("application/octet-stream")
+		}
+	}
+
+	// Path parameters are also read from the query by handlers
+	for _, match := range pathParams(rt.Pattern) {
+		rt.param(match).In = "path"
+	}
+	sort.SliceStable(rt.Params, func(i, j int) bool {
+		return rt.Params[i].In == "path" && rt.Params[j].In != "path"
+	})
+}
+
+func (schema *Schema) addProperty(name string, property *Schema) {
+	for _, existing := range schema.Properties {
+		if existing.Name == name {
+			return
+		}
+	}
+	schema.Properties = append(schema.Properties, Property{Name: name, Schema: property})
+}
+
+func pathParams(pattern string) []string {
+	params := make([]string, 0)
+	for _, segment := range strings.Split(pattern, "/") {
+		if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") && segment != "{$}" {
+			params = append(params, strings.TrimSuffix(strings.Trim(segment, "{}"), "..."))
+		}
+	}
+	return params
+}
+
+// Operation id from the pattern, ex: /worlds/{worldId}/canvas -> worldsByWorldIdCanvas
+func operationId(pattern string) string {
+	parts := make([]string, 0)
+	for _, segment := range strings.Split(strings.ReplaceAll(pattern, "{$}", ""), "/") {
+		if strings.HasPrefix(segment, "{") {
+			segment = "by-" + strings.Trim(segment, "{}.")
+		}
+		parts = append(parts, exportedName(segment))
+	}
+	name := strings.Join(parts, "")
+	if name == "" {
+		name = "Root"
+	}
+	if strings.HasSuffix(pattern, "/") && pattern != "/" && !strings.HasSuffix(pattern, "{$}") {
+		name += "Files"
+	}
+	return strings.ToLower(name[:1]) + name[1:]
+}
@@ -0,0 +1,304 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"go/constant"
+	"go/types"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// OpenAPI 3.0 document, only the parts the backend uses
+type Spec struct {
+	OpenAPI    string                           `json:"openapi"`
+	Info       Info                             `json:"info"`
+	Paths      map[string]map[string]*Operation `json:"paths"`
+	Components Components                       `json:"components"`
+}
+
+type Info struct {
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	Version     string `json:"version"`
+}
+
+type Components struct {
+	Schemas         map[string]*Schema         `json:"schemas"`
+	Responses       map[string]*Response       `json:"responses"`
+	SecuritySchemes map[string]*SecurityScheme `json:"securitySchemes"`
+}
+
+type SecurityScheme struct {
+	Type         string `json:"type"`
+	Scheme       string `json:"scheme,omitempty"`
+	BearerFormat string `json:"bearerFormat,omitempty"`
+	In           string `json:"in,omitempty"`
+	Name         string `json:"name,omitempty"`
+	Description  string `json:"description,omitempty"`
+}
+
+type Operation struct {
+	OperationId string                `json:"operationId"`
+	Tags        []string              `json:"tags"`
+	Description string                `json:"description,omitempty"`
+	Parameters  []*Parameter          `json:"parameters,omitempty"`
+	RequestBody *RequestBody          `json:"requestBody,omitempty"`
+	Responses   map[string]*Response  `json:"responses"`
+	Security    []map[string][]string `json:"security,omitempty"`
+	// Extensions for the backend's middleware
+	Permission    string `json:"x-permission,omitempty"`
+	RateLimit     string `json:"x-rate-limit-group,omitempty"`
+	NonProduction bool   `json:"x-non-production,omitempty"`
+	Paginated     bool   `json:"x-paginated,omitempty"`
+}
+
+type Parameter struct {
+	Name     string  `json:"name"`
+	In       string  `json:"in"`
+	Required bool    `json:"required,omitempty"`
+	Schema   *Schema `json:"schema"`
+}
+
+type RequestBody struct {
+	Required bool                  `json:"required"`
+	Content  map[string]*MediaType `json:"content"`
+}
+
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+type Response struct {
+	Ref         string                `json:"$ref,omitempty"`
+	Description string                `json:"description,omitempty"`
+	Content     map[string]*MediaType `json:"content,omitempty"`
+}
+
+type Schema struct {
+	Ref                  string     `json:"$ref,omitempty"`
+	Type                 string     `json:"type,omitempty"`
+	Description          string     `json:"description,omitempty"`
+	Format               string     `json:"format,omitempty"`
+	Enum                 []string   `json:"enum,omitempty"`
+	Nullable             bool       `json:"nullable,omitempty"`
+	Items                *Schema    `json:"items,omitempty"`
+	Properties           Properties `json:"properties,omitempty"`
+	Required             []string   `json:"required,omitempty"`
+	AdditionalProperties *Schema    `json:"additionalProperties,omitempty"`
+	AllOf                []*Schema  `json:"allOf,omitempty"`
+	OneOf                []*Schema  `json:"oneOf,omitempty"`
+}
+
+type Property struct {
+	Name   string
+	Schema *Schema
+}
+
+// Properties keep the Go field order, so the document reads like the structs
+type Properties []Property
+
+func (properties Properties) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for idx, property := range properties {
+		if idx > 0 {
+			buf.WriteByte(',')
+		}
+		name, err := json.Marshal(property.Name)
+		if err != nil {
+			return nil, err
+		}
+		schema, err := json.Marshal(property.Schema)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(name)
+		buf.WriteByte(':')
+		buf.Write(schema)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+func refSchema(name string) *Schema {
+	return &Schema{Ref: "#/components/schemas/" + name}
+}
+
+// A $ref can't have siblings in OpenAPI 3.0, so nullable refs are wrapped
+func nullable(schema *Schema) *Schema {
+	if schema.Ref != "" {
+		return &Schema{AllOf: []*Schema{schema}, Nullable: true}
+	}
+	copied := *schema
+	copied.Nullable = true
+	return &copied
+}
+
+// Schemas of Go types, named structs become components shared by every route
+type schemas struct {
+	components map[string]*Schema
+	names      map[*types.TypeName]string
+}
+
+func newSchemas() *schemas {
+	return &schemas{
+		components: make(map[string]*Schema),
+		names:      make(map[*types.TypeName]string),
+	}
+}
+
+func (s *schemas) schema(t types.Type) *Schema {
+	switch t := types.Unalias(t).(type) {
+	case *types.Pointer:
+		return nullable(s.schema(t.Elem()))
+	case *types.Named:
+		return s.named(t)
+	case *types.Basic:
+		return basicSchema(t)
+	case *types.Slice:
+		if basic, ok := t.Elem().Underlying().(*types.Basic); ok && basic.Kind() == types.Byte {
+			return &Schema{Type: "string", Format: "byte"}
+		}
+		return &Schema{Type: "array", Items: s.schema(t.Elem())}
+	case *types.Array:
+		return &Schema{Type: "array", Items: s.schema(t.Elem())}
+	case *types.Map:
+		return &Schema{Type: "object", AdditionalProperties: s.schema(t.Elem())}
+	case *types.Struct:
+		return s.object(t)
+	}
+	// Interfaces & anything else can hold any JSON value
+	return &Schema{}
+}
+
+func (s *schemas) named(t *types.Named) *Schema {
+	obj := t.Obj()
+	if obj.Pkg() != nil {
+		switch obj.Pkg().Path() + "." + obj.Name() {
+		case "time.Time":
+			return &Schema{Type: "string", Format: "date-time"}
+		case "encoding/json.RawMessage":
+			return &Schema{}
+		case "github.com/NethermindEth/juno/core/felt.Felt", "math/big.Int":
+			return &Schema{Type: "string"}
+		}
+	}
+
+	switch underlying := t.Underlying().(type) {
+	case *types.Struct:
+		if name, ok := s.names[obj]; ok {
+			return refSchema(name)
+		}
+		name := s.componentName(obj)
+		s.names[obj] = name
+		// Registered before the fields, so recursive types end on a ref
+		s.components[name] = &Schema{}
+		*s.components[name] = *s.object(underlying)
+		return refSchema(name)
+	case *types.Basic:
+		schema := basicSchema(underlying)
+		schema.Enum = enumValues(t)
+		return schema
+	}
+	return s.schema(t.Underlying())
+}
+
+// Exported name, prefixed with its package when two packages use the same name
+func (s *schemas) componentName(obj *types.TypeName) string {
+	name := exportedName(obj.Name())
+	if _, taken := s.components[name]; taken && obj.Pkg() != nil {
+		name = exportedName(obj.Pkg().Name()) + name
+	}
+	return name
+}
+
+func (s *schemas) object(t *types.Struct) *Schema {
+	schema := &Schema{Type: "object"}
+	for idx := 0; idx < t.NumFields(); idx++ {
+		field := t.Field(idx)
+		name, options, _ := strings.Cut(reflect.StructTag(t.Tag(idx)).Get("json"), ",")
+		if name == "-" && options == "" {
+			continue
+		}
+		if field.Embedded() && name == "" {
+			if embedded, ok := field.Type().Underlying().(*types.Struct); ok {
+				inlined := s.object(embedded)
+				schema.Properties = append(schema.Properties, inlined.Properties...)
+				schema.Required = append(schema.Required, inlined.Required...)
+				continue
+			}
+		}
+		if !field.Exported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name()
+		}
+
+		fieldSchema := s.schema(field.Type())
+		if strings.Contains(options, "string") {
+			fieldSchema = &Schema{Type: "string"}
+		}
+		schema.Properties = append(schema.Properties, Property{Name: name, Schema: fieldSchema})
+		if !strings.Contains(options, "omitempty") {
+			schema.Required = append(schema.Required, name)
+		}
+	}
+	return schema
+}
+
+func basicSchema(t *types.Basic) *Schema {
+	switch {
+	case t.Info()&types.IsBoolean != 0:
+		return &Schema{Type: "boolean"}
+	case t.Kind() == types.Int64 || t.Kind() == types.Uint64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case t.Info()&types.IsInteger != 0:
+		return &Schema{Type: "integer"}
+	case t.Info()&types.IsFloat != 0:
+		return &Schema{Type: "number"}
+	case t.Info()&types.IsString != 0:
+		return &Schema{Type: "string"}
+	}
+	return &Schema{}
+}
+
+// Values of the constants declared with a named string type, ex: response.ErrorCode
+func enumValues(t *types.Named) []string {
+	if t.Obj().Pkg() == nil {
+		return nil
+	}
+	values := make([]string, 0)
+	scope := t.Obj().Pkg().Scope()
+	for _, name := range scope.Names() {
+		if c, ok := scope.Lookup(name).(*types.Const); ok && types.Identical(c.Type(), t) && c.Val().Kind() == constant.String {
+			values = append(values, constant.StringVal(c.Val()))
+		}
+	}
+	if len(values) == 0 {
+		return nil
+	}
+	sort.Strings(values)
+	return values
+}
+
+// PascalCase name from a JSON field, a path or a Go identifier
+func exportedName(name string) string {
+	var builder strings.Builder
+	upper := true
+	for _, char := range name {
+		if !(char >= 'a' && char <= 'z') && !(char >= 'A' && char <= 'Z') && !(char >= '0' && char <= '9') {
+			upper = true
+			continue
+		}
+		if upper {
+			builder.WriteString(strings.ToUpper(string(char)))
+			upper = false
+			continue
+		}
+		builder.WriteRune(char)
+	}
+	return builder.String()
+}
@@ -2,9 +2,14 @@ package main
 
 import (
 	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
 
 	"github.com/keep-starknet-strange/art-peace/backend/config"
 	"github.com/keep-starknet-strange/art-peace/backend/core"
+	"github.com/keep-starknet-strange/art-peace/backend/render"
 	"github.com/keep-starknet-strange/art-peace/backend/routes"
 	"github.com/keep-starknet-strange/art-peace/backend/routes/indexer"
 )
@@ -15,6 +20,18 @@ func main() {
 	databaseConfigFilename := flag.String("database-config", config.DefaultDatabaseConfigPath, "Database config file")
 	backendConfigFilename := flag.String("backend-config", config.DefaultBackendConfigPath, "Backend config file")
 
+	// Timelapse mode, renders a single timelapse to the output file & exits
+	timelapseOutput := flag.String("timelapse", "", "Timelapse output file ( .gif or .png for apng )")
+	worldId := flag.Int("world", 0, "World id to render, main canvas if 0")
+	round := flag.String("round", "", "Round of the main canvas, current round if empty")
+	pixelsPerFrame := flag.Int("pixels-per-frame", 0, "Placements per timelapse frame")
+	secondsPerFrame := flag.Int64("seconds-per-frame", 0, "Seconds of history per timelapse frame")
+	from := flag.Int64("from", 0, "Unix time to start the timelapse at")
+	to := flag.Int64("to", 0, "Unix time to end the timelapse at")
+	crop := flag.String("crop", "", "Region to render formatted like x,y,width,height")
+	scale := flag.Int("scale", 1, "Pixel scale")
+	delay := flag.Int("delay", render.DefaultFrameDelay, "Delay between frames in ms")
+
 	flag.Parse()
 
 	roundsConfig, err := config.LoadRoundsConfig(*roundsConfigFilename)
@@ -42,6 +59,27 @@ func main() {
 
 	core.ArtPeaceBackend = core.NewBackend(databases, roundsConfig, canvasConfig, backendConfig, true)
 
+	if *timelapseOutput != "" {
+		opts := render.TimelapseOptions{
+			From:            *from,
+			To:              *to,
+			PixelsPerFrame:  *pixelsPerFrame,
+			SecondsPerFrame: *secondsPerFrame,
+			Render:          render.Options{Scale: *scale},
+			FrameDelay:      *delay,
+			Progress: func(replayed int, total int) {
+				fmt.Printf("Replayed %d / %d pixels\n", replayed, total)
+			},
+		}
+		err := generateTimelapse(*timelapseOutput, *worldId, *round, *crop, opts)
+		if err != nil {
+			fmt.Println("Failed to generate timelapse. Error: ", err)
+			os.Exit(1)
+		}
+		fmt.Println("Generated timelapse: ", *timelapseOutput)
+		return
+	}
+
 	routes.InitBaseRoutes()
 	routes.InitCanvasRoutes()
 	indexer.InitIndexerRoutes()
@@ -49,3 +87,36 @@ func main() {
 
 	core.ArtPeaceBackend.Start(core.ArtPeaceBackend.BackendConfig.ConsumerPort)
 }
+
+func generateTimelapse(output string, worldId int, round string, crop string, opts render.TimelapseOptions) error {
+	format := "gif"
+	if filepath.Ext(output) == ".png" || filepath.Ext(output) == ".apng" {
+		format = "apng"
+	}
+
+	worldIdStr := ""
+	if worldId != 0 {
+		worldIdStr = strconv.Itoa(worldId)
+	}
+	source, err := render.SourceFromQuery(worldIdStr, round)
+	if err != nil {
+		return err
+	}
+	opts.Crop, err = render.ParseCrop(crop, source.Width, source.Height)
+	if err != nil {
+		return err
+	}
+
+	timelapse, err := source.BuildTimelapse(opts)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.Create(output)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	return timelapse.Encode(file, format)
+}
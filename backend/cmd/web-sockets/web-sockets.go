@@ -3,6 +3,7 @@ package main
 import (
 	"flag"
 	"fmt"
+	"os"
 
 	"github.com/keep-starknet-strange/art-peace/backend/config"
 	"github.com/keep-starknet-strange/art-peace/backend/core"
@@ -25,6 +26,7 @@ func main() {
 	databaseConfigFilename := flag.String("database-config", config.DefaultDatabaseConfigPath, "Database config file")
 	backendConfigFilename := flag.String("backend-config", config.DefaultBackendConfigPath, "Backend config file")
 	production := flag.Bool("production", false, "Production mode")
+	replicaId := flag.String("replica-id", "", "Websocket replica id, stable across restarts, overrides websocket.replica_id")
 
 	flag.Parse()
 
@@ -51,6 +53,13 @@ func main() {
 	if isFlagSet("production") {
 		backendConfig.Production = *production
 	}
+	if isFlagSet("replica-id") {
+		backendConfig.WebSocket.ReplicaId = *replicaId
+	}
+	if backendConfig.WebSocket.ReplicaId == "" {
+		fmt.Println("Missing websocket.replica_id or --replica-id, it names the server's consumer group & must stay the same across restarts")
+		os.Exit(1)
+	}
 
 	databases := core.NewDatabases(databaseConfig)
 	defer databases.Close()
@@ -59,7 +68,9 @@ func main() {
 
 	routes.InitBaseRoutes()
 	routes.InitWebsocketRoutes()
+	routes.InitEventsRoutes()
 	go routes.StartWebsocketServer()
+	go routes.StartPresenceServer()
 
 	fmt.Println("Starting websocket server on port", core.ArtPeaceBackend.BackendConfig.WsPort)
 	core.ArtPeaceBackend.Start(core.ArtPeaceBackend.BackendConfig.WsPort)
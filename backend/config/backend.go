@@ -35,12 +35,70 @@ type BackendScriptsConfig struct {
 type WebSocketConfig struct {
 	ReadBufferSize  int `json:"read_buffer_size"`
 	WriteBufferSize int `json:"write_buffer_size"`
+	// Messages queued per client before it is evicted as a slow consumer
+	SendBufferSize int `json:"send_buffer_size"`
+	// Seconds
+	WriteTimeout int `json:"write_timeout"`
+	PongTimeout  int `json:"pong_timeout"`
+	// Bytes
+	MaxMessageSize int64 `json:"max_message_size"`
+	// Broadcast messages kept for clients resuming after a disconnect
+	ReplayBufferSize int `json:"replay_buffer_size"`
+	// Approximate length the messages stream is trimmed to
+	StreamMaxLength int64 `json:"stream_max_length"`
+	// Topics a client can subscribe to at once
+	MaxTopics int `json:"max_topics"`
+	// Names the server's consumer group on the messages stream, required & stable across restarts so no message is missed while it is down
+	ReplicaId string `json:"replica_id"`
+	// Seconds a consumer group can stop reading while messages are published before it is removed, for replicas scaled down
+	DeadGroupTimeout int `json:"dead_group_timeout"`
+	// Negotiate per-message deflate with clients supporting it
+	EnableCompression bool `json:"enable_compression"`
+}
+
+type ChatConfig struct {
+	MaxMessageLength int `json:"max_message_length"`
+	// Seconds an address has to wait between two messages
+	MessageInterval int `json:"message_interval"`
+	// Words masked out of messages
+	BlockedWords []string `json:"blocked_words"`
+}
+
+type AccountClassConfig struct {
+	// openzeppelin, argent_0.3 or argent_0.4
+	Kind      string `json:"kind"`
+	ClassHash string `json:"class_hash"`
+}
+
+type AuthConfig struct {
+	// Chain id in the signed typed data, ex: SN_MAIN
+	ChainId string `json:"chain_id"`
+	// Seconds
+	NonceTTL   int `json:"nonce_ttl"`
+	SessionTTL int `json:"session_ttl"`
+	// Account classes whose signatures are verified offline
+	AccountClasses []AccountClassConfig `json:"account_classes"`
+}
+
+type RateLimitConfig struct {
+	// Requests a client can make at once
+	Burst int `json:"burst"`
+	// Requests refilled every minute
+	PerMinute int `json:"per_minute"`
+}
+
+type GraphQLConfig struct {
+	// Fields a query may resolve, lists count once per item of their page
+	MaxComplexity int `json:"max_complexity"`
+	MaxDepth      int `json:"max_depth"`
 }
 
 type HttpConfig struct {
 	AllowOrigin  []string `json:"allow_origin"`
 	AllowMethods []string `json:"allow_methods"`
 	AllowHeaders []string `json:"allow_headers"`
+	// Addresses or CIDR ranges of the proxies whose X-Forwarded-For is honored
+	TrustedProxies []string `json:"trusted_proxies"`
 }
 
 type BackendConfig struct {
@@ -52,7 +110,12 @@ type BackendConfig struct {
 	Scripts      BackendScriptsConfig `json:"scripts"`
 	Production   bool                 `json:"production"`
 	WebSocket    WebSocketConfig      `json:"websocket"`
+	Chat         ChatConfig           `json:"chat"`
+	Auth         AuthConfig           `json:"auth"`
 	Http         HttpConfig           `json:"http_config"`
+	GraphQL      GraphQLConfig        `json:"graphql"`
+	// Token bucket limits of route groups, per IP per address when logged in & per IP otherwise also per address when logged in
+	RateLimits map[string]RateLimitConfig `json:"rate_limits"`
 }
 
 var DefaultBackendConfig = BackendConfig{
@@ -89,14 +152,53 @@ var DefaultBackendConfig = BackendConfig{
 	},
 	Production: false,
 	WebSocket: WebSocketConfig{
-		ReadBufferSize:  1024,
-		WriteBufferSize: 1024,
+		ReadBufferSize:    1024,
+		WriteBufferSize:   1024,
+		SendBufferSize:    256,
+		WriteTimeout:      10,
+		PongTimeout:       60,
+		MaxMessageSize:    4096,
+		ReplayBufferSize:  10000,
+		StreamMaxLength:   100000,
+		MaxTopics:         64,
+		DeadGroupTimeout:  86400,
+		EnableCompression: true,
+	},
+	Chat: ChatConfig{
+		MaxMessageLength: 280,
+		MessageInterval:  2,
+		BlockedWords:     []string{},
+	},
+	Auth: AuthConfig{
+		ChainId:        "SN_SEPOLIA",
+		NonceTTL:       300,
+		SessionTTL:     86400,
+		AccountClasses: DefaultAccountClasses,
 	},
 	Http: HttpConfig{
-		AllowOrigin:  []string{"*"},
-		AllowMethods: []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
-		AllowHeaders: []string{"Content-Type"},
+		AllowOrigin:    []string{"*"},
+		AllowMethods:   []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
+		AllowHeaders:   []string{"Content-Type", "Authorization", "X-Api-Key"},
+		TrustedProxies: []string{},
 	},
+	GraphQL: GraphQLConfig{
+		MaxComplexity: 1000,
+		MaxDepth:      8,
+	},
+	RateLimits: map[string]RateLimitConfig{
+		"render":      {Burst: 30, PerMinute: 30},
+		"heavy_reads": {Burst: 60, PerMinute: 60},
+		"uploads":     {Burst: 10, PerMinute: 5},
+		"auth":        {Burst: 10, PerMinute: 10},
+		"audit":       {Burst: 60, PerMinute: 60},
+	},
+}
+
+var DefaultAccountClasses = []AccountClassConfig{
+	{Kind: "openzeppelin", ClassHash: "0x061dac032f228abef9c6626f995015233097ae253a7f72d68552db02f2971b8f"},
+	{Kind: "argent_0.3", ClassHash: "0x01a736d6ed154502257f02b1ccdf4d9d1089f80811cd6acad48e6b6a9d1f2003"},
+	{Kind: "argent_0.3", ClassHash: "0x029927c8af6bccf3f6fda035981e765a7bdbf18a2dc0d630494f8758aa908e2b"},
+	{Kind: "argent_0.4", ClassHash: "0x036078334509b514626504edc9fb252328d1a240e4e948bef8d0c08dff45927f"},
 }
 
 var DefaultBackendConfigPath = "../configs/backend.config.json"
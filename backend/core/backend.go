@@ -3,17 +3,13 @@ package core
 import (
 	"fmt"
 	"net/http"
-	"sync"
-
-	"github.com/gorilla/websocket"
 
 	"github.com/keep-starknet-strange/art-peace/backend/config"
 )
 
 type Backend struct {
-	Databases         *Databases
-	WSConnections     []*websocket.Conn
-	WSConnectionsLock sync.Mutex
+	Databases *Databases
+	WSHub     *WSHub
 
 	RoundsConfig  *config.RoundsConfig
 	CanvasConfig  *config.CanvasConfig
@@ -27,6 +23,7 @@ var ArtPeaceBackend *Backend
 func NewBackend(databases *Databases, roundsConfig *config.RoundsConfig, canvasConfig *config.CanvasConfig, backendConfig *config.BackendConfig, adminMode bool) *Backend {
 	return &Backend{
 		Databases:     databases,
+		WSHub:         NewWSHub(&backendConfig.WebSocket),
 		RoundsConfig:  roundsConfig,
 		CanvasConfig:  canvasConfig,
 		BackendConfig: backendConfig,
@@ -0,0 +1,115 @@
+package core
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"strconv"
+)
+
+// Subprotocols clients can request with Sec-WebSocket-Protocol, JSON is used when none is requested
+const (
+	JsonProtocol   = "art-peace.json.v1"
+	BinaryProtocol = "art-peace.binary.v1"
+)
+
+// Binary frames start with their kind & the sequence id of the last message they include,
+// followed by the packed records ( big endian )
+//
+//	pixel batch: kind uint8 | seq uint64 | records
+//	pixel batch record: worldId uint32 | position uint32 | color uint8
+//
+// The main canvas is world 0
+const (
+	BinaryPixelBatch      byte = 1
+	BinaryHeaderSize           = 9
+	BinaryPixelRecordSize      = 9
+)
+
+type pixelRecord struct {
+	worldId  uint32
+	position uint32
+	color    uint8
+}
+
+func pixelRecordFromMessage(message map[string]string) (pixelRecord, bool) {
+	messageType := message["messageType"]
+	if messageType != "colorPixel" && messageType != "colorWorldPixel" {
+		return pixelRecord{}, false
+	}
+	position, err := strconv.ParseUint(message["position"], 10, 32)
+	if err != nil {
+		return pixelRecord{}, false
+	}
+	color, err := strconv.ParseUint(message["color"], 10, 8)
+	if err != nil {
+		return pixelRecord{}, false
+	}
+	return pixelRecord{
+		worldId:  uint32(messageWorld(message)),
+		position: uint32(position),
+		color:    uint8(color),
+	}, true
+}
+
+func encodePixelRecords(seq uint64, records []pixelRecord) []byte {
+	data := make([]byte, 1, BinaryHeaderSize+len(records)*BinaryPixelRecordSize)
+	data[0] = BinaryPixelBatch
+	data = binary.BigEndian.AppendUint64(data, seq)
+	for _, record := range records {
+		data = binary.BigEndian.AppendUint32(data, record.worldId)
+		data = binary.BigEndian.AppendUint32(data, record.position)
+		data = append(data, record.color)
+	}
+	return data
+}
+
+// Frames sent to binary clients for a flush : packed pixels & the other messages as a JSON array
+type binaryBatch struct {
+	pixels []byte
+	rest   []byte
+}
+
+// Pixels placed on the same position during a flush window only send the last color
+func encodeBinaryBatch(messages []map[string]string) (*binaryBatch, error) {
+	records := make([]pixelRecord, 0, len(messages))
+	indexes := make(map[[2]uint32]int)
+	rest := make([]map[string]string, 0)
+	var lastSeq uint64
+	for _, message := range messages {
+		record, ok := pixelRecordFromMessage(message)
+		if !ok {
+			rest = append(rest, message)
+			continue
+		}
+		lastSeq = max(lastSeq, MessageSeq(message))
+		key := [2]uint32{record.worldId, record.position}
+		if idx, exists := indexes[key]; exists {
+			records[idx].color = record.color
+			continue
+		}
+		indexes[key] = len(records)
+		records = append(records, record)
+	}
+
+	batch := &binaryBatch{}
+	if len(records) > 0 {
+		batch.pixels = encodePixelRecords(lastSeq, records)
+	}
+	if len(rest) > 0 {
+		restBytes, err := json.Marshal(rest)
+		if err != nil {
+			return nil, err
+		}
+		batch.rest = restBytes
+	}
+	return batch, nil
+}
+
+func (b *binaryBatch) send(client *WSClient) {
+	if b.pixels != nil && !client.SendBinary(b.pixels) {
+		return
+	}
+	if b.rest != nil {
+		client.Send(b.rest)
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"strconv"
 
 	"github.com/georgysavva/scany/v2/pgxscan"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/redis/go-redis/v9"
 
@@ -58,6 +59,17 @@ func PostgresQuery[RowType any](query string, args ...interface{}) ([]RowType, e
 	return result, nil
 }
 
+// Same as PostgresQuery, inside a transaction
+func PostgresTxQuery[RowType any](tx pgx.Tx, query string, args ...interface{}) ([]RowType, error) {
+	var result []RowType
+	err := pgxscan.Select(context.Background(), tx, &result, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
 func PostgresQueryOne[RowType any](query string, args ...interface{}) (*RowType, error) {
 	var result RowType
 	err := pgxscan.Get(context.Background(), ArtPeaceBackend.Databases.Postgres, &result, query, args...)
@@ -0,0 +1,75 @@
+package core
+
+import (
+	"sort"
+	"strconv"
+)
+
+// Recent broadcast messages kept so reconnecting clients can catch up on what they missed
+// Sequence ids are given once when publishing, so they are the same on every websocket server
+type wsHistory struct {
+	capacity int
+	messages []map[string]string
+	start    int
+	lastSeq  uint64
+}
+
+func newWSHistory(capacity int) *wsHistory {
+	return &wsHistory{
+		capacity: capacity,
+		messages: make([]map[string]string, 0, capacity),
+	}
+}
+
+// Keep the message if it is sequenced & newer than the buffer, others are only delivered live
+func (h *wsHistory) add(message map[string]string) {
+	seq := MessageSeq(message)
+	if seq == 0 || seq <= h.lastSeq {
+		return
+	}
+	h.lastSeq = seq
+
+	if h.capacity <= 0 {
+		return
+	}
+	if len(h.messages) < h.capacity {
+		h.messages = append(h.messages, message)
+	} else {
+		h.messages[h.start] = message
+		h.start = (h.start + 1) % h.capacity
+	}
+}
+
+func (h *wsHistory) at(idx int) map[string]string {
+	return h.messages[(h.start+idx)%len(h.messages)]
+}
+
+// Messages sent after lastSeq, false if some of them are no longer retained
+func (h *wsHistory) since(lastSeq uint64) ([]map[string]string, bool) {
+	if lastSeq > h.lastSeq {
+		return nil, false
+	}
+	if lastSeq == h.lastSeq {
+		return nil, true
+	}
+	if len(h.messages) == 0 || MessageSeq(h.at(0)) > lastSeq+1 {
+		return nil, false
+	}
+
+	first := sort.Search(len(h.messages), func(idx int) bool {
+		return MessageSeq(h.at(idx)) > lastSeq
+	})
+	messages := make([]map[string]string, 0, len(h.messages)-first)
+	for idx := first; idx < len(h.messages); idx++ {
+		messages = append(messages, h.at(idx))
+	}
+	return messages, true
+}
+
+func MessageSeq(message map[string]string) uint64 {
+	seq, err := strconv.ParseUint(message["seq"], 10, 64)
+	if err != nil {
+		return 0
+	}
+	return seq
+}
@@ -0,0 +1,107 @@
+package core
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Delay browsers wait before reconnecting a dropped stream
+const sseRetry = 3 * time.Second
+
+// Server-Sent Events connection, written by the handler goroutine serving the request
+type sseStream struct {
+	w          http.ResponseWriter
+	controller *http.ResponseController
+	remoteAddr string
+}
+
+// Track an event stream, topics are subscribed before any message is delivered
+// A non zero lastSeq first sends the messages broadcast after it
+func (h *WSHub) RegisterStream(w http.ResponseWriter, r *http.Request, topics []WSTopic, lastSeq uint64) (*WSClient, error) {
+	client := &WSClient{
+		hub: h,
+		stream: &sseStream{
+			w:          w,
+			controller: http.NewResponseController(w),
+			remoteAddr: r.RemoteAddr,
+		},
+		send: make(chan wsFrame, h.sendBufferSize()),
+		done: make(chan struct{}),
+	}
+	client.Subscriptions.max = h.maxTopics()
+	if err := client.Subscriptions.Subscribe(topics); err != nil {
+		return nil, err
+	}
+
+	h.add(client, lastSeq)
+	return client, nil
+}
+
+// Queue the messages as one chunk of events, using their sequence id as event id
+func (c *WSClient) sendEvents(messages []map[string]string) {
+	var events bytes.Buffer
+	for _, message := range messages {
+		data, err := json.Marshal(message)
+		if err != nil {
+			fmt.Println("Failed to marshal websocket message", err)
+			continue
+		}
+		if seq, ok := message["seq"]; ok {
+			fmt.Fprintf(&events, "id: %s\n", seq)
+		}
+		fmt.Fprintf(&events, "data: %s\n\n", data)
+	}
+	if events.Len() > 0 {
+		c.Send(events.Bytes())
+	}
+}
+
+// Write queued events until the client disconnects, blocking the calling handler
+func (c *WSClient) ServeStream(r *http.Request) {
+	defer c.Close()
+
+	stream := c.stream
+	header := stream.w.Header()
+	header.Set("Content-Type", "text/event-stream")
+	header.Set("Cache-Control", "no-cache")
+	header.Set("Connection", "keep-alive")
+	// Stop proxies from buffering the stream
+	header.Set("X-Accel-Buffering", "no")
+	stream.w.WriteHeader(http.StatusOK)
+
+	// Comments keep idle connections open through proxies
+	pingInterval := c.hub.pongTimeout() * 9 / 10
+	ticker := time.NewTicker(pingInterval)
+	defer ticker.Stop()
+
+	write := func(data []byte) bool {
+		stream.controller.SetWriteDeadline(time.Now().Add(c.hub.writeTimeout()))
+		if _, err := stream.w.Write(data); err != nil {
+			return false
+		}
+		return stream.controller.Flush() == nil
+	}
+
+	if !write([]byte(fmt.Sprintf("retry: %d\n\n", sseRetry.Milliseconds()))) {
+		return
+	}
+	for {
+		select {
+		case <-c.done:
+			return
+		case <-r.Context().Done():
+			return
+		case frame := <-c.send:
+			if !write(frame.data) {
+				return
+			}
+		case <-ticker.C:
+			if !write([]byte(": ping\n\n")) {
+				return
+			}
+		}
+	}
+}
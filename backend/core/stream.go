@@ -0,0 +1,244 @@
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/keep-starknet-strange/art-peace/backend/config"
+)
+
+// Redis stream every websocket server reads with its own consumer group, so any of them can serve any client
+// Messages stay pending until acknowledged, so they survive websocket server restarts
+// Messages used to be fanned out with Redis Pub/Sub, where a server that was down or reconnecting lost the messages published meanwhile
+const WSMessagesStream = "ws-messages"
+
+// Counter giving broadcast messages their sequence id
+const wsSeqKey = "ws-seq"
+
+const wsStreamReadCount = 100
+const wsStreamBlock = 5 * time.Second
+
+// Give the message the next sequence id & add it to the stream read by every websocket server
+func PublishWSMessage(message map[string]string) error {
+	ctx := context.Background()
+	seq, err := ArtPeaceBackend.Databases.Redis.Incr(ctx, wsSeqKey).Result()
+	if err != nil {
+		return err
+	}
+
+	sequenced := make(map[string]string, len(message)+1)
+	for key, value := range message {
+		sequenced[key] = value
+	}
+	sequenced["seq"] = strconv.FormatInt(seq, 10)
+
+	messageBytes, err := json.Marshal(sequenced)
+	if err != nil {
+		return err
+	}
+
+	maxLen := ArtPeaceBackend.BackendConfig.WebSocket.StreamMaxLength
+	if maxLen <= 0 {
+		maxLen = config.DefaultBackendConfig.WebSocket.StreamMaxLength
+	}
+	return ArtPeaceBackend.Databases.Redis.XAdd(ctx, &redis.XAddArgs{
+		Stream: WSMessagesStream,
+		MaxLen: maxLen,
+		Approx: true,
+		Values: map[string]interface{}{"message": messageBytes},
+	}).Err()
+}
+
+// Message read from the stream, to acknowledge once it was sent to the clients
+type WSStreamMessage struct {
+	Id      string
+	Message map[string]string
+}
+
+var ErrMissingReplicaId = errors.New("websocket.replica_id is required")
+
+// Entries pending for longer are claimed again, their consumer failed to acknowledge them
+const wsStreamClaimIdle = time.Minute
+
+// Period of the claims & of the cleanup of dead consumers & groups
+const wsStreamMaintenanceInterval = time.Minute
+
+type WSStreamConsumer struct {
+	group    string
+	consumer string
+}
+
+// Each websocket server needs a replica id stable across restarts & deploys, so its consumer group keeps the messages published while it is down
+// Consumers are named after the process, & the entries left pending by the previous one are claimed on start
+func NewWSStreamConsumer() (*WSStreamConsumer, error) {
+	replicaId := ArtPeaceBackend.BackendConfig.WebSocket.ReplicaId
+	if replicaId == "" {
+		return nil, ErrMissingReplicaId
+	}
+	hostname, err := os.Hostname()
+	if err != nil {
+		return nil, err
+	}
+
+	consumer := &WSStreamConsumer{
+		group:    "ws-" + replicaId,
+		consumer: hostname + "-" + strconv.Itoa(os.Getpid()),
+	}
+	// New groups only receive messages added after they are created
+	err = ArtPeaceBackend.Databases.Redis.XGroupCreateMkStream(context.Background(), WSMessagesStream, consumer.group, "$").Err()
+	if err != nil && !strings.HasPrefix(err.Error(), "BUSYGROUP") {
+		return nil, err
+	}
+	return consumer, nil
+}
+
+// Pass every message to handle, starting with the ones delivered before a restart but never acknowledged
+func (c *WSStreamConsumer) Read(handle func(message WSStreamMessage)) {
+	ctx := context.Background()
+	c.claim(0, handle)
+	lastMaintenance := time.Now()
+	for {
+		if time.Since(lastMaintenance) >= wsStreamMaintenanceInterval {
+			c.claim(wsStreamClaimIdle, handle)
+			c.removeDeadConsumers()
+			c.removeDeadGroups()
+			lastMaintenance = time.Now()
+		}
+
+		streams, err := ArtPeaceBackend.Databases.Redis.XReadGroup(ctx, &redis.XReadGroupArgs{
+			Group:    c.group,
+			Consumer: c.consumer,
+			Streams:  []string{WSMessagesStream, ">"},
+			Count:    wsStreamReadCount,
+			Block:    wsStreamBlock,
+		}).Result()
+		if err == redis.Nil {
+			continue
+		}
+		if err != nil {
+			fmt.Println("Failed to read websocket messages stream", err)
+			time.Sleep(time.Second)
+			continue
+		}
+
+		for _, stream := range streams {
+			for _, entry := range stream.Messages {
+				c.handleEntry(entry, handle)
+			}
+		}
+	}
+}
+
+func (c *WSStreamConsumer) handleEntry(entry redis.XMessage, handle func(message WSStreamMessage)) {
+	var message map[string]string
+	payload, _ := entry.Values["message"].(string)
+	if err := json.Unmarshal([]byte(payload), &message); err != nil {
+		fmt.Println("Failed to unmarshal websocket stream message", entry.ID, err)
+		c.Ack(entry.ID)
+		return
+	}
+	handle(WSStreamMessage{Id: entry.ID, Message: message})
+}
+
+// Take over the group's entries pending for at least minIdle, from a previous process or an acknowledgement that failed
+func (c *WSStreamConsumer) claim(minIdle time.Duration, handle func(message WSStreamMessage)) {
+	ctx := context.Background()
+	start := "0-0"
+	for {
+		entries, next, err := ArtPeaceBackend.Databases.Redis.XAutoClaim(ctx, &redis.XAutoClaimArgs{
+			Stream:   WSMessagesStream,
+			Group:    c.group,
+			Consumer: c.consumer,
+			MinIdle:  minIdle,
+			Start:    start,
+			Count:    wsStreamReadCount,
+		}).Result()
+		if err != nil {
+			fmt.Println("Failed to claim pending websocket messages", err)
+			return
+		}
+		for _, entry := range entries {
+			c.handleEntry(entry, handle)
+		}
+		if next == "0-0" {
+			return
+		}
+		start = next
+	}
+}
+
+// Consumers of previous processes, once their entries were claimed
+func (c *WSStreamConsumer) removeDeadConsumers() {
+	ctx := context.Background()
+	consumers, err := ArtPeaceBackend.Databases.Redis.XInfoConsumers(ctx, WSMessagesStream, c.group).Result()
+	if err != nil {
+		fmt.Println("Failed to list websocket stream consumers", err)
+		return
+	}
+	for _, consumer := range consumers {
+		if consumer.Name == c.consumer || consumer.Pending > 0 {
+			continue
+		}
+		if err := ArtPeaceBackend.Databases.Redis.XGroupDelConsumer(ctx, WSMessagesStream, c.group, consumer.Name).Err(); err != nil {
+			fmt.Println("Failed to remove websocket stream consumer", consumer.Name, err)
+		}
+	}
+}
+
+// Groups of replicas scaled down, which stopped reading for dead_group_timeout while messages were published
+func (c *WSStreamConsumer) removeDeadGroups() {
+	timeout := time.Duration(ArtPeaceBackend.BackendConfig.WebSocket.DeadGroupTimeout) * time.Second
+	if timeout <= 0 {
+		timeout = time.Duration(config.DefaultBackendConfig.WebSocket.DeadGroupTimeout) * time.Second
+	}
+
+	ctx := context.Background()
+	stream, err := ArtPeaceBackend.Databases.Redis.XInfoStream(ctx, WSMessagesStream).Result()
+	if err != nil {
+		fmt.Println("Failed to read websocket messages stream info", err)
+		return
+	}
+	groups, err := ArtPeaceBackend.Databases.Redis.XInfoGroups(ctx, WSMessagesStream).Result()
+	if err != nil {
+		fmt.Println("Failed to list websocket stream groups", err)
+		return
+	}
+	latest := streamIdTime(stream.LastGeneratedID)
+	for _, group := range groups {
+		if group.Name == c.group || !strings.HasPrefix(group.Name, "ws-") {
+			continue
+		}
+		if latest.Sub(streamIdTime(group.LastDeliveredID)) < timeout {
+			continue
+		}
+		if err := ArtPeaceBackend.Databases.Redis.XGroupDestroy(ctx, WSMessagesStream, group.Name).Err(); err != nil {
+			fmt.Println("Failed to remove websocket stream group", group.Name, err)
+			continue
+		}
+		fmt.Println("Removed dead websocket stream group", group.Name)
+	}
+}
+
+// Stream ids start with the milliseconds they were added at
+func streamIdTime(id string) time.Time {
+	ms, _ := strconv.ParseInt(strings.SplitN(id, "-", 2)[0], 10, 64)
+	return time.UnixMilli(ms)
+}
+
+func (c *WSStreamConsumer) Ack(ids ...string) {
+	if len(ids) == 0 {
+		return
+	}
+	err := ArtPeaceBackend.Databases.Redis.XAck(context.Background(), WSMessagesStream, c.group, ids...).Err()
+	if err != nil {
+		fmt.Println("Failed to acknowledge websocket messages", err)
+	}
+}
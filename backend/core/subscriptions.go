@@ -0,0 +1,299 @@
+package core
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Topic a websocket client can subscribe to
+// type is one of world, viewport, event or address
+type WSTopic struct {
+	Type    string `json:"type"`
+	WorldId int    `json:"worldId"`
+	X       int    `json:"x"`
+	Y       int    `json:"y"`
+	Width   int    `json:"width"`
+	Height  int    `json:"height"`
+	Event   string `json:"event"`
+	Address string `json:"address"`
+}
+
+// Subscriptions of a single client, clients without any topic receive every message
+type WSSubscriptions struct {
+	lock sync.RWMutex
+	// Topics in subscription order, & the same topics as a set
+	topics []WSTopic
+	set    map[WSTopic]struct{}
+	// Most topics the client can hold, no limit if 0
+	max int
+}
+
+func normalizeAddress(address string) string {
+	return strings.ToLower(strings.TrimPrefix(strings.TrimSpace(address), "0x"))
+}
+
+func (t WSTopic) normalize() (WSTopic, error) {
+	switch t.Type {
+	case "world":
+		if t.WorldId < 0 {
+			return t, fmt.Errorf("invalid worldId")
+		}
+	case "viewport":
+		if t.WorldId < 0 || t.X < 0 || t.Y < 0 || t.Width <= 0 || t.Height <= 0 {
+			return t, fmt.Errorf("invalid viewport")
+		}
+	case "event":
+		if t.Event == "" {
+			return t, fmt.Errorf("missing event")
+		}
+	case "address":
+		t.Address = normalizeAddress(t.Address)
+		if t.Address == "" {
+			return t, fmt.Errorf("missing address")
+		}
+	default:
+		return t, fmt.Errorf("invalid topic type: %s", t.Type)
+	}
+	return t, nil
+}
+
+func (s *WSSubscriptions) Subscribe(topics []WSTopic) error {
+	normalized := make([]WSTopic, 0, len(topics))
+	for _, topic := range topics {
+		topic, err := topic.normalize()
+		if err != nil {
+			return err
+		}
+		normalized = append(normalized, topic)
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	added := make(map[WSTopic]struct{})
+	for _, topic := range normalized {
+		if _, ok := s.set[topic]; !ok {
+			added[topic] = struct{}{}
+		}
+	}
+	if s.max > 0 && len(s.topics)+len(added) > s.max {
+		return fmt.Errorf("too many topics, at most %d", s.max)
+	}
+
+	if s.set == nil {
+		s.set = make(map[WSTopic]struct{})
+	}
+	for _, topic := range normalized {
+		if _, ok := added[topic]; ok {
+			s.set[topic] = struct{}{}
+			s.topics = append(s.topics, topic)
+			delete(added, topic)
+		}
+	}
+	return nil
+}
+
+func (s *WSSubscriptions) Unsubscribe(topics []WSTopic) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	for _, topic := range topics {
+		topic, err := topic.normalize()
+		if err != nil {
+			continue
+		}
+		if _, ok := s.set[topic]; !ok {
+			continue
+		}
+		delete(s.set, topic)
+		for idx, existing := range s.topics {
+			if existing == topic {
+				s.topics = append(s.topics[:idx], s.topics[idx+1:]...)
+				break
+			}
+		}
+	}
+}
+
+func (s *WSSubscriptions) UnsubscribeAll() {
+	s.lock.Lock()
+	s.topics = nil
+	s.set = nil
+	s.lock.Unlock()
+}
+
+func (s *WSSubscriptions) Topics() []WSTopic {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	topics := make([]WSTopic, len(s.topics))
+	copy(topics, s.topics)
+	return topics
+}
+
+// World of a message, messages without a worldId are about the main canvas ( world 0 )
+func messageWorld(message map[string]string) int {
+	worldId, err := strconv.Atoi(message["worldId"])
+	if err != nil {
+		return 0
+	}
+	return worldId
+}
+
+func messageAddress(message map[string]string) string {
+	if address, ok := message["address"]; ok {
+		return normalizeAddress(address)
+	}
+	return normalizeAddress(message["minter"])
+}
+
+var worldWidths sync.Map
+
+// Width used to turn message positions into viewport coordinates
+func canvasWidth(worldId int) int {
+	if worldId == 0 {
+		return int(ArtPeaceBackend.CanvasConfig.Canvas.Width)
+	}
+	if width, ok := worldWidths.Load(worldId); ok {
+		return width.(int)
+	}
+	width, err := PostgresQueryOne[int]("SELECT width FROM worlds WHERE world_id = $1", worldId)
+	if err != nil {
+		return 0
+	}
+	worldWidths.Store(worldId, *width)
+	return *width
+}
+
+func (t WSTopic) matches(message map[string]string) bool {
+	switch t.Type {
+	case "world":
+		return messageWorld(message) == t.WorldId
+	case "event":
+		return message["messageType"] == t.Event
+	case "address":
+		return messageAddress(message) == t.Address
+	case "viewport":
+		if messageWorld(message) != t.WorldId {
+			return false
+		}
+		position, err := strconv.Atoi(message["position"])
+		if err != nil {
+			return false
+		}
+		width := canvasWidth(t.WorldId)
+		if width <= 0 {
+			return false
+		}
+		x, y := position%width, position/width
+		return x >= t.X && x < t.X+t.Width && y >= t.Y && y < t.Y+t.Height
+	}
+	return false
+}
+
+// A message is delivered if it matches any of the client's topics
+func (s *WSSubscriptions) Matches(message map[string]string) bool {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	if len(s.topics) == 0 {
+		return true
+	}
+	for _, topic := range s.topics {
+		if topic.matches(message) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *WSSubscriptions) subscribed() bool {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return len(s.topics) > 0
+}
+
+func (h *WSHub) clientList() []*WSClient {
+	h.lock.RLock()
+	defer h.lock.RUnlock()
+	clients := make([]*WSClient, 0, len(h.clients))
+	for client := range h.clients {
+		clients = append(clients, client)
+	}
+	return clients
+}
+
+// Send each client only the messages matching its subscriptions, as one JSON array or binary batch
+func (h *WSHub) BroadcastMessages(messages []map[string]string) {
+	h.broadcastLock.Lock()
+	defer h.broadcastLock.Unlock()
+
+	for _, message := range messages {
+		h.history.add(message)
+	}
+	h.deliver(h.clientList(), messages)
+}
+
+// Send messages without sequence ids, for short lived state resuming clients don't need to catch up on
+func (h *WSHub) BroadcastEphemeral(messages []map[string]string) {
+	h.broadcastLock.Lock()
+	defer h.broadcastLock.Unlock()
+	h.deliver(h.clientList(), messages)
+}
+
+func (h *WSHub) deliver(clients []*WSClient, messages []map[string]string) {
+	// Encoded once & shared by every client without subscriptions
+	var everything []byte
+	var everythingBinary *binaryBatch
+
+	for _, client := range clients {
+		matching := messages
+		subscribed := client.Subscriptions.subscribed()
+		if subscribed {
+			matching = make([]map[string]string, 0)
+			for _, message := range messages {
+				if client.Subscriptions.Matches(message) {
+					matching = append(matching, message)
+				}
+			}
+			if len(matching) == 0 {
+				continue
+			}
+		}
+
+		if client.stream != nil {
+			client.sendEvents(matching)
+			continue
+		}
+
+		if client.Binary {
+			batch := everythingBinary
+			if subscribed || batch == nil {
+				var err error
+				batch, err = encodeBinaryBatch(matching)
+				if err != nil {
+					fmt.Println("Failed to marshal websocket messages", err)
+					continue
+				}
+				if !subscribed {
+					everythingBinary = batch
+				}
+			}
+			batch.send(client)
+			continue
+		}
+
+		messageBytes := everything
+		if subscribed || messageBytes == nil {
+			var err error
+			messageBytes, err = json.Marshal(matching)
+			if err != nil {
+				fmt.Println("Failed to marshal websocket messages", err)
+				continue
+			}
+			if !subscribed {
+				everything = messageBytes
+			}
+		}
+		client.Send(messageBytes)
+	}
+}
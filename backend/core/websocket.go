@@ -0,0 +1,270 @@
+package core
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/keep-starknet-strange/art-peace/backend/config"
+)
+
+// WSClient is a websocket connection with its own send queue & writer goroutine
+type WSClient struct {
+	Conn          *websocket.Conn
+	Subscriptions WSSubscriptions
+	// Negotiated the binary subprotocol, pixel updates are sent packed
+	Binary bool
+	// Set for Server-Sent Events clients, which have no websocket connection
+	stream *sseStream
+	// World the client shows before subscribing to one, resyncs point it to that world's canvas
+	worldId int
+
+	hub       *WSHub
+	send      chan wsFrame
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+type wsFrame struct {
+	messageType int
+	data        []byte
+}
+
+// WSHub tracks the connected clients & fans messages out to their send queues
+type WSHub struct {
+	config *config.WebSocketConfig
+
+	// Held while a message is sequenced & queued, so resuming clients never miss or duplicate one
+	broadcastLock sync.Mutex
+	history       *wsHistory
+
+	lock    sync.RWMutex
+	clients map[*WSClient]struct{}
+}
+
+func NewWSHub(wsConfig *config.WebSocketConfig) *WSHub {
+	hub := &WSHub{
+		config:  wsConfig,
+		clients: make(map[*WSClient]struct{}),
+	}
+	hub.history = newWSHistory(hub.replayBufferSize())
+	return hub
+}
+
+func (h *WSHub) sendBufferSize() int {
+	if h.config.SendBufferSize <= 0 {
+		return config.DefaultBackendConfig.WebSocket.SendBufferSize
+	}
+	return h.config.SendBufferSize
+}
+
+func (h *WSHub) replayBufferSize() int {
+	if h.config.ReplayBufferSize <= 0 {
+		return config.DefaultBackendConfig.WebSocket.ReplayBufferSize
+	}
+	return h.config.ReplayBufferSize
+}
+
+func (h *WSHub) maxTopics() int {
+	if h.config.MaxTopics <= 0 {
+		return config.DefaultBackendConfig.WebSocket.MaxTopics
+	}
+	return h.config.MaxTopics
+}
+
+func (h *WSHub) writeTimeout() time.Duration {
+	if h.config.WriteTimeout <= 0 {
+		return time.Duration(config.DefaultBackendConfig.WebSocket.WriteTimeout) * time.Second
+	}
+	return time.Duration(h.config.WriteTimeout) * time.Second
+}
+
+func (h *WSHub) pongTimeout() time.Duration {
+	if h.config.PongTimeout <= 0 {
+		return time.Duration(config.DefaultBackendConfig.WebSocket.PongTimeout) * time.Second
+	}
+	return time.Duration(h.config.PongTimeout) * time.Second
+}
+
+func (h *WSHub) maxMessageSize() int64 {
+	if h.config.MaxMessageSize <= 0 {
+		return config.DefaultBackendConfig.WebSocket.MaxMessageSize
+	}
+	return h.config.MaxMessageSize
+}
+
+// Start tracking the connection & its writer, reads are left to the caller
+// A non zero lastSeq first sends the messages broadcast after it
+func (h *WSHub) Register(conn *websocket.Conn, lastSeq uint64, worldId int) *WSClient {
+	client := &WSClient{
+		Conn:    conn,
+		Binary:  conn.Subprotocol() == BinaryProtocol,
+		worldId: worldId,
+		hub:     h,
+		send:    make(chan wsFrame, h.sendBufferSize()),
+		done:    make(chan struct{}),
+	}
+	client.Subscriptions.max = h.maxTopics()
+
+	// Clients which stop answering pings are dropped once the read deadline passes
+	conn.SetReadLimit(h.maxMessageSize())
+	conn.SetReadDeadline(time.Now().Add(h.pongTimeout()))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(h.pongTimeout()))
+	})
+
+	go client.writer()
+	h.add(client, lastSeq)
+	return client
+}
+
+func (h *WSHub) add(client *WSClient, lastSeq uint64) {
+	h.broadcastLock.Lock()
+	defer h.broadcastLock.Unlock()
+	h.lock.Lock()
+	h.clients[client] = struct{}{}
+	h.lock.Unlock()
+
+	if lastSeq != 0 {
+		h.resume(client, lastSeq)
+	}
+}
+
+func (h *WSHub) resume(client *WSClient, lastSeq uint64) {
+	missed, ok := h.history.since(lastSeq)
+	if !ok {
+		// Too far behind, the client has to reload its canvases & continue from the current id
+		seq := strconv.FormatUint(h.history.lastSeq, 10)
+		resyncs := make([]map[string]string, 0)
+		for _, worldId := range client.canvasWorlds() {
+			resyncs = append(resyncs, map[string]string{
+				"messageType": "resync",
+				"seq":         seq,
+				"worldId":     strconv.Itoa(worldId),
+				"canvas":      canvasRoute(worldId),
+			})
+		}
+		h.deliver([]*WSClient{client}, resyncs)
+		return
+	}
+	if len(missed) > 0 {
+		h.deliver([]*WSClient{client}, missed)
+	}
+}
+
+// Worlds of the client's world & viewport topics, or the world it connected to without any
+func (c *WSClient) canvasWorlds() []int {
+	worlds := make([]int, 0)
+	for _, topic := range c.Subscriptions.Topics() {
+		if (topic.Type == "world" || topic.Type == "viewport") && !slices.Contains(worlds, topic.WorldId) {
+			worlds = append(worlds, topic.WorldId)
+		}
+	}
+	if len(worlds) == 0 {
+		worlds = append(worlds, c.worldId)
+	}
+	return worlds
+}
+
+// World 0 is the main canvas
+func canvasRoute(worldId int) string {
+	if worldId == 0 {
+		return "/get-canvas"
+	}
+	return "/worlds/" + strconv.Itoa(worldId) + "/canvas"
+}
+
+func (h *WSHub) ClientCount() int {
+	h.lock.RLock()
+	defer h.lock.RUnlock()
+	return len(h.clients)
+}
+
+func (c *WSClient) enqueue(frame wsFrame) bool {
+	select {
+	case <-c.done:
+		return true
+	default:
+	}
+
+	select {
+	case c.send <- frame:
+		return true
+	default:
+		return false
+	}
+}
+
+// Queue a message for this client only, evicting it if its queue is full
+func (c *WSClient) Send(message []byte) bool {
+	return c.sendFrame(wsFrame{websocket.TextMessage, message})
+}
+
+func (c *WSClient) SendBinary(message []byte) bool {
+	return c.sendFrame(wsFrame{websocket.BinaryMessage, message})
+}
+
+func (c *WSClient) sendFrame(frame wsFrame) bool {
+	if c.enqueue(frame) {
+		return true
+	}
+	fmt.Println("Evicting slow websocket client", c.remoteAddr())
+	c.Close()
+	return false
+}
+
+// Done is closed once the client is disconnected
+func (c *WSClient) Done() <-chan struct{} {
+	return c.done
+}
+
+func (c *WSClient) Close() {
+	c.closeOnce.Do(func() {
+		c.hub.lock.Lock()
+		delete(c.hub.clients, c)
+		c.hub.lock.Unlock()
+
+		close(c.done)
+		if c.Conn != nil {
+			c.Conn.Close()
+		}
+	})
+}
+
+func (c *WSClient) remoteAddr() string {
+	if c.stream != nil {
+		return c.stream.remoteAddr
+	}
+	return c.Conn.RemoteAddr().String()
+}
+
+// Only goroutine writing to the connection, so writes never overlap
+func (c *WSClient) writer() {
+	pingInterval := c.hub.pongTimeout() * 9 / 10
+	ticker := time.NewTicker(pingInterval)
+	defer func() {
+		ticker.Stop()
+		c.Close()
+	}()
+
+	for {
+		select {
+		case <-c.done:
+			return
+		case frame := <-c.send:
+			c.Conn.SetWriteDeadline(time.Now().Add(c.hub.writeTimeout()))
+			if err := c.Conn.WriteMessage(frame.messageType, frame.data); err != nil {
+				return
+			}
+		case <-ticker.C:
+			c.Conn.SetWriteDeadline(time.Now().Add(c.hub.writeTimeout()))
+			if err := c.Conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+				return
+			}
+		}
+	}
+}
@@ -6,10 +6,12 @@ toolchain go1.22.2
 
 require (
 	github.com/NethermindEth/juno v0.11.5
+	github.com/georgysavva/scany/v2 v2.1.3
 	github.com/gorilla/websocket v1.5.1
+	github.com/graphql-go/graphql v0.8.1
 	github.com/jackc/pgx/v5 v5.5.5
-	github.com/redis/go-redis/v9 v9.5.1
 	github.com/pkg/errors v0.9.1
+	github.com/redis/go-redis/v9 v9.5.1
 )
 
 require (
@@ -19,7 +21,6 @@ require (
 	github.com/consensys/gnark-crypto v0.12.1 // indirect
 	github.com/dgryski/go-rendezvous v0.0.0-20200823014737-9f7001d12a5f // indirect
 	github.com/fxamacker/cbor/v2 v2.5.0 // indirect
-	github.com/georgysavva/scany/v2 v2.1.3 // indirect
 	github.com/jackc/pgpassfile v1.0.0 // indirect
 	github.com/jackc/pgservicefile v0.0.0-20221227161230-091c0ba34f0a // indirect
 	github.com/jackc/puddle/v2 v2.2.1 // indirect
@@ -0,0 +1,181 @@
+package graph
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	gql "github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+// Used when the config leaves the limits out
+const (
+	DefaultMaxComplexity = 1000
+	DefaultMaxDepth      = 8
+)
+
+// Estimated length of lists without a first argument, like a user's factions
+const unpagedListLength = 10
+
+// Cost of a query before running it : every field costs 1 & lists multiply the cost of their fields by their length
+// Introspection fields are free, clients & tools fetch the schema with them
+// The walk stops as soon as the limit is passed, so fragments spread many times can't make it slow
+type complexity struct {
+	fragments     map[string]*ast.FragmentDefinition
+	variables     map[string]interface{}
+	maxComplexity int
+	maxDepth      int
+}
+
+func (c *complexity) selectionSet(set *ast.SelectionSet, parent *gql.Object, depth int) (int, error) {
+	if set == nil || parent == nil {
+		return 0, nil
+	}
+	cost := 0
+	for _, selection := range set.Selections {
+		switch selection := selection.(type) {
+		case *ast.Field:
+			name := selection.Name.Value
+			if strings.HasPrefix(name, "__") {
+				continue
+			}
+			if depth > c.maxDepth {
+				return 0, fmt.Errorf("Query is deeper than %d fields", c.maxDepth)
+			}
+			field, ok := parent.Fields()[name]
+			if !ok {
+				continue
+			}
+			fieldCost, err := c.selectionSet(selection.SelectionSet, objectType(field.Type), depth+1)
+			if err != nil {
+				return 0, err
+			}
+			cost += 1 + fieldCost*c.listLength(field, selection)
+		case *ast.FragmentSpread:
+			fragment, ok := c.fragments[selection.Name.Value]
+			if !ok {
+				continue
+			}
+			fragmentCost, err := c.selectionSet(fragment.SelectionSet, parent, depth)
+			if err != nil {
+				return 0, err
+			}
+			cost += fragmentCost
+		case *ast.InlineFragment:
+			fragmentCost, err := c.selectionSet(selection.SelectionSet, parent, depth)
+			if err != nil {
+				return 0, err
+			}
+			cost += fragmentCost
+		}
+		if cost > c.maxComplexity {
+			return 0, fmt.Errorf("Query complexity is over the limit of %d", c.maxComplexity)
+		}
+	}
+	return cost, nil
+}
+
+// Page length of lists, with the same default & maximum as their resolvers
+func (c *complexity) listLength(field *gql.FieldDefinition, selection *ast.Field) int {
+	if !isList(field.Type) {
+		return 1
+	}
+	hasFirst := false
+	for _, arg := range field.Args {
+		hasFirst = hasFirst || arg.Name() == "first"
+	}
+	if !hasFirst {
+		return unpagedListLength
+	}
+	args := make(map[string]interface{})
+	for _, arg := range selection.Arguments {
+		if arg.Name.Value == "first" {
+			args["first"] = c.intValue(arg.Value)
+		}
+	}
+	return pageArgs(args).First
+}
+
+func (c *complexity) intValue(value ast.Value) interface{} {
+	switch value := value.(type) {
+	case *ast.IntValue:
+		if parsed, err := strconv.Atoi(value.Value); err == nil {
+			return parsed
+		}
+	case *ast.Variable:
+		// Variables are decoded from JSON as numbers
+		switch variable := c.variables[value.Name.Value].(type) {
+		case float64:
+			return int(variable)
+		case int:
+			return variable
+		}
+	}
+	return nil
+}
+
+func objectType(t gql.Type) *gql.Object {
+	for {
+		switch wrapped := t.(type) {
+		case *gql.NonNull:
+			t = wrapped.OfType
+		case *gql.List:
+			t = wrapped.OfType
+		case *gql.Object:
+			return wrapped
+		default:
+			return nil
+		}
+	}
+}
+
+func isList(t gql.Type) bool {
+	if nonNull, ok := t.(*gql.NonNull); ok {
+		t = nonNull.OfType
+	}
+	_, ok := t.(*gql.List)
+	return ok
+}
+
+// Operation that Execute will run, nil when it is ambiguous or missing
+func findOperation(document *ast.Document, operationName string) *ast.OperationDefinition {
+	var found *ast.OperationDefinition
+	for _, definition := range document.Definitions {
+		operation, ok := definition.(*ast.OperationDefinition)
+		if !ok {
+			continue
+		}
+		if operationName == "" {
+			if found != nil {
+				return nil
+			}
+			found = operation
+		} else if operation.Name != nil && operation.Name.Value == operationName {
+			found = operation
+		}
+	}
+	return found
+}
+
+// Reject queries over the limits, before any of their resolvers runs
+func checkComplexity(document *ast.Document, operationName string, variables map[string]interface{}, maxComplexity int, maxDepth int) error {
+	operation := findOperation(document, operationName)
+	if operation == nil {
+		// Execute reports it
+		return nil
+	}
+	c := &complexity{
+		fragments:     make(map[string]*ast.FragmentDefinition),
+		variables:     variables,
+		maxComplexity: maxComplexity,
+		maxDepth:      maxDepth,
+	}
+	for _, definition := range document.Definitions {
+		if fragment, ok := definition.(*ast.FragmentDefinition); ok {
+			c.fragments[fragment.Name.Value] = fragment
+		}
+	}
+	_, err := c.selectionSet(operation.SelectionSet, schema.QueryType(), 1)
+	return err
+}
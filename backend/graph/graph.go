@@ -0,0 +1,52 @@
+// Package graph serves a read only GraphQL schema of the backend's entities
+// Relations are batched per query level & queries over the configured complexity or depth are rejected before running
+package graph
+
+import (
+	"context"
+
+	gql "github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/graphql-go/graphql/language/source"
+
+	"github.com/keep-starknet-strange/art-peace/backend/core"
+)
+
+func limits() (maxComplexity int, maxDepth int) {
+	maxComplexity, maxDepth = DefaultMaxComplexity, DefaultMaxDepth
+	if core.ArtPeaceBackend == nil {
+		return maxComplexity, maxDepth
+	}
+	config := core.ArtPeaceBackend.BackendConfig.GraphQL
+	if config.MaxComplexity > 0 {
+		maxComplexity = config.MaxComplexity
+	}
+	if config.MaxDepth > 0 {
+		maxDepth = config.MaxDepth
+	}
+	return maxComplexity, maxDepth
+}
+
+// Run a query, errors are part of the result as the GraphQL spec has them
+func Execute(ctx context.Context, query string, operationName string, variables map[string]interface{}) *gql.Result {
+	document, err := parser.Parse(parser.ParseParams{Source: source.NewSource(&source.Source{Body: []byte(query), Name: "GraphQL request"})})
+	if err != nil {
+		return &gql.Result{Errors: gqlerrors.FormatErrors(err)}
+	}
+	validation := gql.ValidateDocument(&schema, document, nil)
+	if !validation.IsValid {
+		return &gql.Result{Errors: validation.Errors}
+	}
+	maxComplexity, maxDepth := limits()
+	if err := checkComplexity(document, operationName, variables, maxComplexity, maxDepth); err != nil {
+		return &gql.Result{Errors: gqlerrors.FormatErrors(err)}
+	}
+	return gql.Execute(gql.ExecuteParams{
+		Schema:        schema,
+		AST:           document,
+		OperationName: operationName,
+		Args:          variables,
+		Context:       withLoaders(ctx),
+	})
+}
@@ -0,0 +1,119 @@
+package graph
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// Database errors are logged, clients only see this one
+var errLoad = errors.New("Failed to load data")
+
+func loadFailed(err error) error {
+	fmt.Println("Failed to load GraphQL data", err)
+	return errLoad
+}
+
+// Loads values by key in batches, resolvers register their keys & return a thunk
+// The executor resolves a whole level of the query before calling the thunks, so the first call fetches every key of the level at once
+type Loader[K comparable, V any] struct {
+	fetch   func(keys []K) (map[K]V, error)
+	pending []K
+	queued  map[K]bool
+	values  map[K]V
+	errs    map[K]error
+}
+
+func NewLoader[K comparable, V any](fetch func(keys []K) (map[K]V, error)) *Loader[K, V] {
+	return &Loader[K, V]{
+		fetch:  fetch,
+		queued: make(map[K]bool),
+		values: make(map[K]V),
+		errs:   make(map[K]error),
+	}
+}
+
+// Keys without a value resolve to V's zero value
+func (l *Loader[K, V]) Load(key K) func() (interface{}, error) {
+	if !l.queued[key] {
+		l.queued[key] = true
+		l.pending = append(l.pending, key)
+	}
+	return func() (interface{}, error) {
+		if len(l.pending) > 0 {
+			l.dispatch()
+		}
+		if err := l.errs[key]; err != nil {
+			return nil, err
+		}
+		return l.values[key], nil
+	}
+}
+
+func (l *Loader[K, V]) dispatch() {
+	keys := l.pending
+	l.pending = nil
+	values, err := l.fetch(keys)
+	if err != nil {
+		err = loadFailed(err)
+	}
+	for _, key := range keys {
+		if err != nil {
+			l.errs[key] = err
+			continue
+		}
+		if value, ok := values[key]; ok {
+			l.values[key] = value
+		}
+	}
+}
+
+// Loaders of a single request, so values are never shared between requests
+type loaders struct {
+	named map[string]interface{}
+}
+
+type loadersKey struct{}
+
+func withLoaders(ctx context.Context) context.Context {
+	return context.WithValue(ctx, loadersKey{}, &loaders{named: make(map[string]interface{})})
+}
+
+// Loader of the request by name, lists are named after their arguments so each page is batched on its own
+func loader[K comparable, V any](ctx context.Context, name string, fetch func(keys []K) (map[K]V, error)) *Loader[K, V] {
+	l, ok := ctx.Value(loadersKey{}).(*loaders)
+	if !ok {
+		// Outside of Execute, nothing to batch with
+		return NewLoader(fetch)
+	}
+	if existing, ok := l.named[name].(*Loader[K, V]); ok {
+		return existing
+	}
+	created := NewLoader(fetch)
+	l.named[name] = created
+	return created
+}
+
+func loaderName(name string, args ...interface{}) string {
+	for _, arg := range args {
+		name += fmt.Sprintf("/%v", arg)
+	}
+	return name
+}
+
+// Groups rows by their parent's key, keeping their order
+func groupBy[K comparable, V any](rows []V, key func(V) K) map[K][]V {
+	grouped := make(map[K][]V)
+	for _, row := range rows {
+		grouped[key(row)] = append(grouped[key(row)], row)
+	}
+	return grouped
+}
+
+func indexBy[K comparable, V any](rows []V, key func(V) K) map[K]V {
+	indexed := make(map[K]V, len(rows))
+	for _, row := range rows {
+		indexed[key(row)] = row
+	}
+	return indexed
+}
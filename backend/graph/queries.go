@@ -0,0 +1,395 @@
+package graph
+
+import (
+	"time"
+
+	"github.com/keep-starknet-strange/art-peace/backend/core"
+)
+
+// Rows of the entities, relations are resolved from their keys by the loaders
+
+type User struct {
+	Address string `json:"address"`
+}
+
+type NFT struct {
+	TokenId     int    `json:"tokenId"`
+	Position    int    `json:"position"`
+	Width       int    `json:"width"`
+	Height      int    `json:"height"`
+	Name        string `json:"name"`
+	ImageHash   string `json:"imageHash"`
+	BlockNumber int    `json:"blockNumber"`
+	DayIndex    int    `json:"dayIndex"`
+	Minter      string `json:"-"`
+	Owner       string `json:"-"`
+}
+
+type World struct {
+	WorldId           int       `json:"id"`
+	Host              string    `json:"-"`
+	Name              string    `json:"name"`
+	UniqueName        string    `json:"uniqueName"`
+	Width             int       `json:"width"`
+	Height            int       `json:"height"`
+	PixelsPerTime     int       `json:"pixelsPerTime"`
+	TimeBetweenPixels int       `json:"timeBetweenPixels"`
+	StartTime         time.Time `json:"startTime"`
+	EndTime           time.Time `json:"endTime"`
+}
+
+type Stencil struct {
+	StencilId int    `json:"id"`
+	WorldId   int    `json:"-"`
+	Hash      string `json:"hash"`
+	Width     int    `json:"width"`
+	Height    int    `json:"height"`
+	Position  int    `json:"position"`
+}
+
+type Faction struct {
+	FactionId int `json:"id"`
+	// Chain factions are open to everyone & have no leader
+	Chain      bool   `json:"chain"`
+	Name       string `json:"name"`
+	Leader     string `json:"-"`
+	Joinable   bool   `json:"joinable"`
+	Allocation int    `json:"allocation"`
+	Icon       string `json:"icon"`
+	Telegram   string `json:"telegram"`
+	Twitter    string `json:"twitter"`
+	Github     string `json:"github"`
+	Site       string `json:"site"`
+}
+
+type FactionMember struct {
+	FactionId      int       `json:"-"`
+	Chain          bool      `json:"-"`
+	UserAddress    string    `json:"-"`
+	LastPlacedTime time.Time `json:"lastPlacedTime"`
+	MemberPixels   int       `json:"memberPixels"`
+}
+
+type factionKey struct {
+	chain bool
+	id    int
+}
+
+type stencilKey struct {
+	worldId   int
+	stencilId int
+}
+
+type countRow[K any] struct {
+	Key   K
+	Count int
+}
+
+const nftColumns = "token_id, position, width, height, name, image_hash, block_number, day_index, minter, owner"
+const worldColumns = "world_id, host, name, unique_name, width, height, pixels_per_time, time_between_pixels, start_time, end_time"
+const stencilColumns = "stencil_id, world_id, hash, width, height, position"
+const memberColumns = "faction_id, chain, user_address, last_placed_time, member_pixels"
+
+// Same values as /get-factions & /get-chain-factions
+const factionsQuery = `
+  SELECT f.faction_id, false AS chain, f.name, f.leader, f.joinable, f.allocation,
+    COALESCE(l.icon, '') AS icon, COALESCE(l.telegram, '') AS telegram, COALESCE(l.twitter, '') AS twitter, COALESCE(l.github, '') AS github, COALESCE(l.site, '') AS site
+  FROM Factions f
+  LEFT JOIN FactionLinks l ON f.faction_id = l.faction_id`
+const chainFactionsQuery = `
+  SELECT f.faction_id, true AS chain, f.name, '' AS leader, true AS joinable, 2 AS allocation,
+    COALESCE(l.icon, '') AS icon, COALESCE(l.telegram, '') AS telegram, COALESCE(l.twitter, '') AS twitter, COALESCE(l.github, '') AS github, COALESCE(l.site, '') AS site
+  FROM ChainFactions f
+  LEFT JOIN ChainFactionLinks l ON f.faction_id = l.faction_id`
+
+// Single rows, nil when missing
+func queryOne[T any](query string, args ...interface{}) (*T, error) {
+	rows, err := core.PostgresQuery[T](query, args...)
+	if err != nil {
+		return nil, loadFailed(err)
+	}
+	if len(rows) == 0 {
+		return nil, nil
+	}
+	return &rows[0], nil
+}
+
+// Counts per key of a query grouping keys from $1
+func fetchCounts[K comparable](query string, args ...interface{}) func(keys []K) (map[K]int, error) {
+	return func(keys []K) (map[K]int, error) {
+		rows, err := core.PostgresQuery[countRow[K]](query, append([]interface{}{keys}, args...)...)
+		if err != nil {
+			return nil, err
+		}
+		counts := make(map[K]int, len(rows))
+		for _, row := range rows {
+			counts[row.Key] = row.Count
+		}
+		return counts, nil
+	}
+}
+
+// Regular & chain factions live in their own tables
+func splitFactionKeys(keys []factionKey) (ids []int, chainIds []int) {
+	for _, key := range keys {
+		if key.chain {
+			chainIds = append(chainIds, key.id)
+		} else {
+			ids = append(ids, key.id)
+		}
+	}
+	return ids, chainIds
+}
+
+func fetchUsernames(addresses []string) (map[string]*string, error) {
+	rows, err := core.PostgresQuery[struct {
+		Address string
+		Name    string
+	}]("SELECT address, name FROM Users WHERE address = ANY($1)", addresses)
+	if err != nil {
+		return nil, err
+	}
+	usernames := make(map[string]*string, len(rows))
+	for _, row := range rows {
+		usernames[row.Address] = &row.Name
+	}
+	return usernames, nil
+}
+
+func fetchWorlds(worldIds []int) (map[int]*World, error) {
+	rows, err := core.PostgresQuery[World]("SELECT "+worldColumns+" FROM Worlds WHERE world_id = ANY($1)", worldIds)
+	if err != nil {
+		return nil, err
+	}
+	return indexBy(pointers(rows), func(world *World) int { return world.WorldId }), nil
+}
+
+func fetchFactions(keys []factionKey) (map[factionKey]*Faction, error) {
+	ids, chainIds := splitFactionKeys(keys)
+	rows, err := core.PostgresQuery[Faction](factionsQuery+" WHERE f.faction_id = ANY($1) UNION ALL "+chainFactionsQuery+" WHERE f.faction_id = ANY($2)", ids, chainIds)
+	if err != nil {
+		return nil, err
+	}
+	return indexBy(pointers(rows), func(faction *Faction) factionKey {
+		return factionKey{chain: faction.Chain, id: faction.FactionId}
+	}), nil
+}
+
+func fetchFactionMemberCounts(keys []factionKey) (map[factionKey]int, error) {
+	ids, chainIds := splitFactionKeys(keys)
+	rows, err := core.PostgresQuery[struct {
+		FactionId int
+		Chain     bool
+		Count     int
+	}](`
+    SELECT faction_id, false AS chain, COUNT(*) AS count FROM FactionMembersInfo WHERE faction_id = ANY($1) GROUP BY faction_id
+    UNION ALL
+    SELECT faction_id, true AS chain, COUNT(*) AS count FROM ChainFactionMembersInfo WHERE faction_id = ANY($2) GROUP BY faction_id`, ids, chainIds)
+	if err != nil {
+		return nil, err
+	}
+	counts := make(map[factionKey]int, len(rows))
+	for _, row := range rows {
+		counts[factionKey{chain: row.Chain, id: row.FactionId}] = row.Count
+	}
+	return counts, nil
+}
+
+func fetchStencilFavorites(keys []stencilKey) (map[stencilKey]int, error) {
+	worldIds, stencilIds := splitStencilKeys(keys)
+	rows, err := core.PostgresQuery[struct {
+		WorldId   int
+		StencilId int
+		Count     int
+	}](`
+    SELECT world_id, stencil_id, COUNT(*) AS count FROM StencilFavorites
+    WHERE (world_id, stencil_id) IN (SELECT * FROM UNNEST($1::integer[], $2::integer[]))
+    GROUP BY world_id, stencil_id`, worldIds, stencilIds)
+	if err != nil {
+		return nil, err
+	}
+	counts := make(map[stencilKey]int, len(rows))
+	for _, row := range rows {
+		counts[stencilKey{worldId: row.WorldId, stencilId: row.StencilId}] = row.Count
+	}
+	return counts, nil
+}
+
+// The owner of a stencil is its first favorite, as in /get-stencils
+func fetchStencilOwners(keys []stencilKey) (map[stencilKey]*User, error) {
+	worldIds, stencilIds := splitStencilKeys(keys)
+	rows, err := core.PostgresQuery[struct {
+		WorldId     int
+		StencilId   int
+		UserAddress string
+	}](`
+    SELECT DISTINCT ON (world_id, stencil_id) world_id, stencil_id, user_address FROM StencilFavorites
+    WHERE (world_id, stencil_id) IN (SELECT * FROM UNNEST($1::integer[], $2::integer[]))
+    ORDER BY world_id, stencil_id, key`, worldIds, stencilIds)
+	if err != nil {
+		return nil, err
+	}
+	owners := make(map[stencilKey]*User, len(rows))
+	for _, row := range rows {
+		owners[stencilKey{worldId: row.WorldId, stencilId: row.StencilId}] = &User{Address: row.UserAddress}
+	}
+	return owners, nil
+}
+
+func splitStencilKeys(keys []stencilKey) (worldIds []int, stencilIds []int) {
+	for _, key := range keys {
+		worldIds = append(worldIds, key.worldId)
+		stencilIds = append(stencilIds, key.stencilId)
+	}
+	return worldIds, stencilIds
+}
+
+// Lists of many parents in one query : rows are numbered per parent & the page is cut from each
+// $1 holds the parents, $2 & $3 the offset & length of the page
+func pagedQuery(columns string, subquery string) string {
+	return "SELECT " + columns + " FROM (" + subquery + ") AS paged WHERE row_number > $2 AND row_number <= $2 + $3 ORDER BY row_number"
+}
+
+func fetchUserNfts(p page) func(addresses []string) (map[string][]*NFT, error) {
+	return func(addresses []string) (map[string][]*NFT, error) {
+		rows, err := core.PostgresQuery[NFT](pagedQuery(nftColumns, `
+      SELECT *, ROW_NUMBER() OVER (PARTITION BY owner ORDER BY token_id DESC) AS row_number
+      FROM NFTs WHERE owner = ANY($1)`), addresses, p.Offset, p.First)
+		if err != nil {
+			return nil, err
+		}
+		return groupBy(pointers(rows), func(nft *NFT) string { return nft.Owner }), nil
+	}
+}
+
+// Most recently liked first
+func fetchUserLikedNfts(p page) func(addresses []string) (map[string][]*NFT, error) {
+	return func(addresses []string) (map[string][]*NFT, error) {
+		rows, err := core.PostgresQuery[struct {
+			Liker string
+			NFT
+		}](pagedQuery("liker, "+nftColumns, `
+      SELECT l.liker, n.*, ROW_NUMBER() OVER (PARTITION BY l.liker ORDER BY l.key DESC) AS row_number
+      FROM NFTLikes l
+      JOIN NFTs n ON l.nftKey = n.token_id
+      WHERE l.liker = ANY($1)`), addresses, p.Offset, p.First)
+		if err != nil {
+			return nil, err
+		}
+		nfts := make(map[string][]*NFT)
+		for i := range rows {
+			nfts[rows[i].Liker] = append(nfts[rows[i].Liker], &rows[i].NFT)
+		}
+		return nfts, nil
+	}
+}
+
+// Most recently favorited first
+func fetchUserFavoriteWorlds(p page) func(addresses []string) (map[string][]*World, error) {
+	return func(addresses []string) (map[string][]*World, error) {
+		rows, err := core.PostgresQuery[struct {
+			UserAddress string
+			World
+		}](pagedQuery("user_address, "+worldColumns, `
+      SELECT f.user_address, w.*, ROW_NUMBER() OVER (PARTITION BY f.user_address ORDER BY f.key DESC) AS row_number
+      FROM WorldFavorites f
+      JOIN Worlds w ON f.world_id = w.world_id
+      WHERE f.user_address = ANY($1)`), addresses, p.Offset, p.First)
+		if err != nil {
+			return nil, err
+		}
+		worlds := make(map[string][]*World)
+		for i := range rows {
+			worlds[rows[i].UserAddress] = append(worlds[rows[i].UserAddress], &rows[i].World)
+		}
+		return worlds, nil
+	}
+}
+
+func fetchUserHostedWorlds(p page) func(addresses []string) (map[string][]*World, error) {
+	return func(addresses []string) (map[string][]*World, error) {
+		rows, err := core.PostgresQuery[World](pagedQuery(worldColumns, `
+      SELECT *, ROW_NUMBER() OVER (PARTITION BY host ORDER BY world_id DESC) AS row_number
+      FROM Worlds WHERE host = ANY($1)`), addresses, p.Offset, p.First)
+		if err != nil {
+			return nil, err
+		}
+		return groupBy(pointers(rows), func(world *World) string { return world.Host }), nil
+	}
+}
+
+// Most recently favorited first, of every world when worldId is nil
+func fetchUserFavoriteStencils(worldId *int, p page) func(addresses []string) (map[string][]*Stencil, error) {
+	return func(addresses []string) (map[string][]*Stencil, error) {
+		rows, err := core.PostgresQuery[struct {
+			UserAddress string
+			Stencil
+		}](pagedQuery("user_address, "+stencilColumns, `
+      SELECT f.user_address, s.*, ROW_NUMBER() OVER (PARTITION BY f.user_address ORDER BY f.key DESC) AS row_number
+      FROM StencilFavorites f
+      JOIN Stencils s ON f.world_id = s.world_id AND f.stencil_id = s.stencil_id
+      WHERE f.user_address = ANY($1) AND ($4::integer IS NULL OR f.world_id = $4)`), addresses, p.Offset, p.First, worldId)
+		if err != nil {
+			return nil, err
+		}
+		stencils := make(map[string][]*Stencil)
+		for i := range rows {
+			stencils[rows[i].UserAddress] = append(stencils[rows[i].UserAddress], &rows[i].Stencil)
+		}
+		return stencils, nil
+	}
+}
+
+func fetchUserFactions(addresses []string) (map[string][]*FactionMember, error) {
+	rows, err := core.PostgresQuery[FactionMember](`
+    SELECT faction_id, false AS chain, user_address, last_placed_time, member_pixels FROM FactionMembersInfo WHERE user_address = ANY($1)
+    UNION ALL
+    SELECT faction_id, true AS chain, user_address, last_placed_time, member_pixels FROM ChainFactionMembersInfo WHERE user_address = ANY($1)
+    ORDER BY chain, faction_id`, addresses)
+	if err != nil {
+		return nil, err
+	}
+	return groupBy(pointers(rows), func(member *FactionMember) string { return member.UserAddress }), nil
+}
+
+// Biggest contributors first
+func fetchFactionMembers(p page) func(keys []factionKey) (map[factionKey][]*FactionMember, error) {
+	return func(keys []factionKey) (map[factionKey][]*FactionMember, error) {
+		ids, chainIds := splitFactionKeys(keys)
+		rows, err := core.PostgresQuery[FactionMember](pagedQuery(memberColumns, `
+      SELECT faction_id, false AS chain, user_address, last_placed_time, member_pixels,
+        ROW_NUMBER() OVER (PARTITION BY faction_id ORDER BY member_pixels DESC, user_address) AS row_number
+      FROM FactionMembersInfo WHERE faction_id = ANY($1)
+      UNION ALL
+      SELECT faction_id, true AS chain, user_address, last_placed_time, member_pixels,
+        ROW_NUMBER() OVER (PARTITION BY faction_id ORDER BY member_pixels DESC, user_address) AS row_number
+      FROM ChainFactionMembersInfo WHERE faction_id = ANY($4)`), ids, p.Offset, p.First, chainIds)
+		if err != nil {
+			return nil, err
+		}
+		return groupBy(pointers(rows), func(member *FactionMember) factionKey {
+			return factionKey{chain: member.Chain, id: member.FactionId}
+		}), nil
+	}
+}
+
+// Newest first, as in /get-stencils
+func fetchWorldStencils(p page) func(worldIds []int) (map[int][]*Stencil, error) {
+	return func(worldIds []int) (map[int][]*Stencil, error) {
+		rows, err := core.PostgresQuery[Stencil](pagedQuery(stencilColumns, `
+      SELECT *, ROW_NUMBER() OVER (PARTITION BY world_id ORDER BY stencil_id DESC) AS row_number
+      FROM Stencils WHERE world_id = ANY($1)`), worldIds, p.Offset, p.First)
+		if err != nil {
+			return nil, err
+		}
+		return groupBy(pointers(rows), func(stencil *Stencil) int { return stencil.WorldId }), nil
+	}
+}
+
+func pointers[T any](rows []T) []*T {
+	result := make([]*T, len(rows))
+	for i := range rows {
+		result[i] = &rows[i]
+	}
+	return result
+}
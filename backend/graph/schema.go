@@ -0,0 +1,468 @@
+package graph
+
+import (
+	"fmt"
+
+	gql "github.com/graphql-go/graphql"
+
+	"github.com/keep-starknet-strange/art-peace/backend/auth"
+	"github.com/keep-starknet-strange/art-peace/backend/core"
+	routeutils "github.com/keep-starknet-strange/art-peace/backend/routes/utils"
+)
+
+// Read only schema of the users, worlds, stencils, NFTs & factions
+// Relations are loaded in batches, so a list of worlds & their hosts is two queries whatever the list length
+
+type page struct {
+	First  int
+	Offset int
+}
+
+// Same defaults & maximum as the page parameters of the routes
+func pageArgs(args map[string]interface{}) page {
+	first, ok := args["first"].(int)
+	if !ok || first <= 0 {
+		first = routeutils.DefaultPageLength
+	}
+	if first > routeutils.MaxPageLength {
+		first = routeutils.MaxPageLength
+	}
+	offset, ok := args["offset"].(int)
+	if !ok || offset < 0 {
+		offset = 0
+	}
+	return page{First: first, Offset: offset}
+}
+
+func pagedArgs(args gql.FieldConfigArgument) gql.FieldConfigArgument {
+	paged := gql.FieldConfigArgument{
+		"first": &gql.ArgumentConfig{
+			Type:         gql.Int,
+			DefaultValue: routeutils.DefaultPageLength,
+			Description:  fmt.Sprintf("At most %d", routeutils.MaxPageLength),
+		},
+		"offset": &gql.ArgumentConfig{Type: gql.Int, DefaultValue: 0},
+	}
+	for name, arg := range args {
+		paged[name] = arg
+	}
+	return paged
+}
+
+func optionalInt(args map[string]interface{}, name string) *int {
+	if value, ok := args[name].(int); ok {
+		return &value
+	}
+	return nil
+}
+
+// Loader names include the optional argument, nil & 0 must not share a loader
+func optionalIntName(value *int) string {
+	if value == nil {
+		return "all"
+	}
+	return fmt.Sprint(*value)
+}
+
+func listOf(t gql.Type) gql.Output {
+	return gql.NewNonNull(gql.NewList(gql.NewNonNull(t)))
+}
+
+var schema gql.Schema
+
+func init() {
+	var err error
+	schema, err = newSchema()
+	if err != nil {
+		panic(fmt.Sprint("Invalid GraphQL schema: ", err))
+	}
+}
+
+func newSchema() (gql.Schema, error) {
+	userType := gql.NewObject(gql.ObjectConfig{
+		Name: "User",
+		Fields: gql.Fields{
+			"address": &gql.Field{Type: gql.NewNonNull(gql.String)},
+		},
+	})
+	nftType := gql.NewObject(gql.ObjectConfig{
+		Name: "NFT",
+		Fields: gql.Fields{
+			"tokenId":     &gql.Field{Type: gql.NewNonNull(gql.Int)},
+			"position":    &gql.Field{Type: gql.NewNonNull(gql.Int)},
+			"width":       &gql.Field{Type: gql.NewNonNull(gql.Int)},
+			"height":      &gql.Field{Type: gql.NewNonNull(gql.Int)},
+			"name":        &gql.Field{Type: gql.NewNonNull(gql.String)},
+			"imageHash":   &gql.Field{Type: gql.NewNonNull(gql.String)},
+			"blockNumber": &gql.Field{Type: gql.NewNonNull(gql.Int)},
+			"dayIndex":    &gql.Field{Type: gql.NewNonNull(gql.Int)},
+		},
+	})
+	worldType := gql.NewObject(gql.ObjectConfig{
+		Name: "World",
+		Fields: gql.Fields{
+			"id":                &gql.Field{Type: gql.NewNonNull(gql.Int)},
+			"name":              &gql.Field{Type: gql.NewNonNull(gql.String)},
+			"uniqueName":        &gql.Field{Type: gql.NewNonNull(gql.String)},
+			"width":             &gql.Field{Type: gql.NewNonNull(gql.Int)},
+			"height":            &gql.Field{Type: gql.NewNonNull(gql.Int)},
+			"pixelsPerTime":     &gql.Field{Type: gql.NewNonNull(gql.Int)},
+			"timeBetweenPixels": &gql.Field{Type: gql.NewNonNull(gql.Int)},
+			"startTime":         &gql.Field{Type: gql.NewNonNull(gql.DateTime)},
+			"endTime":           &gql.Field{Type: gql.NewNonNull(gql.DateTime)},
+		},
+	})
+	stencilType := gql.NewObject(gql.ObjectConfig{
+		Name: "Stencil",
+		Fields: gql.Fields{
+			"id":       &gql.Field{Type: gql.NewNonNull(gql.Int)},
+			"hash":     &gql.Field{Type: gql.NewNonNull(gql.String)},
+			"width":    &gql.Field{Type: gql.NewNonNull(gql.Int)},
+			"height":   &gql.Field{Type: gql.NewNonNull(gql.Int)},
+			"position": &gql.Field{Type: gql.NewNonNull(gql.Int)},
+		},
+	})
+	factionType := gql.NewObject(gql.ObjectConfig{
+		Name: "Faction",
+		Fields: gql.Fields{
+			"id":         &gql.Field{Type: gql.NewNonNull(gql.Int)},
+			"chain":      &gql.Field{Type: gql.NewNonNull(gql.Boolean)},
+			"name":       &gql.Field{Type: gql.NewNonNull(gql.String)},
+			"joinable":   &gql.Field{Type: gql.NewNonNull(gql.Boolean)},
+			"allocation": &gql.Field{Type: gql.NewNonNull(gql.Int)},
+			"icon":       &gql.Field{Type: gql.NewNonNull(gql.String)},
+			"telegram":   &gql.Field{Type: gql.NewNonNull(gql.String)},
+			"twitter":    &gql.Field{Type: gql.NewNonNull(gql.String)},
+			"github":     &gql.Field{Type: gql.NewNonNull(gql.String)},
+			"site":       &gql.Field{Type: gql.NewNonNull(gql.String)},
+		},
+	})
+	memberType := gql.NewObject(gql.ObjectConfig{
+		Name: "FactionMember",
+		Fields: gql.Fields{
+			"lastPlacedTime": &gql.Field{Type: gql.NewNonNull(gql.DateTime)},
+			"memberPixels":   &gql.Field{Type: gql.NewNonNull(gql.Int)},
+		},
+	})
+
+	// User
+	userType.AddFieldConfig("username", &gql.Field{
+		Type: gql.String,
+		Resolve: func(p gql.ResolveParams) (interface{}, error) {
+			return loader(p.Context, "usernames", fetchUsernames).Load(p.Source.(*User).Address), nil
+		},
+	})
+	userType.AddFieldConfig("pixelCount", &gql.Field{
+		Type:        gql.NewNonNull(gql.Int),
+		Description: "Pixels placed on the main canvas",
+		Resolve: func(p gql.ResolveParams) (interface{}, error) {
+			counts := loader(p.Context, "pixelCounts", fetchCounts[string](`
+        SELECT address AS key, COUNT(*) AS count FROM Pixels WHERE address = ANY($1) GROUP BY address`))
+			return counts.Load(p.Source.(*User).Address), nil
+		},
+	})
+	userType.AddFieldConfig("worldPixelCount", &gql.Field{
+		Type:        gql.NewNonNull(gql.Int),
+		Description: "Pixels placed on a world, or on every world",
+		Args:        gql.FieldConfigArgument{"worldId": &gql.ArgumentConfig{Type: gql.Int}},
+		Resolve: func(p gql.ResolveParams) (interface{}, error) {
+			worldId := optionalInt(p.Args, "worldId")
+			counts := loader(p.Context, loaderName("worldPixelCounts", optionalIntName(worldId)), fetchCounts[string](`
+        SELECT address AS key, COUNT(*) AS count FROM WorldsPixels
+        WHERE address = ANY($1) AND ($2::integer IS NULL OR world_id = $2)
+        GROUP BY address`, worldId))
+			return counts.Load(p.Source.(*User).Address), nil
+		},
+	})
+	userType.AddFieldConfig("nfts", &gql.Field{
+		Type:        listOf(nftType),
+		Description: "NFTs owned, newest first",
+		Args:        pagedArgs(nil),
+		Resolve: func(p gql.ResolveParams) (interface{}, error) {
+			page := pageArgs(p.Args)
+			nfts := loader(p.Context, loaderName("userNfts", page.First, page.Offset), fetchUserNfts(page))
+			return nfts.Load(p.Source.(*User).Address), nil
+		},
+	})
+	userType.AddFieldConfig("likedNfts", &gql.Field{
+		Type:        listOf(nftType),
+		Description: "NFTs liked, most recent like first",
+		Args:        pagedArgs(nil),
+		Resolve: func(p gql.ResolveParams) (interface{}, error) {
+			page := pageArgs(p.Args)
+			nfts := loader(p.Context, loaderName("userLikedNfts", page.First, page.Offset), fetchUserLikedNfts(page))
+			return nfts.Load(p.Source.(*User).Address), nil
+		},
+	})
+	userType.AddFieldConfig("favoriteWorlds", &gql.Field{
+		Type:        listOf(worldType),
+		Description: "Most recent favorite first",
+		Args:        pagedArgs(nil),
+		Resolve: func(p gql.ResolveParams) (interface{}, error) {
+			page := pageArgs(p.Args)
+			worlds := loader(p.Context, loaderName("userFavoriteWorlds", page.First, page.Offset), fetchUserFavoriteWorlds(page))
+			return worlds.Load(p.Source.(*User).Address), nil
+		},
+	})
+	userType.AddFieldConfig("hostedWorlds", &gql.Field{
+		Type:        listOf(worldType),
+		Description: "Newest first",
+		Args:        pagedArgs(nil),
+		Resolve: func(p gql.ResolveParams) (interface{}, error) {
+			page := pageArgs(p.Args)
+			worlds := loader(p.Context, loaderName("userHostedWorlds", page.First, page.Offset), fetchUserHostedWorlds(page))
+			return worlds.Load(p.Source.(*User).Address), nil
+		},
+	})
+	userType.AddFieldConfig("favoriteStencils", &gql.Field{
+		Type:        listOf(stencilType),
+		Description: "Most recent favorite first, of a world or of every world",
+		Args:        pagedArgs(gql.FieldConfigArgument{"worldId": &gql.ArgumentConfig{Type: gql.Int}}),
+		Resolve: func(p gql.ResolveParams) (interface{}, error) {
+			page := pageArgs(p.Args)
+			worldId := optionalInt(p.Args, "worldId")
+			name := loaderName("userFavoriteStencils", optionalIntName(worldId), page.First, page.Offset)
+			stencils := loader(p.Context, name, fetchUserFavoriteStencils(worldId, page))
+			return stencils.Load(p.Source.(*User).Address), nil
+		},
+	})
+	userType.AddFieldConfig("factions", &gql.Field{
+		Type:        listOf(memberType),
+		Description: "Memberships of regular factions, then chain factions",
+		Resolve: func(p gql.ResolveParams) (interface{}, error) {
+			return loader(p.Context, "userFactions", fetchUserFactions).Load(p.Source.(*User).Address), nil
+		},
+	})
+
+	// NFT
+	nftType.AddFieldConfig("minter", &gql.Field{
+		Type: gql.NewNonNull(userType),
+		Resolve: func(p gql.ResolveParams) (interface{}, error) {
+			return &User{Address: p.Source.(*NFT).Minter}, nil
+		},
+	})
+	nftType.AddFieldConfig("owner", &gql.Field{
+		Type: gql.NewNonNull(userType),
+		Resolve: func(p gql.ResolveParams) (interface{}, error) {
+			return &User{Address: p.Source.(*NFT).Owner}, nil
+		},
+	})
+	nftType.AddFieldConfig("likes", &gql.Field{
+		Type: gql.NewNonNull(gql.Int),
+		Resolve: func(p gql.ResolveParams) (interface{}, error) {
+			likes := loader(p.Context, "nftLikes", fetchCounts[int](`
+        SELECT nftKey AS key, COUNT(*) AS count FROM NFTLikes WHERE nftKey = ANY($1) GROUP BY nftKey`))
+			return likes.Load(p.Source.(*NFT).TokenId), nil
+		},
+	})
+
+	// World
+	worldType.AddFieldConfig("host", &gql.Field{
+		Type: gql.NewNonNull(userType),
+		Resolve: func(p gql.ResolveParams) (interface{}, error) {
+			return &User{Address: p.Source.(*World).Host}, nil
+		},
+	})
+	worldType.AddFieldConfig("favorites", &gql.Field{
+		Type: gql.NewNonNull(gql.Int),
+		Resolve: func(p gql.ResolveParams) (interface{}, error) {
+			favorites := loader(p.Context, "worldFavorites", fetchCounts[int](`
+        SELECT world_id AS key, COUNT(*) AS count FROM WorldFavorites WHERE world_id = ANY($1) GROUP BY world_id`))
+			return favorites.Load(p.Source.(*World).WorldId), nil
+		},
+	})
+	worldType.AddFieldConfig("stencils", &gql.Field{
+		Type:        listOf(stencilType),
+		Description: "Newest first",
+		Args:        pagedArgs(nil),
+		Resolve: func(p gql.ResolveParams) (interface{}, error) {
+			page := pageArgs(p.Args)
+			stencils := loader(p.Context, loaderName("worldStencils", page.First, page.Offset), fetchWorldStencils(page))
+			return stencils.Load(p.Source.(*World).WorldId), nil
+		},
+	})
+
+	// Stencil
+	stencilType.AddFieldConfig("world", &gql.Field{
+		Type: gql.NewNonNull(worldType),
+		Resolve: func(p gql.ResolveParams) (interface{}, error) {
+			return loader(p.Context, "worlds", fetchWorlds).Load(p.Source.(*Stencil).WorldId), nil
+		},
+	})
+	stencilType.AddFieldConfig("favorites", &gql.Field{
+		Type: gql.NewNonNull(gql.Int),
+		Resolve: func(p gql.ResolveParams) (interface{}, error) {
+			stencil := p.Source.(*Stencil)
+			key := stencilKey{worldId: stencil.WorldId, stencilId: stencil.StencilId}
+			return loader(p.Context, "stencilFavorites", fetchStencilFavorites).Load(key), nil
+		},
+	})
+	stencilType.AddFieldConfig("owner", &gql.Field{
+		Type:        userType,
+		Description: "First user to favorite the stencil",
+		Resolve: func(p gql.ResolveParams) (interface{}, error) {
+			stencil := p.Source.(*Stencil)
+			key := stencilKey{worldId: stencil.WorldId, stencilId: stencil.StencilId}
+			return loader(p.Context, "stencilOwners", fetchStencilOwners).Load(key), nil
+		},
+	})
+
+	// Faction
+	factionType.AddFieldConfig("leader", &gql.Field{
+		Type:        userType,
+		Description: "Null for chain factions",
+		Resolve: func(p gql.ResolveParams) (interface{}, error) {
+			faction := p.Source.(*Faction)
+			if faction.Leader == "" {
+				return nil, nil
+			}
+			return &User{Address: faction.Leader}, nil
+		},
+	})
+	factionType.AddFieldConfig("memberCount", &gql.Field{
+		Type: gql.NewNonNull(gql.Int),
+		Resolve: func(p gql.ResolveParams) (interface{}, error) {
+			faction := p.Source.(*Faction)
+			key := factionKey{chain: faction.Chain, id: faction.FactionId}
+			return loader(p.Context, "factionMemberCounts", fetchFactionMemberCounts).Load(key), nil
+		},
+	})
+	factionType.AddFieldConfig("members", &gql.Field{
+		Type:        listOf(memberType),
+		Description: "Most pixels first",
+		Args:        pagedArgs(nil),
+		Resolve: func(p gql.ResolveParams) (interface{}, error) {
+			faction := p.Source.(*Faction)
+			page := pageArgs(p.Args)
+			members := loader(p.Context, loaderName("factionMembers", page.First, page.Offset), fetchFactionMembers(page))
+			return members.Load(factionKey{chain: faction.Chain, id: faction.FactionId}), nil
+		},
+	})
+
+	// FactionMember
+	memberType.AddFieldConfig("user", &gql.Field{
+		Type: gql.NewNonNull(userType),
+		Resolve: func(p gql.ResolveParams) (interface{}, error) {
+			return &User{Address: p.Source.(*FactionMember).UserAddress}, nil
+		},
+	})
+	memberType.AddFieldConfig("faction", &gql.Field{
+		Type: gql.NewNonNull(factionType),
+		Resolve: func(p gql.ResolveParams) (interface{}, error) {
+			member := p.Source.(*FactionMember)
+			return loader(p.Context, "factions", fetchFactions).Load(factionKey{chain: member.Chain, id: member.FactionId}), nil
+		},
+	})
+
+	queryType := gql.NewObject(gql.ObjectConfig{
+		Name: "Query",
+		Fields: gql.Fields{
+			"user": &gql.Field{
+				Type:        gql.NewNonNull(userType),
+				Description: "Any address is a user, even without a username",
+				Args:        gql.FieldConfigArgument{"address": &gql.ArgumentConfig{Type: gql.NewNonNull(gql.String)}},
+				Resolve: func(p gql.ResolveParams) (interface{}, error) {
+					address, err := auth.ParseFelt(p.Args["address"].(string))
+					if err != nil {
+						return nil, fmt.Errorf("Invalid address")
+					}
+					return &User{Address: auth.FormatAddress(address)}, nil
+				},
+			},
+			"world": &gql.Field{
+				Type:        worldType,
+				Description: "World by id or unique name",
+				Args: gql.FieldConfigArgument{
+					"id":         &gql.ArgumentConfig{Type: gql.Int},
+					"uniqueName": &gql.ArgumentConfig{Type: gql.String},
+				},
+				Resolve: func(p gql.ResolveParams) (interface{}, error) {
+					if worldId := optionalInt(p.Args, "id"); worldId != nil {
+						return loader(p.Context, "worlds", fetchWorlds).Load(*worldId), nil
+					}
+					if uniqueName, ok := p.Args["uniqueName"].(string); ok {
+						return queryOne[World]("SELECT "+worldColumns+" FROM Worlds WHERE unique_name = $1", uniqueName)
+					}
+					return nil, fmt.Errorf("Missing id or uniqueName")
+				},
+			},
+			"worlds": &gql.Field{
+				Type:        listOf(worldType),
+				Description: "Newest first",
+				Args:        pagedArgs(nil),
+				Resolve: func(p gql.ResolveParams) (interface{}, error) {
+					page := pageArgs(p.Args)
+					return queryList[World]("SELECT "+worldColumns+" FROM Worlds ORDER BY world_id DESC LIMIT $1 OFFSET $2", page.First, page.Offset)
+				},
+			},
+			"stencil": &gql.Field{
+				Type: stencilType,
+				Args: gql.FieldConfigArgument{
+					"worldId": &gql.ArgumentConfig{Type: gql.NewNonNull(gql.Int)},
+					"id":      &gql.ArgumentConfig{Type: gql.NewNonNull(gql.Int)},
+				},
+				Resolve: func(p gql.ResolveParams) (interface{}, error) {
+					return queryOne[Stencil]("SELECT "+stencilColumns+" FROM Stencils WHERE world_id = $1 AND stencil_id = $2", p.Args["worldId"], p.Args["id"])
+				},
+			},
+			"stencils": &gql.Field{
+				Type:        listOf(stencilType),
+				Description: "Newest first",
+				Args:        pagedArgs(gql.FieldConfigArgument{"worldId": &gql.ArgumentConfig{Type: gql.NewNonNull(gql.Int)}}),
+				Resolve: func(p gql.ResolveParams) (interface{}, error) {
+					page := pageArgs(p.Args)
+					return queryList[Stencil]("SELECT "+stencilColumns+" FROM Stencils WHERE world_id = $1 ORDER BY stencil_id DESC LIMIT $2 OFFSET $3", p.Args["worldId"], page.First, page.Offset)
+				},
+			},
+			"nft": &gql.Field{
+				Type: nftType,
+				Args: gql.FieldConfigArgument{"tokenId": &gql.ArgumentConfig{Type: gql.NewNonNull(gql.Int)}},
+				Resolve: func(p gql.ResolveParams) (interface{}, error) {
+					return queryOne[NFT]("SELECT "+nftColumns+" FROM NFTs WHERE token_id = $1", p.Args["tokenId"])
+				},
+			},
+			"nfts": &gql.Field{
+				Type:        listOf(nftType),
+				Description: "Newest first",
+				Args:        pagedArgs(nil),
+				Resolve: func(p gql.ResolveParams) (interface{}, error) {
+					page := pageArgs(p.Args)
+					return queryList[NFT]("SELECT "+nftColumns+" FROM NFTs ORDER BY token_id DESC LIMIT $1 OFFSET $2", page.First, page.Offset)
+				},
+			},
+			"faction": &gql.Field{
+				Type: factionType,
+				Args: gql.FieldConfigArgument{
+					"id":    &gql.ArgumentConfig{Type: gql.NewNonNull(gql.Int)},
+					"chain": &gql.ArgumentConfig{Type: gql.Boolean, DefaultValue: false},
+				},
+				Resolve: func(p gql.ResolveParams) (interface{}, error) {
+					chain, _ := p.Args["chain"].(bool)
+					return loader(p.Context, "factions", fetchFactions).Load(factionKey{chain: chain, id: p.Args["id"].(int)}), nil
+				},
+			},
+			"factions": &gql.Field{
+				Type: listOf(factionType),
+				Args: pagedArgs(gql.FieldConfigArgument{"chain": &gql.ArgumentConfig{Type: gql.Boolean, DefaultValue: false}}),
+				Resolve: func(p gql.ResolveParams) (interface{}, error) {
+					page := pageArgs(p.Args)
+					query := factionsQuery
+					if chain, _ := p.Args["chain"].(bool); chain {
+						query = chainFactionsQuery
+					}
+					return queryList[Faction](query+" ORDER BY f.faction_id LIMIT $1 OFFSET $2", page.First, page.Offset)
+				},
+			},
+		},
+	})
+
+	return gql.NewSchema(gql.SchemaConfig{Query: queryType})
+}
+
+func queryList[T any](query string, args ...interface{}) ([]*T, error) {
+	rows, err := core.PostgresQuery[T](query, args...)
+	if err != nil {
+		return nil, loadFailed(err)
+	}
+	return pointers(rows), nil
+}
@@ -0,0 +1,139 @@
+package render
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+	"image/png"
+	"io"
+)
+
+var pngSignature = []byte{0x89, 'P', 'N', 'G', '\r', '\n', 0x1a, '\n'}
+
+type pngChunk struct {
+	Type string
+	Data []byte
+}
+
+// Split an encoded PNG into its chunks
+func readPNGChunks(data []byte) ([]pngChunk, error) {
+	if !bytes.HasPrefix(data, pngSignature) {
+		return nil, fmt.Errorf("invalid png signature")
+	}
+
+	chunks := make([]pngChunk, 0)
+	data = data[len(pngSignature):]
+	for len(data) >= 12 {
+		length := binary.BigEndian.Uint32(data[0:4])
+		if uint64(len(data)) < 12+uint64(length) {
+			return nil, fmt.Errorf("truncated png chunk")
+		}
+		chunks = append(chunks, pngChunk{Type: string(data[4:8]), Data: data[8 : 8+length]})
+		data = data[12+length:]
+	}
+	return chunks, nil
+}
+
+func writePNGChunk(w io.Writer, chunkType string, data []byte) error {
+	header := make([]byte, 8)
+	binary.BigEndian.PutUint32(header[0:4], uint32(len(data)))
+	copy(header[4:8], chunkType)
+
+	crc := crc32.NewIEEE()
+	crc.Write(header[4:8])
+	crc.Write(data)
+	footer := make([]byte, 4)
+	binary.BigEndian.PutUint32(footer, crc.Sum32())
+
+	for _, part := range [][]byte{header, data, footer} {
+		if _, err := w.Write(part); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Animated PNG, every frame is encoded as a regular PNG & its image data moved into fdAT chunks
+// https://wiki.mozilla.org/APNG_Specification
+func (t *Timelapse) EncodeAPNG(w io.Writer) error {
+	if _, err := w.Write(pngSignature); err != nil {
+		return err
+	}
+
+	encoder := png.Encoder{CompressionLevel: png.BestCompression}
+	sequence := uint32(0)
+	for idx, frame := range t.Frames {
+		var buf bytes.Buffer
+		if err := encoder.Encode(&buf, frame); err != nil {
+			return err
+		}
+		chunks, err := readPNGChunks(buf.Bytes())
+		if err != nil {
+			return err
+		}
+
+		if idx == 0 {
+			// First frame is the full image, its header & palette are shared by every frame
+			for _, chunk := range chunks {
+				if chunk.Type == "IDAT" || chunk.Type == "IEND" {
+					continue
+				}
+				if err := writePNGChunk(w, chunk.Type, chunk.Data); err != nil {
+					return err
+				}
+				if chunk.Type == "IHDR" {
+					actl := make([]byte, 8)
+					binary.BigEndian.PutUint32(actl[0:4], uint32(len(t.Frames)))
+					// Loop forever
+					binary.BigEndian.PutUint32(actl[4:8], 0)
+					if err := writePNGChunk(w, "acTL", actl); err != nil {
+						return err
+					}
+				}
+			}
+		}
+
+		bounds := frame.Bounds()
+		fctl := make([]byte, 26)
+		binary.BigEndian.PutUint32(fctl[0:4], sequence)
+		binary.BigEndian.PutUint32(fctl[4:8], uint32(bounds.Dx()))
+		binary.BigEndian.PutUint32(fctl[8:12], uint32(bounds.Dy()))
+		binary.BigEndian.PutUint32(fctl[12:16], uint32(bounds.Min.X))
+		binary.BigEndian.PutUint32(fctl[16:20], uint32(bounds.Min.Y))
+		delayNum, delayDen := t.Delays[idx], 1000
+		if delayNum > 0xFFFF {
+			// Long holds are stored in 1/100s
+			delayNum, delayDen = min(delayNum/10, 0xFFFF), 100
+		}
+		binary.BigEndian.PutUint16(fctl[20:22], uint16(delayNum))
+		binary.BigEndian.PutUint16(fctl[22:24], uint16(delayDen))
+		// APNG_DISPOSE_OP_NONE & APNG_BLEND_OP_SOURCE
+		fctl[24] = 0
+		fctl[25] = 0
+		if err := writePNGChunk(w, "fcTL", fctl); err != nil {
+			return err
+		}
+		sequence++
+
+		for _, chunk := range chunks {
+			if chunk.Type != "IDAT" {
+				continue
+			}
+			if idx == 0 {
+				err = writePNGChunk(w, "IDAT", chunk.Data)
+			} else {
+				fdat := make([]byte, 4+len(chunk.Data))
+				binary.BigEndian.PutUint32(fdat[0:4], sequence)
+				copy(fdat[4:], chunk.Data)
+				err = writePNGChunk(w, "fdAT", fdat)
+				sequence++
+			}
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return writePNGChunk(w, "IEND", nil)
+}
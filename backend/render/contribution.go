@@ -0,0 +1,86 @@
+package render
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/keep-starknet-strange/art-peace/backend/core"
+)
+
+// Frames of a contribution timelapse when neither pixels nor seconds per frame are given
+const DefaultContributionFrames = 100
+
+type lastPlacer struct {
+	Position int       `json:"position"`
+	Address  string    `json:"address"`
+	Time     time.Time `json:"time"`
+}
+
+// Positions whose latest placement is by the address & which were not cleared since
+func (s *Source) SurvivingPixels(address string) ([]bool, int, error) {
+	where, args := s.historyWhere(HistoryFilter{})
+	args = append(args, address)
+	query := "SELECT DISTINCT ON (position) position, address, time FROM " + s.HistoryTable() + " WHERE " + where + " AND position IN (SELECT position FROM " + s.HistoryTable() + " WHERE " + where + " AND address = $" + strconv.Itoa(len(args)) + ") ORDER BY position, time DESC"
+	placers, err := core.PostgresQuery[lastPlacer](query, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	clears, err := s.clearsBetween(0, 0)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	surviving := make([]bool, s.Width*s.Height)
+	count := 0
+	for _, placer := range placers {
+		if strings.TrimSpace(placer.Address) != address || placer.Position < 0 || placer.Position >= len(surviving) {
+			continue
+		}
+		cleared := false
+		x := placer.Position % s.Width
+		y := placer.Position / s.Width
+		for _, clear := range clears {
+			if x >= clear.XStart && x <= clear.XEnd && y >= clear.YStart && y <= clear.YEnd && placer.Time.Before(clear.Time) {
+				cleared = true
+				break
+			}
+		}
+		if !cleared {
+			surviving[placer.Position] = true
+			count++
+		}
+	}
+
+	return surviving, count, nil
+}
+
+// Animate the address's placements in time order over the dimmed canvas as it was at opts.To ( or now )
+func (s *Source) BuildContributionTimelapse(address string, opts TimelapseOptions) (*Timelapse, error) {
+	palette, err := s.Palette()
+	if err != nil {
+		return nil, err
+	}
+
+	base, err := s.FrameAtOrNow(opts.To)
+	if err != nil {
+		return nil, err
+	}
+	frame := HighlightFrame(base, len(palette), nil)
+
+	placements, err := s.PlacementsUpTo(HistoryFilter{From: opts.From, To: opts.To, Addresses: []string{address}}, MaxTimelapsePlacements)
+	if err != nil {
+		return nil, err
+	}
+	for idx := range placements {
+		// Keep the user's colors in the bright half of the dimmed palette
+		placements[idx].Color = int(paletteIndex(uint8(placements[idx].Color), len(palette)))
+	}
+
+	if opts.PixelsPerFrame <= 0 && opts.SecondsPerFrame <= 0 {
+		opts.PixelsPerFrame = max(1, (len(placements)+DefaultContributionFrames-1)/DefaultContributionFrames)
+	}
+
+	return replayTimelapse(frame, DimmedPalette(palette), placements, nil, opts)
+}
@@ -0,0 +1,124 @@
+package render
+
+import (
+	"fmt"
+	"image"
+	"strconv"
+	"strings"
+)
+
+// Frame holds one color index per canvas position
+type Frame struct {
+	Width  int
+	Height int
+	Pixels []uint8
+}
+
+func NewFrame(width int, height int) *Frame {
+	return &Frame{
+		Width:  width,
+		Height: height,
+		Pixels: make([]uint8, width*height),
+	}
+}
+
+// Decode a redis bitfield canvas ( big endian, bitWidth bits per pixel )
+func DecodeFrame(canvas []byte, width int, height int, bitWidth uint) *Frame {
+	frame := NewFrame(width, height)
+	for pos := 0; pos < width*height; pos++ {
+		frame.Pixels[pos] = ColorAt(canvas, pos, bitWidth)
+	}
+
+	return frame
+}
+
+func ColorAt(canvas []byte, position int, bitWidth uint) uint8 {
+	bitPos := uint(position) * bitWidth
+	var val uint8
+	for i := uint(0); i < bitWidth; i++ {
+		bytePos := (bitPos + i) / 8
+		if int(bytePos) >= len(canvas) {
+			// Unset tail of the canvas
+			return 0
+		}
+		bit := (canvas[bytePos] >> (7 - (bitPos+i)%8)) & 1
+		val = val<<1 | bit
+	}
+
+	return val
+}
+
+// Encode the frame in the redis bitfield format, the inverse of DecodeFrame
+func (f *Frame) EncodeBitfield(bitWidth uint) []byte {
+	canvas := make([]byte, (uint(len(f.Pixels))*bitWidth+7)/8)
+	for pos, colorIdx := range f.Pixels {
+		bitPos := uint(pos) * bitWidth
+		for i := uint(0); i < bitWidth; i++ {
+			if (colorIdx>>(bitWidth-1-i))&1 == 1 {
+				canvas[(bitPos+i)/8] |= 1 << (7 - (bitPos+i)%8)
+			}
+		}
+	}
+
+	return canvas
+}
+
+func (f *Frame) Copy() *Frame {
+	frame := NewFrame(f.Width, f.Height)
+	copy(frame.Pixels, f.Pixels)
+	return frame
+}
+
+func (f *Frame) Contains(position int) bool {
+	return position >= 0 && position < len(f.Pixels)
+}
+
+func (f *Frame) Set(position int, colorIdx uint8) {
+	if f.Contains(position) {
+		f.Pixels[position] = colorIdx
+	}
+}
+
+func (f *Frame) Bounds() image.Rectangle {
+	return image.Rect(0, 0, f.Width, f.Height)
+}
+
+// Return the part of the frame inside rect, clipped to the frame bounds
+func (f *Frame) Crop(rect image.Rectangle) *Frame {
+	rect = rect.Intersect(f.Bounds())
+	frame := NewFrame(rect.Dx(), rect.Dy())
+	for y := rect.Min.Y; y < rect.Max.Y; y++ {
+		copy(frame.Pixels[(y-rect.Min.Y)*frame.Width:], f.Pixels[y*f.Width+rect.Min.X:y*f.Width+rect.Max.X])
+	}
+
+	return frame
+}
+
+// Parse a crop query param formatted like "x,y,width,height"
+func ParseCrop(crop string, width int, height int) (image.Rectangle, error) {
+	if crop == "" {
+		return image.Rect(0, 0, width, height), nil
+	}
+
+	parts := strings.Split(crop, ",")
+	if len(parts) != 4 {
+		return image.Rectangle{}, fmt.Errorf("crop must be formatted like x,y,width,height")
+	}
+
+	values := make([]int, 4)
+	for idx, part := range parts {
+		val, err := strconv.Atoi(strings.TrimSpace(part))
+		if err != nil || val < 0 {
+			return image.Rectangle{}, fmt.Errorf("invalid crop value: %s", part)
+		}
+		values[idx] = val
+	}
+
+	rect := image.Rect(values[0], values[1], values[0]+values[2], values[1]+values[3])
+	rect = rect.Intersect(image.Rect(0, 0, width, height))
+	if rect.Empty() {
+		return image.Rectangle{}, fmt.Errorf("crop is outside of the canvas")
+	}
+
+	return rect, nil
+}
@@ -0,0 +1,143 @@
+package render
+
+import (
+	"image"
+	"image/color"
+	"math"
+)
+
+// Placement counts aggregated into CellSize x CellSize cells
+type Heatmap struct {
+	Width    int     `json:"width"`
+	Height   int     `json:"height"`
+	CellSize int     `json:"cellSize"`
+	Columns  int     `json:"columns"`
+	Rows     int     `json:"rows"`
+	Max      int     `json:"max"`
+	Total    int     `json:"total"`
+	Counts   []int   `json:"-"`
+	Cells    []*Cell `json:"cells"`
+}
+
+type Cell struct {
+	X     int `json:"x"`
+	Y     int `json:"y"`
+	Count int `json:"count"`
+}
+
+type PositionCount struct {
+	Position int `json:"position"`
+	Count    int `json:"count"`
+}
+
+func NewHeatmap(width int, height int, cellSize int, positionCounts []PositionCount) *Heatmap {
+	if cellSize < 1 {
+		cellSize = 1
+	}
+	columns := (width + cellSize - 1) / cellSize
+	rows := (height + cellSize - 1) / cellSize
+
+	heatmap := &Heatmap{
+		Width:    width,
+		Height:   height,
+		CellSize: cellSize,
+		Columns:  columns,
+		Rows:     rows,
+		Counts:   make([]int, columns*rows),
+		Cells:    make([]*Cell, 0),
+	}
+
+	for _, positionCount := range positionCounts {
+		if positionCount.Position < 0 || positionCount.Position >= width*height {
+			continue
+		}
+		x := (positionCount.Position % width) / cellSize
+		y := (positionCount.Position / width) / cellSize
+		heatmap.Counts[y*columns+x] += positionCount.Count
+		heatmap.Total += positionCount.Count
+	}
+
+	for idx, count := range heatmap.Counts {
+		if count == 0 {
+			continue
+		}
+		if count > heatmap.Max {
+			heatmap.Max = count
+		}
+		// Cell coordinates are given in canvas pixels
+		heatmap.Cells = append(heatmap.Cells, &Cell{X: (idx % columns) * cellSize, Y: (idx / columns) * cellSize, Count: count})
+	}
+
+	return heatmap
+}
+
+var heatGradient = []color.RGBA{
+	{R: 0x00, G: 0x00, B: 0xFF, A: 0xFF},
+	{R: 0x00, G: 0xFF, B: 0xFF, A: 0xFF},
+	{R: 0x00, G: 0xFF, B: 0x00, A: 0xFF},
+	{R: 0xFF, G: 0xFF, B: 0x00, A: 0xFF},
+	{R: 0xFF, G: 0x00, B: 0x00, A: 0xFF},
+}
+
+// Map a value in [0, 1] onto the heat gradient, more intense values are also more opaque
+func HeatColor(value float64) color.NRGBA {
+	if value <= 0 {
+		return color.NRGBA{}
+	}
+	if value > 1 {
+		value = 1
+	}
+
+	scaled := value * float64(len(heatGradient)-1)
+	idx := int(scaled)
+	if idx >= len(heatGradient)-1 {
+		idx = len(heatGradient) - 2
+	}
+	t := scaled - float64(idx)
+	from := heatGradient[idx]
+	to := heatGradient[idx+1]
+	lerp := func(a uint8, b uint8) uint8 {
+		return uint8(float64(a) + (float64(b)-float64(a))*t)
+	}
+
+	return color.NRGBA{
+		R: lerp(from.R, to.R),
+		G: lerp(from.G, to.G),
+		B: lerp(from.B, to.B),
+		A: uint8(96 + 159*value),
+	}
+}
+
+// Transparent overlay with the same dimensions as the ( scaled ) canvas
+// Counts are log scaled so a few hot spots don't wash out the rest of the canvas
+func (h *Heatmap) Overlay(scale int) *image.NRGBA {
+	if scale < 1 {
+		scale = 1
+	}
+	if scale > MaxScale {
+		scale = MaxScale
+	}
+	scale = fitScale(h.Width, h.Height, scale, MaxRGBAOutputPixels)
+
+	img := image.NewNRGBA(image.Rect(0, 0, h.Width*scale, h.Height*scale))
+	if h.Max == 0 {
+		return img
+	}
+
+	logMax := math.Log1p(float64(h.Max))
+	for idx, count := range h.Counts {
+		if count == 0 {
+			continue
+		}
+		c := HeatColor(math.Log1p(float64(count)) / logMax)
+		minX := (idx % h.Columns) * h.CellSize * scale
+		minY := (idx / h.Columns) * h.CellSize * scale
+		for y := minY; y < minY+h.CellSize*scale && y < h.Height*scale; y++ {
+			for x := minX; x < minX+h.CellSize*scale && x < h.Width*scale; x++ {
+				img.SetNRGBA(x, y, c)
+			}
+		}
+	}
+
+	return img
+}
@@ -0,0 +1,47 @@
+package render
+
+import (
+	"image"
+	"image/color"
+)
+
+// How much non highlighted pixels are faded toward DimColor ( 0 - 255 )
+const DimAmount = 180
+
+var DimColor = color.RGBA{R: 0xFF, G: 0xFF, B: 0xFF, A: 0xFF}
+
+func dim(c color.RGBA) color.RGBA {
+	blend := func(a uint8, b uint8) uint8 {
+		return uint8((uint(a)*(255-DimAmount) + uint(b)*DimAmount) / 255)
+	}
+	return color.RGBA{R: blend(c.R, DimColor.R), G: blend(c.G, DimColor.G), B: blend(c.B, DimColor.B), A: 0xFF}
+}
+
+// Palette made of the game colors followed by their dimmed variants
+func DimmedPalette(palette []color.RGBA) []color.RGBA {
+	dimmed := make([]color.RGBA, 0, len(palette)*2)
+	dimmed = append(dimmed, palette...)
+	for _, c := range palette {
+		dimmed = append(dimmed, dim(c))
+	}
+	return dimmed
+}
+
+// Render the frame with every position where highlight is false dimmed
+func Highlighted(frame *Frame, palette []color.RGBA, highlight []bool, opts Options) *image.Paletted {
+	return Paletted(HighlightFrame(frame, len(palette), highlight), DimmedPalette(palette), opts)
+}
+
+// Remap the frame's color indexes onto a DimmedPalette
+func HighlightFrame(frame *Frame, paletteLen int, highlight []bool) *Frame {
+	remapped := frame.Copy()
+	for pos, colorIdx := range remapped.Pixels {
+		colorIdx = paletteIndex(colorIdx, paletteLen)
+		if pos < len(highlight) && highlight[pos] {
+			remapped.Pixels[pos] = colorIdx
+		} else {
+			remapped.Pixels[pos] = colorIdx + uint8(paletteLen)
+		}
+	}
+	return remapped
+}
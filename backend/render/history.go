@@ -0,0 +1,103 @@
+package render
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/keep-starknet-strange/art-peace/backend/core"
+)
+
+// Filter on the pixel placement history of a source
+// Zero values mean unbounded / unfiltered
+type HistoryFilter struct {
+	From           int64
+	To             int64
+	Addresses      []string
+	FactionId      *int
+	ChainFactionId *int
+}
+
+type Placement struct {
+	Address  string    `json:"address"`
+	Position int       `json:"position"`
+	Color    int       `json:"color"`
+	Time     time.Time `json:"time"`
+}
+
+func (s *Source) HistoryTable() string {
+	if s.IsWorld {
+		return "WorldsPixels"
+	}
+	return "Pixels"
+}
+
+func (s *Source) historyWhere(filter HistoryFilter) (string, []interface{}) {
+	conditions := []string{"TRUE"}
+	args := []interface{}{}
+	addArg := func(arg interface{}) string {
+		args = append(args, arg)
+		return "$" + strconv.Itoa(len(args))
+	}
+
+	if s.IsWorld {
+		conditions = append(conditions, "world_id = "+addArg(s.WorldId))
+	}
+	if filter.From != 0 {
+		conditions = append(conditions, "time >= TO_TIMESTAMP("+addArg(filter.From)+")")
+	}
+	if filter.To != 0 {
+		conditions = append(conditions, "time < TO_TIMESTAMP("+addArg(filter.To)+")")
+	}
+	if len(filter.Addresses) > 0 {
+		conditions = append(conditions, "address = ANY("+addArg(filter.Addresses)+")")
+	}
+	if filter.FactionId != nil {
+		conditions = append(conditions, "address IN (SELECT user_address FROM FactionMembersInfo WHERE faction_id = "+addArg(*filter.FactionId)+")")
+	}
+	if filter.ChainFactionId != nil {
+		conditions = append(conditions, "address IN (SELECT user_address FROM ChainFactionMembersInfo WHERE faction_id = "+addArg(*filter.ChainFactionId)+")")
+	}
+
+	return strings.Join(conditions, " AND "), args
+}
+
+// Number of placements per position
+func (s *Source) PositionCounts(filter HistoryFilter) ([]PositionCount, error) {
+	where, args := s.historyWhere(filter)
+	query := "SELECT position, COUNT(*) AS count FROM " + s.HistoryTable() + " WHERE " + where + " GROUP BY position"
+	return core.PostgresQuery[PositionCount](query, args...)
+}
+
+// Placements in time order
+func (s *Source) Placements(filter HistoryFilter) ([]Placement, error) {
+	where, args := s.historyWhere(filter)
+	query := "SELECT address, position, color, time FROM " + s.HistoryTable() + " WHERE " + where + " ORDER BY time ASC"
+	return core.PostgresQuery[Placement](query, args...)
+}
+
+// Placements in time order, failing without loading them all if there are more than limit
+func (s *Source) PlacementsUpTo(filter HistoryFilter, limit int) ([]Placement, error) {
+	where, args := s.historyWhere(filter)
+	args = append(args, limit+1)
+	query := "SELECT address, position, color, time FROM " + s.HistoryTable() + " WHERE " + where + " ORDER BY time ASC LIMIT $" + strconv.Itoa(len(args))
+	placements, err := core.PostgresQuery[Placement](query, args...)
+	if err != nil {
+		return nil, err
+	}
+	if len(placements) > limit {
+		return nil, fmt.Errorf("more than %d placements, use a shorter time range", limit)
+	}
+	return placements, nil
+}
+
+// Number of placements matching the filter
+func (s *Source) CountPlacements(filter HistoryFilter) (int, error) {
+	where, args := s.historyWhere(filter)
+	count, err := core.PostgresQueryOne[int]("SELECT COUNT(*) FROM "+s.HistoryTable()+" WHERE "+where, args...)
+	if err != nil {
+		return 0, err
+	}
+	return *count, nil
+}
@@ -0,0 +1,330 @@
+package render
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/png"
+	"io"
+	"strconv"
+)
+
+const MaxScale = 32
+
+// Pixels of a rendered image, the full main canvas fits up to scale 4
+const MaxOutputPixels = 4096 * 4096
+
+// Requests for larger images are rejected before rendering
+func CheckOutputSize(width int, height int, scale int) error {
+	if width*height*scale*scale > MaxOutputPixels {
+		return fmt.Errorf("%dx%d pixels at scale %d is over the %d pixels limit, use a smaller crop or scale", width, height, scale, MaxOutputPixels)
+	}
+	return nil
+}
+
+// RGBA images use 4 bytes per pixel, so they get a quarter of the pixels to use as much memory as paletted ones
+const MaxRGBAOutputPixels = MaxOutputPixels / 4
+
+func CheckRGBAOutputSize(width int, height int, scale int) error {
+	if width*height*scale*scale > MaxRGBAOutputPixels {
+		return fmt.Errorf("%dx%d pixels at scale %d is over the %d pixels limit of RGBA images, use a smaller scale", width, height, scale, MaxRGBAOutputPixels)
+	}
+	return nil
+}
+
+// Highest scale up to the given one keeping the image under maxPixels
+func fitScale(width int, height int, scale int, maxPixels int) int {
+	for scale > 1 && width*height*scale*scale > maxPixels {
+		scale--
+	}
+	return scale
+}
+
+type Options struct {
+	// Integer upscale factor, each canvas pixel becomes a Scale x Scale block
+	Scale int
+	// Draw a line between every canvas pixel ( requires Scale >= 4 )
+	Grid bool
+	// Draw x / y coordinates in a margin on the top & left
+	Labels bool
+	// Canvas coordinates of the frame's top left pixel, used for labels
+	Origin image.Point
+}
+
+var GridColor = color.RGBA{R: 0x80, G: 0x80, B: 0x80, A: 0xFF}
+var LabelColor = color.RGBA{R: 0x00, G: 0x00, B: 0x00, A: 0xFF}
+var MarginColor = color.RGBA{R: 0xFF, G: 0xFF, B: 0xFF, A: 0xFF}
+
+func (o Options) scale() int {
+	if o.Scale < 1 {
+		return 1
+	}
+	if o.Scale > MaxScale {
+		return MaxScale
+	}
+	return o.Scale
+}
+
+func (o Options) drawGrid() bool {
+	return o.Grid && o.scale() >= 4
+}
+
+// Clamp color indexes which are outside of the palette to the first color
+func paletteIndex(colorIdx uint8, paletteLen int) uint8 {
+	if int(colorIdx) >= paletteLen {
+		return 0
+	}
+	return colorIdx
+}
+
+// Render the frame to a paletted image whose first colors are exactly the given palette
+// Extra colors for grid & labels are appended after the game colors when needed
+func Paletted(frame *Frame, palette []color.RGBA, opts Options) *image.Paletted {
+	opts.Scale = fitScale(frame.Width, frame.Height, opts.scale(), MaxOutputPixels)
+	scale := opts.scale()
+
+	imagePalette := make(color.Palette, 0, len(palette)+3)
+	for _, c := range palette {
+		imagePalette = append(imagePalette, c)
+	}
+	if len(imagePalette) == 0 {
+		imagePalette = append(imagePalette, MarginColor)
+	}
+	gameColors := len(imagePalette)
+
+	var gridIdx, labelIdx, marginIdx uint8
+	if opts.drawGrid() {
+		gridIdx = uint8(len(imagePalette))
+		imagePalette = append(imagePalette, GridColor)
+	}
+
+	marginLeft, marginTop := 0, 0
+	if opts.Labels {
+		labelIdx = uint8(len(imagePalette))
+		imagePalette = append(imagePalette, LabelColor)
+		marginIdx = uint8(len(imagePalette))
+		imagePalette = append(imagePalette, MarginColor)
+		marginLeft, marginTop = labelMargins(frame, opts)
+	}
+
+	img := image.NewPaletted(image.Rect(0, 0, marginLeft+frame.Width*scale, marginTop+frame.Height*scale), imagePalette)
+	if opts.Labels {
+		for idx := range img.Pix {
+			img.Pix[idx] = marginIdx
+		}
+	}
+
+	for y := 0; y < frame.Height; y++ {
+		for x := 0; x < frame.Width; x++ {
+			colorIdx := paletteIndex(frame.Pixels[y*frame.Width+x], gameColors)
+			for dy := 0; dy < scale; dy++ {
+				rowStart := img.PixOffset(marginLeft+x*scale, marginTop+y*scale+dy)
+				for dx := 0; dx < scale; dx++ {
+					img.Pix[rowStart+dx] = colorIdx
+				}
+			}
+		}
+	}
+
+	if opts.drawGrid() {
+		bounds := img.Bounds()
+		for x := 0; x <= frame.Width; x++ {
+			px := marginLeft + x*scale
+			if px >= bounds.Max.X {
+				px = bounds.Max.X - 1
+			}
+			for py := marginTop; py < bounds.Max.Y; py++ {
+				img.SetColorIndex(px, py, gridIdx)
+			}
+		}
+		for y := 0; y <= frame.Height; y++ {
+			py := marginTop + y*scale
+			if py >= bounds.Max.Y {
+				py = bounds.Max.Y - 1
+			}
+			for px := marginLeft; px < bounds.Max.X; px++ {
+				img.SetColorIndex(px, py, gridIdx)
+			}
+		}
+	}
+
+	if opts.Labels {
+		drawLabels(img, frame, opts, marginLeft, marginTop, labelIdx)
+	}
+
+	return img
+}
+
+// Paletted PNG ( color type 3 ) using the game palette
+func EncodePNG(w io.Writer, frame *Frame, palette []color.RGBA, opts Options) error {
+	encoder := png.Encoder{CompressionLevel: png.BestCompression}
+	return encoder.Encode(w, Paletted(frame, palette, opts))
+}
+
+func EncodeGIF(w io.Writer, frame *Frame, palette []color.RGBA, opts Options) error {
+	return gif.Encode(w, Paletted(frame, palette, opts), &gif.Options{NumColors: 256})
+}
+
+func hexColor(c color.RGBA) string {
+	const digits = "0123456789abcdef"
+	return string([]byte{'#', digits[c.R>>4], digits[c.R&0xF], digits[c.G>>4], digits[c.G&0xF], digits[c.B>>4], digits[c.B&0xF]})
+}
+
+// SVG with one rect per horizontal run of same colored pixels
+func EncodeSVG(w io.Writer, frame *Frame, palette []color.RGBA, opts Options) error {
+	scale := opts.scale()
+	marginLeft, marginTop := 0, 0
+	if opts.Labels {
+		marginLeft, marginTop = labelMargins(frame, opts)
+	}
+	width := marginLeft + frame.Width*scale
+	height := marginTop + frame.Height*scale
+
+	out := &errWriter{w: w}
+	out.printf(`<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" shape-rendering="crispEdges">`+"\n", width, height, width, height)
+	if opts.Labels {
+		out.printf(`<rect width="%d" height="%d" fill="%s"/>`+"\n", width, height, hexColor(MarginColor))
+	}
+
+	paletteLen := len(palette)
+	for y := 0; y < frame.Height; y++ {
+		runStart := 0
+		for x := 1; x <= frame.Width; x++ {
+			if x < frame.Width && frame.Pixels[y*frame.Width+x] == frame.Pixels[y*frame.Width+runStart] {
+				continue
+			}
+			fill := MarginColor
+			if paletteLen > 0 {
+				fill = palette[paletteIndex(frame.Pixels[y*frame.Width+runStart], paletteLen)]
+			}
+			out.printf(`<rect x="%d" y="%d" width="%d" height="%d" fill="%s"/>`+"\n", marginLeft+runStart*scale, marginTop+y*scale, (x-runStart)*scale, scale, hexColor(fill))
+			runStart = x
+		}
+	}
+
+	if opts.drawGrid() {
+		out.printf(`<g stroke="%s" stroke-width="1">`+"\n", hexColor(GridColor))
+		for x := 0; x <= frame.Width; x++ {
+			out.printf(`<line x1="%d" y1="%d" x2="%d" y2="%d"/>`+"\n", marginLeft+x*scale, marginTop, marginLeft+x*scale, height)
+		}
+		for y := 0; y <= frame.Height; y++ {
+			out.printf(`<line x1="%d" y1="%d" x2="%d" y2="%d"/>`+"\n", marginLeft, marginTop+y*scale, width, marginTop+y*scale)
+		}
+		out.printf("</g>\n")
+	}
+
+	if opts.Labels {
+		step := labelStep(frame, opts)
+		fontSize := labelFontScale(opts) * glyphHeight
+		out.printf(`<g font-family="monospace" font-size="%d" fill="%s">`+"\n", fontSize+2, hexColor(LabelColor))
+		for x := firstLabel(opts.Origin.X, step); x < opts.Origin.X+frame.Width; x += step {
+			out.printf(`<text x="%d" y="%d">%d</text>`+"\n", marginLeft+(x-opts.Origin.X)*scale, marginTop-2, x)
+		}
+		for y := firstLabel(opts.Origin.Y, step); y < opts.Origin.Y+frame.Height; y += step {
+			out.printf(`<text x="0" y="%d">%d</text>`+"\n", marginTop+(y-opts.Origin.Y)*scale+fontSize, y)
+		}
+		out.printf("</g>\n")
+	}
+
+	out.printf("</svg>\n")
+	return out.err
+}
+
+// Labels
+
+const glyphWidth = 3
+const glyphHeight = 5
+
+// 3x5 bitmap digits, one row per byte using the low 3 bits
+var digitGlyphs = [10][glyphHeight]uint8{
+	{0b111, 0b101, 0b101, 0b101, 0b111},
+	{0b010, 0b110, 0b010, 0b010, 0b111},
+	{0b111, 0b001, 0b111, 0b100, 0b111},
+	{0b111, 0b001, 0b111, 0b001, 0b111},
+	{0b101, 0b101, 0b111, 0b001, 0b001},
+	{0b111, 0b100, 0b111, 0b001, 0b111},
+	{0b111, 0b100, 0b111, 0b101, 0b111},
+	{0b111, 0b001, 0b010, 0b010, 0b010},
+	{0b111, 0b101, 0b111, 0b101, 0b111},
+	{0b111, 0b101, 0b111, 0b001, 0b111},
+}
+
+func labelFontScale(opts Options) int {
+	if opts.scale() >= 8 {
+		return 2
+	}
+	return 1
+}
+
+func labelWidth(value int, fontScale int) int {
+	return len(strconv.Itoa(value)) * (glyphWidth + 1) * fontScale
+}
+
+func labelMargins(frame *Frame, opts Options) (int, int) {
+	fontScale := labelFontScale(opts)
+	maxLabel := opts.Origin.X + frame.Width
+	if opts.Origin.Y+frame.Height > maxLabel {
+		maxLabel = opts.Origin.Y + frame.Height
+	}
+	return labelWidth(maxLabel, fontScale) + 2, glyphHeight*fontScale + 3
+}
+
+// Smallest "nice" step where labels don't overlap
+func labelStep(frame *Frame, opts Options) int {
+	fontScale := labelFontScale(opts)
+	maxLabel := opts.Origin.X + frame.Width
+	if opts.Origin.Y+frame.Height > maxLabel {
+		maxLabel = opts.Origin.Y + frame.Height
+	}
+	minSpacing := labelWidth(maxLabel, fontScale) + 2*fontScale
+	if glyphHeight*fontScale+2 > minSpacing {
+		minSpacing = glyphHeight*fontScale + 2
+	}
+
+	for _, step := range []int{1, 2, 5, 10, 20, 50, 100, 200, 500, 1000} {
+		if step*opts.scale() >= minSpacing {
+			return step
+		}
+	}
+	return 1000
+}
+
+func firstLabel(origin int, step int) int {
+	if origin%step == 0 {
+		return origin
+	}
+	return origin + step - origin%step
+}
+
+func drawNumber(img *image.Paletted, value int, x int, y int, fontScale int, colorIdx uint8) {
+	for _, digit := range strconv.Itoa(value) {
+		glyph := digitGlyphs[digit-'0']
+		for row := 0; row < glyphHeight; row++ {
+			for col := 0; col < glyphWidth; col++ {
+				if glyph[row]&(1<<(glyphWidth-1-col)) == 0 {
+					continue
+				}
+				for dy := 0; dy < fontScale; dy++ {
+					for dx := 0; dx < fontScale; dx++ {
+						img.SetColorIndex(x+col*fontScale+dx, y+row*fontScale+dy, colorIdx)
+					}
+				}
+			}
+		}
+		x += (glyphWidth + 1) * fontScale
+	}
+}
+
+func drawLabels(img *image.Paletted, frame *Frame, opts Options, marginLeft int, marginTop int, colorIdx uint8) {
+	scale := opts.scale()
+	fontScale := labelFontScale(opts)
+	step := labelStep(frame, opts)
+
+	for x := firstLabel(opts.Origin.X, step); x < opts.Origin.X+frame.Width; x += step {
+		drawNumber(img, x, marginLeft+(x-opts.Origin.X)*scale, 1, fontScale, colorIdx)
+	}
+	for y := firstLabel(opts.Origin.Y, step); y < opts.Origin.Y+frame.Height; y += step {
+		drawNumber(img, y, 1, marginTop+(y-opts.Origin.Y)*scale, fontScale, colorIdx)
+	}
+}
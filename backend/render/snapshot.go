@@ -0,0 +1,77 @@
+package render
+
+import (
+	"time"
+
+	"github.com/keep-starknet-strange/art-peace/backend/core"
+)
+
+type CanvasClear struct {
+	Time   time.Time `json:"time"`
+	XStart int       `json:"xStart"`
+	XEnd   int       `json:"xEnd"`
+	YStart int       `json:"yStart"`
+	YEnd   int       `json:"yEnd"`
+}
+
+type lastPlacement struct {
+	Position int       `json:"position"`
+	Color    int       `json:"color"`
+	Time     time.Time `json:"time"`
+}
+
+// Admin clears in [from, to), 0 meaning unbounded
+func (s *Source) clearsBetween(from int64, to int64) ([]CanvasClear, error) {
+	if !s.IsWorld {
+		// Clears are only done on worlds
+		return []CanvasClear{}, nil
+	}
+
+	query := "SELECT time, x_start, x_end, y_start, y_end FROM CanvasClears WHERE world_id = $1 AND ($2::bigint = 0 OR time >= TO_TIMESTAMP($2::bigint)) AND ($3::bigint = 0 OR time < TO_TIMESTAMP($3::bigint)) ORDER BY time ASC"
+	return core.PostgresQuery[CanvasClear](query, s.WorldId, from, to)
+}
+
+// Rebuild the canvas as it was at the given unix time from the placement history & admin clears
+func (s *Source) FrameAt(at int64) (*Frame, error) {
+	where, args := s.historyWhere(HistoryFilter{To: at})
+	query := "SELECT DISTINCT ON (position) position, color, time FROM " + s.HistoryTable() + " WHERE " + where + " ORDER BY position, time DESC"
+	placements, err := core.PostgresQuery[lastPlacement](query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	frame := NewFrame(s.Width, s.Height)
+	placedAt := make([]time.Time, s.Width*s.Height)
+	for _, placement := range placements {
+		if !frame.Contains(placement.Position) {
+			continue
+		}
+		frame.Pixels[placement.Position] = uint8(placement.Color)
+		placedAt[placement.Position] = placement.Time
+	}
+
+	clears, err := s.clearsBetween(0, at)
+	if err != nil {
+		return nil, err
+	}
+	for _, clear := range clears {
+		for y := clear.YStart; y <= clear.YEnd && y < s.Height; y++ {
+			for x := clear.XStart; x <= clear.XEnd && x < s.Width; x++ {
+				pos := y*s.Width + x
+				if placedAt[pos].Before(clear.Time) {
+					frame.Pixels[pos] = 0
+				}
+			}
+		}
+	}
+
+	return frame, nil
+}
+
+// Canvas at the given unix time, or the live canvas if at is 0
+func (s *Source) FrameAtOrNow(at int64) (*Frame, error) {
+	if at == 0 {
+		return s.Load()
+	}
+	return s.FrameAt(at)
+}
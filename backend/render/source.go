@@ -0,0 +1,133 @@
+package render
+
+import (
+	"context"
+	"fmt"
+	"image/color"
+	"strconv"
+
+	"github.com/keep-starknet-strange/art-peace/backend/core"
+)
+
+// Source is a canvas stored in redis, either the main round canvas or a world canvas
+type Source struct {
+	IsWorld bool
+	WorldId int
+	Round   string
+	Width   int
+	Height  int
+}
+
+func MainSource(round string) *Source {
+	if round == "" {
+		round = core.ArtPeaceBackend.CanvasConfig.Round
+	}
+
+	return &Source{
+		IsWorld: false,
+		Round:   round,
+		Width:   int(core.ArtPeaceBackend.CanvasConfig.Canvas.Width),
+		Height:  int(core.ArtPeaceBackend.CanvasConfig.Canvas.Height),
+	}
+}
+
+type worldSize struct {
+	Width  int `json:"width"`
+	Height int `json:"height"`
+}
+
+func WorldSource(worldId int) (*Source, error) {
+	size, err := core.PostgresQueryOne[worldSize]("SELECT width, height FROM worlds WHERE world_id = $1", worldId)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Source{
+		IsWorld: true,
+		WorldId: worldId,
+		Width:   size.Width,
+		Height:  size.Height,
+	}, nil
+}
+
+// Build a source from the usual worldId & round query params, defaulting to the main canvas
+func SourceFromQuery(worldIdStr string, round string) (*Source, error) {
+	if worldIdStr == "" {
+		return MainSource(round), nil
+	}
+
+	worldId, err := strconv.Atoi(worldIdStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid worldId: %s", worldIdStr)
+	}
+
+	return WorldSource(worldId)
+}
+
+func (s *Source) RedisKey() string {
+	if s.IsWorld {
+		return fmt.Sprintf("canvas-%d", s.WorldId)
+	}
+	return fmt.Sprintf("canvas-%s", s.Round)
+}
+
+func (s *Source) Palette() ([]color.RGBA, error) {
+	var colorsHex []string
+	var err error
+	if s.IsWorld {
+		colorsHex, err = core.PostgresQuery[string]("SELECT hex FROM WorldsColors WHERE world_id = $1 ORDER BY color_key", s.WorldId)
+	} else {
+		colorsHex, err = core.PostgresQuery[string]("SELECT hex FROM colors ORDER BY color_key")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return ParsePalette(colorsHex)
+}
+
+// Load the current state of the canvas from redis
+func (s *Source) Load() (*Frame, error) {
+	ctx := context.Background()
+	canvas, err := core.ArtPeaceBackend.Databases.Redis.Get(ctx, s.RedisKey()).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	return DecodeFrame([]byte(canvas), s.Width, s.Height, core.ArtPeaceBackend.CanvasConfig.ColorsBitWidth), nil
+}
+
+func HexToRGBA(colorHex string) (color.RGBA, error) {
+	// Hex like "rrggbb", optionally prefixed
+	if len(colorHex) < 6 {
+		return color.RGBA{}, fmt.Errorf("invalid hex color: %s", colorHex)
+	}
+	colorHex = colorHex[len(colorHex)-6:]
+
+	r, err := strconv.ParseUint(colorHex[0:2], 16, 8)
+	if err != nil {
+		return color.RGBA{}, err
+	}
+	g, err := strconv.ParseUint(colorHex[2:4], 16, 8)
+	if err != nil {
+		return color.RGBA{}, err
+	}
+	b, err := strconv.ParseUint(colorHex[4:6], 16, 8)
+	if err != nil {
+		return color.RGBA{}, err
+	}
+	return color.RGBA{R: uint8(r), G: uint8(g), B: uint8(b), A: 255}, nil
+}
+
+func ParsePalette(colorsHex []string) ([]color.RGBA, error) {
+	palette := make([]color.RGBA, len(colorsHex))
+	for idx, colorHex := range colorsHex {
+		c, err := HexToRGBA(colorHex)
+		if err != nil {
+			return nil, err
+		}
+		palette[idx] = c
+	}
+
+	return palette, nil
+}
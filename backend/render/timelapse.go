@@ -0,0 +1,262 @@
+package render
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/gif"
+	"io"
+	"time"
+)
+
+const (
+	MaxTimelapseFrames = 3000
+	// Placements replayed by one timelapse, they are all loaded before rendering
+	MaxTimelapsePlacements = 1000000
+	// Bytes of the frames held in memory until the timelapse is encoded
+	MaxTimelapseFrameBytes = 256 << 20
+	DefaultFrameDelay      = 100
+	// How long the last frame is held before looping ( ms )
+	FinalFrameDelay = 2000
+)
+
+type TimelapseOptions struct {
+	// Unix seconds, 0 meaning unbounded
+	From int64
+	To   int64
+	// Start a new frame every PixelsPerFrame placements or every SecondsPerFrame of history
+	PixelsPerFrame  int
+	SecondsPerFrame int64
+	// Canvas region to render, the whole canvas if empty
+	Crop   image.Rectangle
+	Render Options
+	// Delay between frames ( ms )
+	FrameDelay int
+	// Called as placements are replayed
+	Progress func(replayed int, total int)
+}
+
+// Timelapse frames are stored as the changed region of the image since the previous frame
+type Timelapse struct {
+	Width   int
+	Height  int
+	Palette color.Palette
+	Frames  []*image.Paletted
+	// Per frame delay ( ms )
+	Delays []int
+}
+
+func (o TimelapseOptions) frameDelay() int {
+	if o.FrameDelay <= 0 {
+		return DefaultFrameDelay
+	}
+	return o.FrameDelay
+}
+
+// Replay the placement history in time order, rendering a frame every PixelsPerFrame placements or SecondsPerFrame
+func (s *Source) BuildTimelapse(opts TimelapseOptions) (*Timelapse, error) {
+	if opts.PixelsPerFrame <= 0 && opts.SecondsPerFrame <= 0 {
+		return nil, fmt.Errorf("pixels per frame or seconds per frame is required")
+	}
+
+	palette, err := s.Palette()
+	if err != nil {
+		return nil, err
+	}
+
+	frame := NewFrame(s.Width, s.Height)
+	if opts.From != 0 {
+		frame, err = s.FrameAt(opts.From)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	placements, err := s.PlacementsUpTo(HistoryFilter{From: opts.From, To: opts.To}, MaxTimelapsePlacements)
+	if err != nil {
+		return nil, err
+	}
+	clears, err := s.clearsBetween(opts.From, opts.To)
+	if err != nil {
+		return nil, err
+	}
+
+	return replayTimelapse(frame, palette, placements, clears, opts)
+}
+
+// Apply the placements & clears to frame in time order, collecting the rendered frames
+func replayTimelapse(frame *Frame, palette []color.RGBA, placements []Placement, clears []CanvasClear, opts TimelapseOptions) (*Timelapse, error) {
+	crop := opts.Crop
+	if crop.Empty() {
+		crop = frame.Bounds()
+	}
+	opts.Render.Origin = crop.Min
+
+	timelapse := &Timelapse{}
+	var previous *image.Paletted
+	frameBytes := 0
+	addFrame := func() error {
+		if len(timelapse.Frames) >= MaxTimelapseFrames {
+			return fmt.Errorf("timelapse exceeds %d frames, use more pixels or seconds per frame", MaxTimelapseFrames)
+		}
+
+		img := Paletted(frame.Crop(crop), palette, opts.Render)
+		if previous == nil {
+			frameBytes += len(img.Pix)
+			timelapse.Width = img.Bounds().Dx()
+			timelapse.Height = img.Bounds().Dy()
+			timelapse.Palette = img.Palette
+			timelapse.Frames = append(timelapse.Frames, img)
+			timelapse.Delays = append(timelapse.Delays, opts.frameDelay())
+			previous = img
+			return nil
+		}
+
+		changed := changedBounds(previous, img)
+		if changed.Empty() {
+			// Nothing visible changed, hold the previous frame longer instead
+			timelapse.Delays[len(timelapse.Delays)-1] += opts.frameDelay()
+			return nil
+		}
+		// Only the changed region is kept, but a frame can still cover the whole crop
+		frameBytes += changed.Dx() * changed.Dy()
+		if frameBytes > MaxTimelapseFrameBytes {
+			return fmt.Errorf("timelapse frames exceed %d bytes, use a smaller crop or scale, or more pixels or seconds per frame", MaxTimelapseFrameBytes)
+		}
+		timelapse.Frames = append(timelapse.Frames, copyRegion(img, changed))
+		timelapse.Delays = append(timelapse.Delays, opts.frameDelay())
+		previous = img
+		return nil
+	}
+
+	if err := addFrame(); err != nil {
+		return nil, err
+	}
+
+	var frameEnd time.Time
+	if opts.SecondsPerFrame > 0 && len(placements) > 0 {
+		start := placements[0].Time
+		if opts.From != 0 {
+			start = time.Unix(opts.From, 0).UTC()
+		}
+		frameEnd = start.Add(time.Duration(opts.SecondsPerFrame) * time.Second)
+	}
+
+	clearIdx := 0
+	pixelsInFrame := 0
+	for idx, placement := range placements {
+		if opts.SecondsPerFrame > 0 && !placement.Time.Before(frameEnd) {
+			if pixelsInFrame > 0 {
+				if err := addFrame(); err != nil {
+					return nil, err
+				}
+				pixelsInFrame = 0
+			}
+			for !placement.Time.Before(frameEnd) {
+				frameEnd = frameEnd.Add(time.Duration(opts.SecondsPerFrame) * time.Second)
+			}
+		}
+
+		// Clears only affect pixels placed before them
+		for clearIdx < len(clears) && !clears[clearIdx].Time.After(placement.Time) {
+			frame.clear(clears[clearIdx])
+			clearIdx++
+		}
+		frame.Set(placement.Position, uint8(placement.Color))
+		pixelsInFrame++
+
+		if opts.PixelsPerFrame > 0 && pixelsInFrame >= opts.PixelsPerFrame {
+			if err := addFrame(); err != nil {
+				return nil, err
+			}
+			pixelsInFrame = 0
+		}
+		if opts.Progress != nil && (idx%1000 == 0 || idx == len(placements)-1) {
+			opts.Progress(idx+1, len(placements))
+		}
+	}
+	for ; clearIdx < len(clears); clearIdx++ {
+		frame.clear(clears[clearIdx])
+		pixelsInFrame++
+	}
+	if pixelsInFrame > 0 {
+		if err := addFrame(); err != nil {
+			return nil, err
+		}
+	}
+
+	last := len(timelapse.Delays) - 1
+	if timelapse.Delays[last] < FinalFrameDelay {
+		timelapse.Delays[last] = FinalFrameDelay
+	}
+
+	return timelapse, nil
+}
+
+func (f *Frame) clear(clear CanvasClear) {
+	for y := clear.YStart; y <= clear.YEnd && y < f.Height; y++ {
+		for x := clear.XStart; x <= clear.XEnd && x < f.Width; x++ {
+			f.Pixels[y*f.Width+x] = 0
+		}
+	}
+}
+
+// Smallest rectangle containing every pixel which differs between two images of the same size
+func changedBounds(a *image.Paletted, b *image.Paletted) image.Rectangle {
+	bounds := b.Bounds()
+	changed := image.Rectangle{}
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		rowA := a.Pix[a.PixOffset(bounds.Min.X, y):a.PixOffset(bounds.Max.X, y)]
+		rowB := b.Pix[b.PixOffset(bounds.Min.X, y):b.PixOffset(bounds.Max.X, y)]
+		for x := range rowB {
+			if rowA[x] != rowB[x] {
+				changed = changed.Union(image.Rect(bounds.Min.X+x, y, bounds.Min.X+x+1, y+1))
+			}
+		}
+	}
+	return changed
+}
+
+// Copy of the region so the full image can be released
+func copyRegion(img *image.Paletted, rect image.Rectangle) *image.Paletted {
+	region := image.NewPaletted(rect, img.Palette)
+	for y := rect.Min.Y; y < rect.Max.Y; y++ {
+		copy(region.Pix[region.PixOffset(rect.Min.X, y):], img.Pix[img.PixOffset(rect.Min.X, y):img.PixOffset(rect.Max.X, y)])
+	}
+	return region
+}
+
+func (t *Timelapse) EncodeGIF(w io.Writer) error {
+	anim := &gif.GIF{
+		Image:    t.Frames,
+		Delay:    make([]int, len(t.Delays)),
+		Disposal: make([]byte, len(t.Frames)),
+		Config: image.Config{
+			ColorModel: t.Palette,
+			Width:      t.Width,
+			Height:     t.Height,
+		},
+	}
+	for idx, delay := range t.Delays {
+		// GIF delays are in 1/100s
+		anim.Delay[idx] = delay / 10
+		anim.Disposal[idx] = gif.DisposalNone
+	}
+	return gif.EncodeAll(w, anim)
+}
+
+// Content types of the supported timelapse formats
+var TimelapseContentTypes = map[string]string{
+	"gif":  "image/gif",
+	"apng": "image/apng",
+}
+
+func (t *Timelapse) Encode(w io.Writer, format string) error {
+	switch format {
+	case "gif":
+		return t.EncodeGIF(w)
+	case "apng":
+		return t.EncodeAPNG(w)
+	}
+	return fmt.Errorf("invalid timelapse format: %s", format)
+}
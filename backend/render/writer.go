@@ -0,0 +1,19 @@
+package render
+
+import (
+	"fmt"
+	"io"
+)
+
+// Writer which keeps the first error so encoders can check once at the end
+type errWriter struct {
+	w   io.Writer
+	err error
+}
+
+func (e *errWriter) printf(format string, args ...interface{}) {
+	if e.err != nil {
+		return
+	}
+	_, e.err = fmt.Fprintf(e.w, format, args...)
+}
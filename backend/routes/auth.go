@@ -0,0 +1,154 @@
+package routes
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/NethermindEth/juno/core/felt"
+
+	"github.com/keep-starknet-strange/art-peace/backend/auth"
+	"github.com/keep-starknet-strange/art-peace/backend/core"
+	"github.com/keep-starknet-strange/art-peace/backend/routes/response"
+	routeutils "github.com/keep-starknet-strange/art-peace/backend/routes/utils"
+)
+
+// Login flow :
+//  1. /get-auth-nonce?address=0x... returns SNIP-12 typed data with a fresh nonce
+//  2. The wallet signs it & the client posts the signature with the nonce to /login
+//  3. /login returns a session token, sent as Authorization: Bearer <token> to routes requiring a session
+func InitAuthRoutes() {
+	routeutils.Get("/get-auth-nonce", getAuthNonce, routeutils.RateLimit(routeutils.RateLimitAuth))
+	routeutils.Post("/login", login, routeutils.RateLimit(routeutils.RateLimitAuth))
+	routeutils.Post("/logout", logout, routeutils.Auth)
+	routeutils.Get("/get-session", getSession, routeutils.Auth)
+}
+
+type LoginRequest struct {
+	Address string `json:"address"`
+	// Nonce of the signed typed data
+	Nonce     string   `json:"nonce"`
+	PublicKey string   `json:"publicKey"`
+	Signature []string `json:"signature"`
+}
+
+type SessionResponse struct {
+	Address   string    `json:"address"`
+	Token     string    `json:"token,omitempty"`
+	ExpiresAt time.Time `json:"expiresAt,omitempty"`
+}
+
+func getAuthNonce(w http.ResponseWriter, r *http.Request) {
+	address, err := auth.ParseFelt(r.URL.Query().Get("address"))
+	if err != nil {
+		routeutils.WriteErrorJson(w, http.StatusBadRequest, "Invalid address")
+		return
+	}
+
+	typedData, err := auth.IssueNonce(address)
+	if err != nil {
+		routeutils.WriteErrorJson(w, http.StatusInternalServerError, "Failed to issue nonce")
+		return
+	}
+	typedDataJson, err := json.Marshal(typedData)
+	if err != nil {
+		routeutils.WriteErrorJson(w, http.StatusInternalServerError, "Failed to marshal typed data")
+		return
+	}
+	routeutils.WriteDataJson(w, string(typedDataJson))
+}
+
+func login(w http.ResponseWriter, r *http.Request) {
+	jsonBody, err := routeutils.ReadJsonBody[LoginRequest](r)
+	if err != nil {
+		routeutils.WriteErrorJson(w, http.StatusBadRequest, "Invalid JSON request body")
+		return
+	}
+
+	address, err := auth.ParseFelt(jsonBody.Address)
+	if err != nil {
+		routeutils.WriteErrorJson(w, http.StatusBadRequest, "Invalid address")
+		return
+	}
+	nonce, err := auth.ParseFelt(jsonBody.Nonce)
+	if err != nil {
+		routeutils.WriteErrorJson(w, http.StatusBadRequest, "Invalid nonce")
+		return
+	}
+	publicKey, err := auth.ParseFelt(jsonBody.PublicKey)
+	if err != nil {
+		routeutils.WriteErrorJson(w, http.StatusBadRequest, "Invalid publicKey")
+		return
+	}
+	signature := make([]*felt.Felt, 0, len(jsonBody.Signature))
+	for _, value := range jsonBody.Signature {
+		part, err := auth.ParseFelt(value)
+		if err != nil {
+			routeutils.WriteErrorJson(w, http.StatusBadRequest, "Invalid signature")
+			return
+		}
+		signature = append(signature, part)
+	}
+
+	token, err := auth.Login(address, nonce, publicKey, signature)
+	if errors.Is(err, auth.ErrNonceNotFound) {
+		routeutils.WriteErrorCodeJson(w, http.StatusUnauthorized, response.CodeNonceNotFound, err.Error())
+		return
+	}
+	if errors.Is(err, auth.ErrInvalidSignature) {
+		routeutils.WriteErrorCodeJson(w, http.StatusUnauthorized, response.CodeInvalidSignature, err.Error())
+		return
+	}
+	if err != nil {
+		fmt.Println("Failed to login", err)
+		routeutils.WriteErrorJson(w, http.StatusInternalServerError, "Failed to login")
+		return
+	}
+
+	session, err := json.Marshal(SessionResponse{
+		Address:   auth.FormatAddress(address),
+		Token:     token,
+		ExpiresAt: time.Now().Add(auth.SessionTTL()),
+	})
+	if err != nil {
+		routeutils.WriteErrorJson(w, http.StatusInternalServerError, "Failed to marshal session")
+		return
+	}
+	routeutils.WriteDataJson(w, string(session))
+}
+
+func logout(w http.ResponseWriter, r *http.Request) {
+	if err := auth.Logout(routeutils.SessionToken(r)); err != nil {
+		routeutils.WriteErrorJson(w, http.StatusInternalServerError, "Failed to logout")
+		return
+	}
+	routeutils.WriteResultJson(w, "Logged out")
+}
+
+func getSession(w http.ResponseWriter, r *http.Request) {
+	session, err := json.Marshal(SessionResponse{Address: routeutils.SessionAddress(r)})
+	if err != nil {
+		routeutils.WriteErrorJson(w, http.StatusInternalServerError, "Failed to marshal session")
+		return
+	}
+	routeutils.WriteDataJson(w, string(session))
+}
+
+// Websocket clients send {"messageType":"authenticate","token":"..."} to use features requiring a session
+func handleAuthenticateMessage(client *core.WSClient, msg *wsClientMessage) string {
+	address, err := auth.SessionAddress(msg.Token)
+	if err != nil || address == "" {
+		routeutils.WriteWebSocketMessage(client, map[string]string{
+			"messageType": "authError",
+			"error":       "invalid or expired session",
+		})
+		return ""
+	}
+	routeutils.WriteWebSocketMessage(client, map[string]string{
+		"messageType": "authenticated",
+		"address":     address,
+	})
+	return msg.Token
+}
@@ -5,21 +5,17 @@ import (
 	"fmt"
 	"net/http"
 
+	"github.com/keep-starknet-strange/art-peace/backend/auth"
 	"github.com/keep-starknet-strange/art-peace/backend/core"
 	routeutils "github.com/keep-starknet-strange/art-peace/backend/routes/utils"
 )
 
 func InitCanvasRoutes() {
-	http.HandleFunc("/init-canvas", initCanvas)
-	http.HandleFunc("/get-canvas", getCanvas)
+	routeutils.Post("/init-canvas", initCanvas, routeutils.RequirePermission(auth.PermissionSetup))
+	routeutils.Get("/get-canvas", getCanvas)
 }
 
 func initCanvas(w http.ResponseWriter, r *http.Request) {
-	// Only allow admin to initialize canvas
-	if routeutils.AdminMiddleware(w, r) {
-		return
-	}
-
 	roundNumber := core.ArtPeaceBackend.CanvasConfig.Round
 	canvasKey := fmt.Sprintf("canvas-%s", roundNumber)
 
@@ -0,0 +1,324 @@
+package routes
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/keep-starknet-strange/art-peace/backend/auth"
+	"github.com/keep-starknet-strange/art-peace/backend/core"
+	routeutils "github.com/keep-starknet-strange/art-peace/backend/routes/utils"
+)
+
+func InitChatRoutes() {
+	routeutils.Get("/get-chat-messages", getChatMessages)
+	routeutils.Get("/worlds/{worldId}/chat", getChatMessages)
+	routeutils.Post("/delete-chat-message", deleteChatMessage, routeutils.AuditApiKey)
+	routeutils.Post("/mute-chat-address", muteChatAddress, routeutils.AuditApiKey)
+	routeutils.Post("/unmute-chat-address", unmuteChatAddress, routeutils.AuditApiKey)
+}
+
+type ChatMessage struct {
+	Key     int       `json:"key"`
+	WorldId int       `json:"worldId"`
+	Address string    `json:"address"`
+	Message string    `json:"message"`
+	Time    time.Time `json:"time"`
+}
+
+type DeleteChatMessageRequest struct {
+	Address string `json:"address"`
+	WorldId int    `json:"worldId"`
+	Key     int    `json:"key"`
+}
+
+type MuteChatAddressRequest struct {
+	Address string `json:"address"`
+	WorldId int    `json:"worldId"`
+	Target  string `json:"target"`
+	// Seconds, 0 mutes until unmuted
+	Duration int64 `json:"duration"`
+}
+
+// Filters run on each chat message before it is stored & sent
+// They return the text to keep, or an error to reject the message
+type ChatFilter func(worldId int, address string, message string) (string, error)
+
+var chatFilters = []ChatFilter{blockedWordsFilter}
+
+func RegisterChatFilter(filter ChatFilter) {
+	chatFilters = append(chatFilters, filter)
+}
+
+var blockedWordsOnce sync.Once
+var blockedWordsRegexp *regexp.Regexp
+
+// Mask the words from the chat config with asterisks
+func blockedWordsFilter(worldId int, address string, message string) (string, error) {
+	blockedWordsOnce.Do(func() {
+		words := make([]string, 0)
+		for _, word := range core.ArtPeaceBackend.BackendConfig.Chat.BlockedWords {
+			if word = strings.TrimSpace(word); word != "" {
+				words = append(words, regexp.QuoteMeta(word))
+			}
+		}
+		if len(words) > 0 {
+			blockedWordsRegexp = regexp.MustCompile(`(?i)\b(` + strings.Join(words, "|") + `)\b`)
+		}
+	})
+	if blockedWordsRegexp == nil {
+		return message, nil
+	}
+	return blockedWordsRegexp.ReplaceAllStringFunc(message, func(word string) string {
+		return strings.Repeat("*", utf8.RuneCountInString(word))
+	}), nil
+}
+
+func chatMaxMessageLength() int {
+	if core.ArtPeaceBackend.BackendConfig.Chat.MaxMessageLength <= 0 {
+		return 280
+	}
+	return core.ArtPeaceBackend.BackendConfig.Chat.MaxMessageLength
+}
+
+func chatMuted(worldId int, address string) (bool, error) {
+	muted, err := core.PostgresQueryOne[bool]("SELECT EXISTS(SELECT 1 FROM ChatMutes WHERE world_id = $1 AND address = $2 AND (until IS NULL OR until > NOW()))", worldId, address)
+	if err != nil {
+		return false, err
+	}
+	return *muted, nil
+}
+
+// Only one message per address every message_interval seconds, shared by every websocket server
+func chatRateLimited(address string) bool {
+	interval := core.ArtPeaceBackend.BackendConfig.Chat.MessageInterval
+	if interval <= 0 {
+		return false
+	}
+	ok, err := core.ArtPeaceBackend.Databases.Redis.SetNX(context.Background(), "chat-rate-"+address, 1, time.Duration(interval)*time.Second).Result()
+	if err != nil {
+		fmt.Println("Failed to check chat rate limit", err)
+		return false
+	}
+	return !ok
+}
+
+func sendChatMessage(session string, msg *wsClientMessage) error {
+	address, err := auth.SessionAddress(session)
+	if err != nil {
+		return fmt.Errorf("failed to check session")
+	}
+	if address == "" {
+		return fmt.Errorf("authentication is required")
+	}
+	if msg.WorldId < 0 {
+		return fmt.Errorf("invalid worldId")
+	}
+	message := strings.TrimSpace(msg.Text)
+	if message == "" {
+		return fmt.Errorf("empty message")
+	}
+	if utf8.RuneCountInString(message) > chatMaxMessageLength() {
+		return fmt.Errorf("message longer than %d characters", chatMaxMessageLength())
+	}
+
+	muted, err := chatMuted(msg.WorldId, address)
+	if err != nil {
+		return fmt.Errorf("failed to check mutes")
+	}
+	if muted {
+		return fmt.Errorf("address is muted")
+	}
+	if chatRateLimited(address) {
+		return fmt.Errorf("sending messages too fast")
+	}
+
+	for _, filter := range chatFilters {
+		message, err = filter(msg.WorldId, address, message)
+		if err != nil {
+			return err
+		}
+	}
+
+	var key int
+	var sentAt time.Time
+	err = core.ArtPeaceBackend.Databases.Postgres.QueryRow(context.Background(), "INSERT INTO ChatMessages (world_id, address, message) VALUES ($1, $2, $3) RETURNING key, time", msg.WorldId, address, message).Scan(&key, &sentAt)
+	if err != nil {
+		return fmt.Errorf("failed to store message")
+	}
+
+	routeutils.SendMessageToWSS(map[string]string{
+		"messageType": "chatMessage",
+		"worldId":     strconv.Itoa(msg.WorldId),
+		"key":         strconv.Itoa(key),
+		"address":     address,
+		"message":     message,
+		"time":        strconv.FormatInt(sentAt.Unix(), 10),
+	})
+	return nil
+}
+
+func handleChatMessage(client *core.WSClient, session string, msg *wsClientMessage) {
+	if err := sendChatMessage(session, msg); err != nil {
+		routeutils.WriteWebSocketMessage(client, map[string]string{
+			"messageType": "chatError",
+			"error":       err.Error(),
+		})
+	}
+}
+
+// ex: /get-chat-messages?worldId=13&page=1&pageLength=25
+// Newest messages first
+func getChatMessages(w http.ResponseWriter, r *http.Request) {
+	worldIdStr := r.URL.Query().Get("worldId")
+	if worldIdStr == "" {
+		worldIdStr = "0"
+	}
+	worldId, err := strconv.Atoi(worldIdStr)
+	if err != nil || worldId < 0 {
+		routeutils.WriteErrorJson(w, http.StatusBadRequest, "Invalid worldId")
+		return
+	}
+
+	pagination := routeutils.ParsePagination(r)
+	after := firstNewestCursor()
+	if err := pagination.ParseCursor(&after); err != nil {
+		writeInvalidCursor(w)
+		return
+	}
+
+	messages, err := core.PostgresQueryJson[ChatMessage]("SELECT key, world_id, address, message, time FROM ChatMessages WHERE world_id = $1 AND deleted = false AND key < $4 ORDER BY key DESC LIMIT $2 OFFSET $3", worldId, pagination.Limit(), pagination.Offset(), after.Id)
+	if err != nil {
+		routeutils.WriteErrorJson(w, http.StatusInternalServerError, "Failed to retrieve chat messages")
+		return
+	}
+	routeutils.WritePageJson(w, messages, pagination, func(last ChatMessage) interface{} {
+		return newestCursor{Id: last.Key}
+	})
+}
+
+// Address of the host acting on the chat in its stored form, empty for admin & API key requests without one
+func moderatorAddress(w http.ResponseWriter, address string) (string, bool) {
+	if address == "" {
+		return "", true
+	}
+	normalized, err := auth.NormalizeAddress(address)
+	if err != nil {
+		routeutils.WriteErrorJson(w, http.StatusBadRequest, "Invalid address")
+		return "", false
+	}
+	return normalized, true
+}
+
+func deleteChatMessage(w http.ResponseWriter, r *http.Request) {
+	jsonBody, err := routeutils.ReadJsonBody[DeleteChatMessageRequest](r)
+	if err != nil {
+		routeutils.WriteErrorJson(w, http.StatusBadRequest, "Invalid JSON request body")
+		return
+	}
+
+	if hostOrAdminMiddleware(w, r, jsonBody.WorldId, jsonBody.Address) {
+		return
+	}
+
+	deletedBy, ok := moderatorAddress(w, jsonBody.Address)
+	if !ok {
+		return
+	}
+	result, err := core.ArtPeaceBackend.Databases.Postgres.Exec(context.Background(), "UPDATE ChatMessages SET deleted = true, deleted_by = $3 WHERE key = $1 AND world_id = $2 AND deleted = false", jsonBody.Key, jsonBody.WorldId, deletedBy)
+	if err != nil {
+		routeutils.WriteErrorJson(w, http.StatusInternalServerError, "Failed to delete chat message")
+		return
+	}
+	if result.RowsAffected() == 0 {
+		routeutils.WriteErrorJson(w, http.StatusNotFound, "Chat message not found")
+		return
+	}
+
+	var message = map[string]string{
+		"worldId":     strconv.Itoa(jsonBody.WorldId),
+		"key":         strconv.Itoa(jsonBody.Key),
+		"messageType": "chatMessageDeleted",
+	}
+	go routeutils.SendMessageToWSS(message)
+
+	routeutils.WriteResultJson(w, "Chat message deleted")
+}
+
+func muteChatAddress(w http.ResponseWriter, r *http.Request) {
+	jsonBody, err := routeutils.ReadJsonBody[MuteChatAddressRequest](r)
+	if err != nil {
+		routeutils.WriteErrorJson(w, http.StatusBadRequest, "Invalid JSON request body")
+		return
+	}
+
+	if hostOrAdminMiddleware(w, r, jsonBody.WorldId, jsonBody.Address) {
+		return
+	}
+
+	if strings.TrimSpace(jsonBody.Target) == "" {
+		routeutils.WriteErrorJson(w, http.StatusBadRequest, "Missing target")
+		return
+	}
+	target, err := auth.NormalizeAddress(jsonBody.Target)
+	if err != nil {
+		routeutils.WriteErrorJson(w, http.StatusBadRequest, "Invalid target")
+		return
+	}
+	if jsonBody.Duration < 0 {
+		routeutils.WriteErrorJson(w, http.StatusBadRequest, "Invalid duration")
+		return
+	}
+
+	var until *time.Time
+	if jsonBody.Duration > 0 {
+		end := time.Now().Add(time.Duration(jsonBody.Duration) * time.Second)
+		until = &end
+	}
+	mutedBy, ok := moderatorAddress(w, jsonBody.Address)
+	if !ok {
+		return
+	}
+	_, err = core.ArtPeaceBackend.Databases.Postgres.Exec(context.Background(), "INSERT INTO ChatMutes (world_id, address, muted_by, until) VALUES ($1, $2, $3, $4) ON CONFLICT (world_id, address) DO UPDATE SET muted_by = $3, until = $4, time = NOW()", jsonBody.WorldId, target, mutedBy, until)
+	if err != nil {
+		routeutils.WriteErrorJson(w, http.StatusInternalServerError, "Failed to mute address")
+		return
+	}
+
+	routeutils.WriteResultJson(w, "Address muted")
+}
+
+func unmuteChatAddress(w http.ResponseWriter, r *http.Request) {
+	jsonBody, err := routeutils.ReadJsonBody[MuteChatAddressRequest](r)
+	if err != nil {
+		routeutils.WriteErrorJson(w, http.StatusBadRequest, "Invalid JSON request body")
+		return
+	}
+
+	if hostOrAdminMiddleware(w, r, jsonBody.WorldId, jsonBody.Address) {
+		return
+	}
+
+	target, err := auth.NormalizeAddress(jsonBody.Target)
+	if err != nil {
+		routeutils.WriteErrorJson(w, http.StatusBadRequest, "Invalid target")
+		return
+	}
+	result, err := core.ArtPeaceBackend.Databases.Postgres.Exec(context.Background(), "DELETE FROM ChatMutes WHERE world_id = $1 AND address = $2", jsonBody.WorldId, target)
+	if err != nil {
+		routeutils.WriteErrorJson(w, http.StatusInternalServerError, "Failed to unmute address")
+		return
+	}
+	if result.RowsAffected() == 0 {
+		routeutils.WriteErrorJson(w, http.StatusNotFound, "Address is not muted")
+		return
+	}
+
+	routeutils.WriteResultJson(w, "Address unmuted")
+}
@@ -4,24 +4,20 @@ import (
 	"context"
 	"net/http"
 
+	"github.com/keep-starknet-strange/art-peace/backend/auth"
 	"github.com/keep-starknet-strange/art-peace/backend/core"
 	routeutils "github.com/keep-starknet-strange/art-peace/backend/routes/utils"
 )
 
 func InitColorsRoutes() {
-	http.HandleFunc("/init-colors", InitColors)
-	http.HandleFunc("/get-colors", GetAllColors)
-	http.HandleFunc("/get-color", GetSingleColor)
+	routeutils.Post("/init-colors", InitColors, routeutils.RequirePermission(auth.PermissionSetup))
+	routeutils.Get("/get-colors", GetAllColors)
+	routeutils.Get("/get-color", GetSingleColor)
 }
 
 type ColorType = string
 
 func InitColors(w http.ResponseWriter, r *http.Request) {
-	// Only allow admin to initialize colors
-	if routeutils.AdminMiddleware(w, r) {
-		return
-	}
-
 	// TODO: check if colors already exist
 	colors, err := routeutils.ReadJsonBody[[]ColorType](r)
 	if err != nil {
@@ -8,29 +8,25 @@ import (
 	"os"
 	"strconv"
 
+	"github.com/keep-starknet-strange/art-peace/backend/auth"
 	"github.com/keep-starknet-strange/art-peace/backend/core"
 	routeutils "github.com/keep-starknet-strange/art-peace/backend/routes/utils"
 )
 
 func InitContractRoutes() {
-	http.HandleFunc("/get-contract-address", getContractAddress)
-	http.HandleFunc("/set-contract-address", setContractAddress)
-	http.HandleFunc("/get-factory-contract-address", getFactoryContractAddress)
-	http.HandleFunc("/set-factory-contract-address", setFactoryContractAddress)
-	http.HandleFunc("/get-game-data", getGameData)
+	routeutils.Get("/get-contract-address", getContractAddress)
+	routeutils.Post("/set-contract-address", setContractAddress, routeutils.RequirePermission(auth.PermissionSetup))
+	routeutils.Get("/get-factory-contract-address", getFactoryContractAddress)
+	routeutils.Post("/set-factory-contract-address", setFactoryContractAddress, routeutils.RequirePermission(auth.PermissionSetup))
+	routeutils.Get("/get-game-data", getGameData)
 }
 
 func getContractAddress(w http.ResponseWriter, r *http.Request) {
 	contractAddress := os.Getenv("ART_PEACE_CONTRACT_ADDRESS")
-	routeutils.WriteDataJson(w, "\""+contractAddress+"\"")
+	routeutils.WriteStringDataJson(w, contractAddress)
 }
 
 func setContractAddress(w http.ResponseWriter, r *http.Request) {
-	// Only allow admin to set contract address
-	if routeutils.AdminMiddleware(w, r) {
-		return
-	}
-
 	data, err := io.ReadAll(r.Body)
 	if err != nil {
 		routeutils.WriteErrorJson(w, http.StatusBadRequest, "Failed to read request body")
@@ -42,15 +38,10 @@ func setContractAddress(w http.ResponseWriter, r *http.Request) {
 
 func getFactoryContractAddress(w http.ResponseWriter, r *http.Request) {
 	contractAddress := os.Getenv("CANVAS_FACTORY_CONTRACT_ADDRESS")
-	routeutils.WriteDataJson(w, "\""+contractAddress+"\"")
+	routeutils.WriteStringDataJson(w, contractAddress)
 }
 
 func setFactoryContractAddress(w http.ResponseWriter, r *http.Request) {
-	// Only allow admin to set contract address
-	if routeutils.AdminMiddleware(w, r) {
-		return
-	}
-
 	data, err := io.ReadAll(r.Body)
 	if err != nil {
 		routeutils.WriteErrorJson(w, http.StatusBadRequest, "Failed to read request body")
@@ -0,0 +1,106 @@
+package routes
+
+import (
+	"math"
+	"net/http"
+	"time"
+
+	"github.com/keep-starknet-strange/art-peace/backend/core"
+	"github.com/keep-starknet-strange/art-peace/backend/routes/response"
+	routeutils "github.com/keep-starknet-strange/art-peace/backend/routes/utils"
+)
+
+// Keyset cursors of the list routes, the sort values of a page's last item
+// Their first page values sort before every item, so the same query serves page numbers & cursors
+
+// Lists ordered by id, newest first
+type newestCursor struct {
+	Id int `json:"id"`
+}
+
+func firstNewestCursor() newestCursor {
+	return newestCursor{Id: math.MaxInt32}
+}
+
+// Stencil ids are only unique in their world
+type stencilCursor struct {
+	StencilId int `json:"stencilId"`
+	WorldId   int `json:"worldId"`
+}
+
+func firstStencilCursor() stencilCursor {
+	return stencilCursor{StencilId: math.MaxInt32, WorldId: math.MaxInt32}
+}
+
+// Lists ordered by id, oldest first
+type oldestCursor struct {
+	Id int `json:"id"`
+}
+
+func firstOldestCursor() oldestCursor {
+	return oldestCursor{Id: -1}
+}
+
+type addressCursor struct {
+	Address string `json:"address"`
+}
+
+// Hot & top rankings, ties broken by id
+// Scores are counted up to the latest like or favorite when the first page was read, so new ones don't reorder the next pages
+type rankingCursor struct {
+	AsOf  int `json:"asOf"`
+	Score int `json:"score"`
+	Id    int `json:"id"`
+	// Stencil rankings
+	WorldId int `json:"worldId,omitempty"`
+}
+
+func firstRankingCursor() rankingCursor {
+	return rankingCursor{Score: math.MaxInt32, Id: math.MaxInt32, WorldId: math.MaxInt32}
+}
+
+// Leaderboards, ties broken by key
+// Pixels are counted up to the latest one when the first page was read
+type leaderboardCursor struct {
+	AsOf  time.Time `json:"asOf"`
+	Score int       `json:"score"`
+	Key   string    `json:"key"`
+}
+
+func firstLeaderboardCursor() leaderboardCursor {
+	return leaderboardCursor{Score: math.MaxInt32}
+}
+
+func writeInvalidCursor(w http.ResponseWriter) {
+	routeutils.WriteErrorCodeJson(w, http.StatusBadRequest, response.CodeInvalidCursor, "Invalid cursor")
+}
+
+// Snapshot of a ranking on its first page, the latest key of its likes or favorites table
+func (c *rankingCursor) snapshot(table string) error {
+	if c.AsOf != 0 {
+		return nil
+	}
+	latest, err := core.PostgresQueryOne[int]("SELECT COALESCE(MAX(key), 0) FROM " + table)
+	if err != nil {
+		return err
+	}
+	c.AsOf = *latest
+	return nil
+}
+
+func (c *leaderboardCursor) snapshot() error {
+	if !c.AsOf.IsZero() {
+		return nil
+	}
+	latest, err := core.PostgresQueryOne[time.Time]("SELECT COALESCE(MAX(time), LOCALTIMESTAMP) FROM WorldsPixels")
+	if err != nil {
+		return err
+	}
+	c.AsOf = *latest
+	return nil
+}
+
+// Next cursor of a leaderboard page, in the same snapshot
+func (c leaderboardCursor) next(last LeaderboardEntry) interface{} {
+	return leaderboardCursor{AsOf: c.AsOf, Score: last.Score, Key: last.Key}
+}
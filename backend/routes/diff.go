@@ -0,0 +1,165 @@
+package routes
+
+import (
+	"bytes"
+	"encoding/json"
+	"image"
+	"image/png"
+	"net/http"
+	"time"
+
+	"github.com/keep-starknet-strange/art-peace/backend/core"
+	"github.com/keep-starknet-strange/art-peace/backend/render"
+	routeutils "github.com/keep-starknet-strange/art-peace/backend/routes/utils"
+)
+
+func InitDiffRoutes() {
+	routeutils.Get("/canvas-diff", getCanvasDiff, renderRateLimit)
+}
+
+type PixelChange struct {
+	Position int   `json:"position"`
+	X        int   `json:"x"`
+	Y        int   `json:"y"`
+	OldColor uint8 `json:"oldColor"`
+	NewColor uint8 `json:"newColor"`
+}
+
+type CanvasDiff struct {
+	From    int64          `json:"from"`
+	To      int64          `json:"to"`
+	Crop    [4]int         `json:"crop"`
+	Changes []*PixelChange `json:"changes"`
+}
+
+type nftRegion struct {
+	Position int       `json:"position"`
+	Width    int       `json:"width"`
+	Height   int       `json:"height"`
+	MintedAt time.Time `json:"mintedAt"`
+}
+
+// Changes between the canvas at from and the canvas at to ( or now if to is not set ), both rebuilt from the history
+// tokenId restricts the diff to an NFT's region of the main canvas, from defaulting to the NFT's mint
+// ex: /canvas-diff?worldId=13&from=1700000000&to=1700003600&format=png&scale=4
+func getCanvasDiff(w http.ResponseWriter, r *http.Request) {
+	source, err := render.SourceFromQuery(r.URL.Query().Get("worldId"), r.URL.Query().Get("round"))
+	if err != nil {
+		routeutils.WriteErrorJson(w, http.StatusNotFound, "World not found")
+		return
+	}
+
+	from, to, err := parseTimeWindow(r)
+	if err != nil {
+		routeutils.WriteErrorJson(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	crop, opts, err := parseRenderOptions(r, source)
+	if err != nil {
+		routeutils.WriteErrorJson(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	tokenId := r.URL.Query().Get("tokenId")
+	if tokenId != "" {
+		if source.IsWorld {
+			routeutils.WriteErrorJson(w, http.StatusBadRequest, "tokenId is only supported on the main canvas")
+			return
+		}
+		nft, err := core.PostgresQueryOne[nftRegion]("SELECT position, width, height, minted_at FROM nfts WHERE token_id = $1", tokenId)
+		if err != nil {
+			routeutils.WriteErrorJson(w, http.StatusNotFound, "NFT not found")
+			return
+		}
+		nftX := nft.Position % source.Width
+		nftY := nft.Position / source.Width
+		crop = crop.Intersect(image.Rect(nftX, nftY, nftX+nft.Width, nftY+nft.Height))
+		if crop.Empty() {
+			routeutils.WriteErrorJson(w, http.StatusBadRequest, "crop is outside of the NFT")
+			return
+		}
+		opts.Origin = crop.Min
+		if from == 0 {
+			from = nft.MintedAt.Unix()
+		}
+	}
+
+	if from == 0 {
+		routeutils.WriteErrorJson(w, http.StatusBadRequest, "Missing from")
+		return
+	}
+	if to == 0 {
+		// Both frames are rebuilt from the history, the live canvas may hold placements not indexed yet
+		to = time.Now().Unix()
+	}
+	if from >= to {
+		routeutils.WriteErrorJson(w, http.StatusBadRequest, "from must be before to")
+		return
+	}
+
+	oldFrame, err := source.FrameAt(from)
+	if err != nil {
+		routeutils.WriteErrorJson(w, http.StatusInternalServerError, "Failed to rebuild canvas at from")
+		return
+	}
+	newFrame, err := source.FrameAt(to)
+	if err != nil {
+		routeutils.WriteErrorJson(w, http.StatusInternalServerError, "Failed to rebuild canvas at to")
+		return
+	}
+	oldFrame = oldFrame.Crop(crop)
+	newFrame = newFrame.Crop(crop)
+
+	diff := CanvasDiff{
+		From:    from,
+		To:      to,
+		Crop:    [4]int{crop.Min.X, crop.Min.Y, crop.Dx(), crop.Dy()},
+		Changes: make([]*PixelChange, 0),
+	}
+	changed := make([]bool, len(newFrame.Pixels))
+	for idx := range newFrame.Pixels {
+		if oldFrame.Pixels[idx] == newFrame.Pixels[idx] {
+			continue
+		}
+		changed[idx] = true
+		x := crop.Min.X + idx%newFrame.Width
+		y := crop.Min.Y + idx/newFrame.Width
+		diff.Changes = append(diff.Changes, &PixelChange{
+			Position: y*source.Width + x,
+			X:        x,
+			Y:        y,
+			OldColor: oldFrame.Pixels[idx],
+			NewColor: newFrame.Pixels[idx],
+		})
+	}
+
+	format := r.URL.Query().Get("format")
+	if format == "" || format == "json" {
+		diffJson, err := json.Marshal(diff)
+		if err != nil {
+			routeutils.WriteErrorJson(w, http.StatusInternalServerError, "Failed to marshal canvas diff")
+			return
+		}
+		routeutils.WriteDataJson(w, string(diffJson))
+		return
+	} else if format != "png" {
+		routeutils.WriteErrorJson(w, http.StatusBadRequest, "Invalid format, expected json or png")
+		return
+	}
+
+	palette, err := source.Palette()
+	if err != nil {
+		routeutils.WriteErrorJson(w, http.StatusInternalServerError, "Failed to retrieve color palette")
+		return
+	}
+
+	// Changed pixels in their new color, everything else dimmed
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, render.Highlighted(newFrame, palette, changed, opts)); err != nil {
+		routeutils.WriteErrorJson(w, http.StatusInternalServerError, "Failed to render canvas diff")
+		return
+	}
+
+	routeutils.WriteImage(w, "image/png", buf.Bytes())
+}
@@ -0,0 +1,96 @@
+package routes
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/keep-starknet-strange/art-peace/backend/core"
+	routeutils "github.com/keep-starknet-strange/art-peace/backend/routes/utils"
+)
+
+func InitEventsRoutes() {
+	routeutils.Get("/events", eventsEndpoint)
+}
+
+// Topics from the query, each parameter can be repeated
+//
+//	worldId=1
+//	viewport=worldId,x,y,width,height
+//	event=colorPixel,nftMinted
+//	address=0x123
+func parseEventTopics(r *http.Request) ([]core.WSTopic, error) {
+	query := r.URL.Query()
+	topics := make([]core.WSTopic, 0)
+
+	for _, worldIdStr := range query["worldId"] {
+		worldId, err := strconv.Atoi(worldIdStr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid worldId")
+		}
+		topics = append(topics, core.WSTopic{Type: "world", WorldId: worldId})
+	}
+
+	for _, viewport := range query["viewport"] {
+		parts := strings.Split(viewport, ",")
+		if len(parts) != 5 {
+			return nil, fmt.Errorf("viewport must be worldId,x,y,width,height")
+		}
+		values := make([]int, len(parts))
+		for idx, part := range parts {
+			value, err := strconv.Atoi(strings.TrimSpace(part))
+			if err != nil {
+				return nil, fmt.Errorf("viewport must be worldId,x,y,width,height")
+			}
+			values[idx] = value
+		}
+		topics = append(topics, core.WSTopic{
+			Type:    "viewport",
+			WorldId: values[0],
+			X:       values[1],
+			Y:       values[2],
+			Width:   values[3],
+			Height:  values[4],
+		})
+	}
+
+	for _, events := range query["event"] {
+		for _, event := range strings.Split(events, ",") {
+			topics = append(topics, core.WSTopic{Type: "event", Event: strings.TrimSpace(event)})
+		}
+	}
+
+	for _, address := range query["address"] {
+		topics = append(topics, core.WSTopic{Type: "address", Address: address})
+	}
+
+	return topics, nil
+}
+
+func eventsEndpoint(w http.ResponseWriter, r *http.Request) {
+	topics, err := parseEventTopics(r)
+	if err != nil {
+		routeutils.WriteErrorJson(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Browsers send Last-Event-ID when reconnecting, the query parameter allows resuming a new EventSource
+	lastEventId := r.Header.Get("Last-Event-ID")
+	if lastEventId == "" {
+		lastEventId = r.URL.Query().Get("lastEventId")
+	}
+	lastSeq, err := strconv.ParseUint(lastEventId, 10, 64)
+	if err != nil {
+		lastSeq = 0
+	}
+
+	client, err := core.ArtPeaceBackend.WSHub.RegisterStream(w, r, topics, lastSeq)
+	if err != nil {
+		routeutils.WriteErrorJson(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	routeutils.SetupAccessHeaders(w)
+	client.ServeStream(r)
+}
@@ -0,0 +1,108 @@
+package routes
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"net/http"
+	"strconv"
+
+	"github.com/keep-starknet-strange/art-peace/backend/render"
+	routeutils "github.com/keep-starknet-strange/art-peace/backend/routes/utils"
+)
+
+func InitExportRoutes() {
+	routeutils.Get("/export-canvas", exportCanvas, renderRateLimit)
+	routeutils.Get("/worlds/{worldId}/export", exportCanvas, renderRateLimit)
+}
+
+// Shared by the routes rendering canvases on request
+var renderRateLimit = routeutils.RateLimit(routeutils.RateLimitRender)
+
+var exportContentTypes = map[string]string{
+	"png": "image/png",
+	"gif": "image/gif",
+	"svg": "image/svg+xml",
+}
+
+// Parse the shared render query params : scale, crop, grid & labels
+func parseRenderOptions(r *http.Request, source *render.Source) (image.Rectangle, render.Options, error) {
+	opts := render.Options{Scale: 1}
+
+	scaleStr := r.URL.Query().Get("scale")
+	if scaleStr != "" {
+		scale, err := strconv.Atoi(scaleStr)
+		if err != nil || scale < 1 || scale > render.MaxScale {
+			return image.Rectangle{}, opts, fmt.Errorf("scale must be between 1 and %d", render.MaxScale)
+		}
+		opts.Scale = scale
+	}
+	opts.Grid = r.URL.Query().Get("grid") == "true"
+	opts.Labels = r.URL.Query().Get("labels") == "true"
+
+	crop, err := render.ParseCrop(r.URL.Query().Get("crop"), source.Width, source.Height)
+	if err != nil {
+		return image.Rectangle{}, opts, err
+	}
+	opts.Origin = crop.Min
+	if err := render.CheckOutputSize(crop.Dx(), crop.Dy(), opts.Scale); err != nil {
+		return image.Rectangle{}, opts, err
+	}
+
+	return crop, opts, nil
+}
+
+// Export a canvas as an image
+// ex: /export-canvas?worldId=13&format=png&scale=4&crop=0,0,64,64&grid=true&labels=true
+func exportCanvas(w http.ResponseWriter, r *http.Request) {
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = "png"
+	}
+	contentType, ok := exportContentTypes[format]
+	if !ok {
+		routeutils.WriteErrorJson(w, http.StatusBadRequest, "Invalid format, expected png, svg or gif")
+		return
+	}
+
+	source, err := render.SourceFromQuery(r.URL.Query().Get("worldId"), r.URL.Query().Get("round"))
+	if err != nil {
+		routeutils.WriteErrorJson(w, http.StatusNotFound, "World not found")
+		return
+	}
+
+	crop, opts, err := parseRenderOptions(r, source)
+	if err != nil {
+		routeutils.WriteErrorJson(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	frame, err := source.Load()
+	if err != nil {
+		routeutils.WriteErrorJson(w, http.StatusInternalServerError, "Failed to get canvas")
+		return
+	}
+	frame = frame.Crop(crop)
+
+	palette, err := source.Palette()
+	if err != nil {
+		routeutils.WriteErrorJson(w, http.StatusInternalServerError, "Failed to retrieve color palette")
+		return
+	}
+
+	var buf bytes.Buffer
+	switch format {
+	case "png":
+		err = render.EncodePNG(&buf, frame, palette, opts)
+	case "gif":
+		err = render.EncodeGIF(&buf, frame, palette, opts)
+	case "svg":
+		err = render.EncodeSVG(&buf, frame, palette, opts)
+	}
+	if err != nil {
+		routeutils.WriteErrorJson(w, http.StatusInternalServerError, "Failed to render canvas")
+		return
+	}
+
+	routeutils.WriteImage(w, contentType, buf.Bytes())
+}
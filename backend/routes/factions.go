@@ -9,25 +9,26 @@ import (
 	"os/exec"
 	"strconv"
 
+	"github.com/keep-starknet-strange/art-peace/backend/auth"
 	"github.com/keep-starknet-strange/art-peace/backend/core"
 	routeutils "github.com/keep-starknet-strange/art-peace/backend/routes/utils"
 )
 
 func InitFactionRoutes() {
-	http.HandleFunc("/init-factions", initFactions)
-	http.HandleFunc("/upload-faction-icon", uploadFactionIcon)
-	http.HandleFunc("/get-my-factions", getMyFactions)
-	http.HandleFunc("/get-factions", getFactions)
-	http.HandleFunc("/get-my-chain-factions", getMyChainFactions)
-	http.HandleFunc("/get-chain-factions", getChainFactions)
-	http.HandleFunc("/get-chain-faction-members", getChainFactionMembers)
-	http.HandleFunc("/get-faction-members", getFactionMembers)
+	routeutils.Post("/init-factions", initFactions, routeutils.RequirePermission(auth.PermissionSetup))
+	routeutils.Post("/upload-faction-icon", uploadFactionIcon, routeutils.RequirePermission(auth.PermissionSetup), routeutils.RateLimit(routeutils.RateLimitUploads))
+	routeutils.Get("/get-my-factions", getMyFactions)
+	routeutils.Get("/get-factions", getFactions)
+	routeutils.Get("/get-my-chain-factions", getMyChainFactions)
+	routeutils.Get("/get-chain-factions", getChainFactions)
+	routeutils.Get("/get-chain-faction-members", getChainFactionMembers)
+	routeutils.Get("/get-faction-members", getFactionMembers)
 	// Create a static file server for the nft images
-	http.Handle("/faction-images/", http.StripPrefix("/faction-images/", http.FileServer(http.Dir("./factions"))))
+	routeutils.Handle(http.MethodGet, "/faction-images/", http.StripPrefix("/faction-images/", http.FileServer(http.Dir("./factions"))))
 	if !core.ArtPeaceBackend.BackendConfig.Production {
-		http.HandleFunc("/join-chain-faction-devnet", joinChainFactionDevnet)
-		http.HandleFunc("/join-faction-devnet", joinFactionDevnet)
-		http.HandleFunc("/leave-faction-devnet", leaveFactionDevnet)
+		routeutils.Post("/join-chain-faction-devnet", joinChainFactionDevnet, routeutils.NonProduction)
+		routeutils.Post("/join-faction-devnet", joinFactionDevnet, routeutils.NonProduction)
+		routeutils.Post("/leave-faction-devnet", leaveFactionDevnet, routeutils.NonProduction)
 	}
 }
 
@@ -87,12 +88,12 @@ type FactionMemberData struct {
 	TotalAllocation int    `json:"totalAllocation"`
 }
 
-func initFactions(w http.ResponseWriter, r *http.Request) {
-	// Only allow admin to initialize colors
-	if routeutils.AdminMiddleware(w, r) {
-		return
-	}
+// Members are listed by address
+func nextFactionMember(last FactionMemberData) interface{} {
+	return addressCursor{Address: last.UserAddress}
+}
 
+func initFactions(w http.ResponseWriter, r *http.Request) {
 	// TODO: check if factions already exist
 	factionJson, err := routeutils.ReadJsonBody[FactionsConfig](r)
 	if err != nil {
@@ -113,11 +114,6 @@ func initFactions(w http.ResponseWriter, r *http.Request) {
 }
 
 func uploadFactionIcon(w http.ResponseWriter, r *http.Request) {
-	// Only allow admin to initialize colors
-	if routeutils.AdminMiddleware(w, r) {
-		return
-	}
-
 	err := r.ParseMultipartForm(10 << 20) // 10 MB
 	if err != nil {
 		routeutils.WriteErrorJson(w, http.StatusBadRequest, "Failed to parse multipart form")
@@ -188,18 +184,12 @@ func getFactions(w http.ResponseWriter, r *http.Request) {
 	if address == "" {
 		address = "0"
 	}
-	pageLength, err := strconv.Atoi(r.URL.Query().Get("pageLength"))
-	if err != nil || pageLength <= 0 {
-		pageLength = 10
-	}
-	if pageLength > 50 {
-		pageLength = 50
-	}
-	page, err := strconv.Atoi(r.URL.Query().Get("page"))
-	if err != nil || page <= 0 {
-		page = 1
+	pagination := routeutils.ParsePaginationWithDefault(r, 10)
+	after := firstOldestCursor()
+	if err := pagination.ParseCursor(&after); err != nil {
+		writeInvalidCursor(w)
+		return
 	}
-	offset := (page - 1) * pageLength
 
 	query := `
     SELECT f.faction_id, name, leader, COALESCE((SELECT COUNT(*) FROM factionmembersinfo fm WHERE f.faction_id = fm.faction_id), 0) as members,
@@ -207,16 +197,19 @@ func getFactions(w http.ResponseWriter, r *http.Request) {
     COALESCE(icon, '') as icon, COALESCE(telegram, '') as telegram, COALESCE(twitter, '') as twitter, COALESCE(github, '') as github, COALESCE(site, '') as site
     FROM factions f
     LEFT JOIN FactionLinks fl ON f.faction_id = fl.faction_id
+    WHERE f.faction_id > $4
     ORDER BY f.faction_id
     LIMIT $2 OFFSET $3
   `
 
-	factions, err := core.PostgresQueryJson[FactionData](query, address, pageLength, offset)
+	factions, err := core.PostgresQueryJson[FactionData](query, address, pagination.Limit(), pagination.Offset(), after.Id)
 	if err != nil {
 		routeutils.WriteErrorJson(w, http.StatusInternalServerError, "Failed to retrieve factions")
 		return
 	}
-	routeutils.WriteDataJson(w, string(factions))
+	routeutils.WritePageJson(w, factions, pagination, func(last FactionData) interface{} {
+		return oldestCursor{Id: last.FactionId}
+	})
 }
 
 func getMyChainFactions(w http.ResponseWriter, r *http.Request) {
@@ -274,19 +267,12 @@ func getChainFactionMembers(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	pageLength, err := strconv.Atoi(r.URL.Query().Get("pageLength"))
-	if err != nil || pageLength <= 0 {
-		pageLength = 10
-	}
-	if pageLength > 50 {
-		pageLength = 50
-	}
-
-	page, err := strconv.Atoi(r.URL.Query().Get("page"))
-	if err != nil || page <= 0 {
-		page = 1
+	pagination := routeutils.ParsePaginationWithDefault(r, 10)
+	var after addressCursor
+	if err := pagination.ParseCursor(&after); err != nil {
+		writeInvalidCursor(w)
+		return
 	}
-	offset := (page - 1) * pageLength
 
 	query := `
     SELECT 
@@ -295,18 +281,19 @@ func getChainFactionMembers(w http.ResponseWriter, r *http.Request) {
     2 AS total_allocation
     FROM ChainFactionMembersInfo CFMI
     LEFT JOIN Users U ON CFMI.user_address = U.address
-    WHERE CFMI.faction_id = $1
+    WHERE CFMI.faction_id = $1 AND CFMI.user_address > $4
+    ORDER BY CFMI.user_address
     LIMIT $2 OFFSET $3;
   `
 
-	members, err := core.PostgresQueryJson[FactionMemberData](query, factionID, pageLength, offset)
+	members, err := core.PostgresQueryJson[FactionMemberData](query, factionID, pagination.Limit(), pagination.Offset(), after.Address)
 
 	if err != nil {
 		routeutils.WriteErrorJson(w, http.StatusInternalServerError, "Failed to retrieve factions")
 		return
 	}
 
-	routeutils.WriteDataJson(w, string(members))
+	routeutils.WritePageJson(w, members, pagination, nextFactionMember)
 }
 
 func getFactionMembers(w http.ResponseWriter, r *http.Request) {
@@ -316,19 +303,12 @@ func getFactionMembers(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	pageLength, err := strconv.Atoi(r.URL.Query().Get("pageLength"))
-	if err != nil || pageLength <= 0 {
-		pageLength = 10
-	}
-	if pageLength > 50 {
-		pageLength = 50
-	}
-
-	page, err := strconv.Atoi(r.URL.Query().Get("page"))
-	if err != nil || page <= 0 {
-		page = 1
+	pagination := routeutils.ParsePaginationWithDefault(r, 10)
+	var after addressCursor
+	if err := pagination.ParseCursor(&after); err != nil {
+		writeInvalidCursor(w)
+		return
 	}
-	offset := (page - 1) * pageLength
 
 	query := `
 	SELECT 
@@ -338,26 +318,22 @@ func getFactionMembers(w http.ResponseWriter, r *http.Request) {
 	FROM FactionMembersInfo FMI
 	LEFT JOIN Users U ON FMI.user_address = U.address
   LEFT JOIN Factions F ON F.faction_id = FMI.faction_id
-	WHERE FMI.faction_id = $1
+	WHERE FMI.faction_id = $1 AND FMI.user_address > $4
+	ORDER BY FMI.user_address
 	LIMIT $2 OFFSET $3;
 	`
 
-	members, err := core.PostgresQueryJson[FactionMemberData](query, factionID, pageLength, offset)
+	members, err := core.PostgresQueryJson[FactionMemberData](query, factionID, pagination.Limit(), pagination.Offset(), after.Address)
 
 	if err != nil {
 		routeutils.WriteErrorJson(w, http.StatusInternalServerError, "Failed to retrieve factions")
 		return
 	}
 
-	routeutils.WriteDataJson(w, string(members))
+	routeutils.WritePageJson(w, members, pagination, nextFactionMember)
 }
 
 func joinChainFactionDevnet(w http.ResponseWriter, r *http.Request) {
-	// Disable this in production
-	if routeutils.NonProductionMiddleware(w, r) {
-		return
-	}
-
 	jsonBody, err := routeutils.ReadJsonBody[map[string]string](r)
 	if err != nil {
 		routeutils.WriteErrorJson(w, http.StatusBadRequest, "Invalid JSON request body")
@@ -390,11 +366,6 @@ func joinChainFactionDevnet(w http.ResponseWriter, r *http.Request) {
 }
 
 func joinFactionDevnet(w http.ResponseWriter, r *http.Request) {
-	// Disable this in production
-	if routeutils.NonProductionMiddleware(w, r) {
-		return
-	}
-
 	jsonBody, err := routeutils.ReadJsonBody[map[string]string](r)
 	if err != nil {
 		routeutils.WriteErrorJson(w, http.StatusBadRequest, "Invalid JSON request body")
@@ -421,11 +392,6 @@ func joinFactionDevnet(w http.ResponseWriter, r *http.Request) {
 }
 
 func leaveFactionDevnet(w http.ResponseWriter, r *http.Request) {
-	// Disable this in production
-	if routeutils.NonProductionMiddleware(w, r) {
-		return
-	}
-
 	shellCmd := core.ArtPeaceBackend.BackendConfig.Scripts.LeaveFactionDevnet
 	contract := os.Getenv("ART_PEACE_CONTRACT_ADDRESS")
 
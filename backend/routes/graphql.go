@@ -0,0 +1,67 @@
+package routes
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/keep-starknet-strange/art-peace/backend/graph"
+	routeutils "github.com/keep-starknet-strange/art-peace/backend/routes/utils"
+)
+
+// Read only GraphQL schema of users, worlds, stencils, NFTs & factions, for pages needing many of the routes at once
+//
+//	{ user(address: "0x...") { username pixelCount nfts(first: 10) { tokenId likes } factions { faction { name } } } }
+func InitGraphQLRoutes() {
+	routeutils.Get("/graphql", getGraphQL, routeutils.RateLimit(routeutils.RateLimitHeavyReads))
+	routeutils.Post("/graphql", postGraphQL, routeutils.RateLimit(routeutils.RateLimitHeavyReads))
+}
+
+type GraphQLRequest struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName,omitempty"`
+	Variables     map[string]interface{} `json:"variables,omitempty"`
+}
+
+// Query in the query, operationName & variables parameters, variables as JSON
+func getGraphQL(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	var variables map[string]interface{}
+	if rawVariables := query.Get("variables"); rawVariables != "" {
+		if err := json.Unmarshal([]byte(rawVariables), &variables); err != nil {
+			routeutils.WriteErrorJson(w, http.StatusBadRequest, "Invalid variables")
+			return
+		}
+	}
+	writeGraphQL(w, r, GraphQLRequest{
+		Query:         query.Get("query"),
+		OperationName: query.Get("operationName"),
+		Variables:     variables,
+	})
+}
+
+func postGraphQL(w http.ResponseWriter, r *http.Request) {
+	jsonBody, err := routeutils.ReadJsonBody[GraphQLRequest](r)
+	if err != nil {
+		routeutils.WriteErrorJson(w, http.StatusBadRequest, "Invalid JSON request body")
+		return
+	}
+	writeGraphQL(w, r, *jsonBody)
+}
+
+// Results keep the GraphQL shape, with errors next to the data that could be resolved
+func writeGraphQL(w http.ResponseWriter, r *http.Request, request GraphQLRequest) {
+	if request.Query == "" {
+		routeutils.WriteErrorJson(w, http.StatusBadRequest, "Missing query")
+		return
+	}
+
+	result := graph.Execute(r.Context(), request.Query, request.OperationName, request.Variables)
+	resultJson, err := json.Marshal(result)
+	if err != nil {
+		routeutils.WriteErrorJson(w, http.StatusInternalServerError, "Failed to marshal result")
+		return
+	}
+	routeutils.SetupHeaders(w)
+	w.WriteHeader(http.StatusOK)
+	w.Write(resultJson)
+}
@@ -0,0 +1,152 @@
+package routes
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"image/png"
+	"net/http"
+	"strconv"
+
+	"github.com/keep-starknet-strange/art-peace/backend/render"
+	routeutils "github.com/keep-starknet-strange/art-peace/backend/routes/utils"
+)
+
+func InitHeatmapRoutes() {
+	routeutils.Get("/heatmap", getHeatmap, renderRateLimit)
+	routeutils.Get("/heatmap-user", getUserHeatmap, renderRateLimit)
+	routeutils.Get("/heatmap-faction", getFactionHeatmap, renderRateLimit)
+}
+
+// Parse the from & to unix timestamp query params, 0 meaning unbounded
+func parseTimeWindow(r *http.Request) (int64, int64, error) {
+	var from, to int64
+	var err error
+
+	fromStr := r.URL.Query().Get("from")
+	if fromStr != "" {
+		from, err = strconv.ParseInt(fromStr, 10, 64)
+		if err != nil || from < 0 {
+			return 0, 0, fmt.Errorf("invalid from")
+		}
+	}
+
+	toStr := r.URL.Query().Get("to")
+	if toStr != "" {
+		to, err = strconv.ParseInt(toStr, 10, 64)
+		if err != nil || to < 0 {
+			return 0, 0, fmt.Errorf("invalid to")
+		}
+	}
+
+	if from != 0 && to != 0 && from >= to {
+		return 0, 0, fmt.Errorf("from must be before to")
+	}
+
+	return from, to, nil
+}
+
+// ex: /heatmap?worldId=13&from=1700000000&to=1700003600&cell=8&format=png&scale=2
+func getHeatmap(w http.ResponseWriter, r *http.Request) {
+	writeHeatmap(w, r, render.HistoryFilter{})
+}
+
+func getUserHeatmap(w http.ResponseWriter, r *http.Request) {
+	address := r.URL.Query().Get("address")
+	if address == "" {
+		routeutils.WriteErrorJson(w, http.StatusBadRequest, "Missing address")
+		return
+	}
+
+	writeHeatmap(w, r, render.HistoryFilter{Addresses: []string{address}})
+}
+
+func getFactionHeatmap(w http.ResponseWriter, r *http.Request) {
+	filter := render.HistoryFilter{}
+	if factionIdStr := r.URL.Query().Get("factionId"); factionIdStr != "" {
+		factionId, err := strconv.Atoi(factionIdStr)
+		if err != nil {
+			routeutils.WriteErrorJson(w, http.StatusBadRequest, "Invalid factionId")
+			return
+		}
+		filter.FactionId = &factionId
+	} else if chainFactionIdStr := r.URL.Query().Get("chainFactionId"); chainFactionIdStr != "" {
+		chainFactionId, err := strconv.Atoi(chainFactionIdStr)
+		if err != nil {
+			routeutils.WriteErrorJson(w, http.StatusBadRequest, "Invalid chainFactionId")
+			return
+		}
+		filter.ChainFactionId = &chainFactionId
+	} else {
+		routeutils.WriteErrorJson(w, http.StatusBadRequest, "Missing factionId or chainFactionId")
+		return
+	}
+
+	writeHeatmap(w, r, filter)
+}
+
+func writeHeatmap(w http.ResponseWriter, r *http.Request, filter render.HistoryFilter) {
+	source, err := render.SourceFromQuery(r.URL.Query().Get("worldId"), r.URL.Query().Get("round"))
+	if err != nil {
+		routeutils.WriteErrorJson(w, http.StatusNotFound, "World not found")
+		return
+	}
+
+	filter.From, filter.To, err = parseTimeWindow(r)
+	if err != nil {
+		routeutils.WriteErrorJson(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	cellSize := 1
+	if cellStr := r.URL.Query().Get("cell"); cellStr != "" {
+		cellSize, err = strconv.Atoi(cellStr)
+		if err != nil || cellSize < 1 || cellSize > 256 {
+			routeutils.WriteErrorJson(w, http.StatusBadRequest, "Invalid cell size")
+			return
+		}
+	}
+
+	positionCounts, err := source.PositionCounts(filter)
+	if err != nil {
+		routeutils.WriteErrorJson(w, http.StatusInternalServerError, "Failed to retrieve pixel placements")
+		return
+	}
+	heatmap := render.NewHeatmap(source.Width, source.Height, cellSize, positionCounts)
+
+	format := r.URL.Query().Get("format")
+	if format == "" || format == "json" {
+		heatmapJson, err := json.Marshal(heatmap)
+		if err != nil {
+			routeutils.WriteErrorJson(w, http.StatusInternalServerError, "Failed to marshal heatmap")
+			return
+		}
+		routeutils.WriteDataJson(w, string(heatmapJson))
+		return
+	} else if format != "png" {
+		routeutils.WriteErrorJson(w, http.StatusBadRequest, "Invalid format, expected json or png")
+		return
+	}
+
+	scale := 1
+	if scaleStr := r.URL.Query().Get("scale"); scaleStr != "" {
+		scale, err = strconv.Atoi(scaleStr)
+		if err != nil || scale < 1 || scale > render.MaxScale {
+			routeutils.WriteErrorJson(w, http.StatusBadRequest, fmt.Sprintf("scale must be between 1 and %d", render.MaxScale))
+			return
+		}
+	}
+	// The overlay is RGBA, 4 bytes per pixel
+	if err := render.CheckRGBAOutputSize(heatmap.Width, heatmap.Height, scale); err != nil {
+		routeutils.WriteErrorJson(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, heatmap.Overlay(scale)); err != nil {
+		routeutils.WriteErrorJson(w, http.StatusInternalServerError, "Failed to render heatmap")
+		return
+	}
+
+	routeutils.WriteImage(w, "image/png", buf.Bytes())
+}
@@ -0,0 +1,164 @@
+package routes
+
+import (
+	"bytes"
+	"encoding/json"
+	"image"
+	"image/png"
+	"net/http"
+	"strings"
+
+	"github.com/keep-starknet-strange/art-peace/backend/render"
+	routeutils "github.com/keep-starknet-strange/art-peace/backend/routes/utils"
+)
+
+func InitHighlightRoutes() {
+	routeutils.Get("/highlight-user", getUserHighlight, renderRateLimit)
+	// Animated highlights queue a job, so they aren't created by GETs from crawlers & prefetchers
+	routeutils.Post("/highlight-user", createUserHighlightTimelapse, renderRateLimit)
+}
+
+type UserHighlight struct {
+	Address   string `json:"address"`
+	Surviving int    `json:"surviving"`
+	Positions []int  `json:"positions"`
+}
+
+// Address, canvas & render options of a highlight, nil source if an error was written
+func parseUserHighlight(w http.ResponseWriter, r *http.Request) (string, *render.Source, image.Rectangle, render.Options) {
+	address := strings.TrimPrefix(r.URL.Query().Get("address"), "0x")
+	if address == "" {
+		routeutils.WriteErrorJson(w, http.StatusBadRequest, "Missing address")
+		return "", nil, image.Rectangle{}, render.Options{}
+	}
+
+	source, err := render.SourceFromQuery(r.URL.Query().Get("worldId"), r.URL.Query().Get("round"))
+	if err != nil {
+		routeutils.WriteErrorJson(w, http.StatusNotFound, "World not found")
+		return "", nil, image.Rectangle{}, render.Options{}
+	}
+
+	crop, opts, err := parseRenderOptions(r, source)
+	if err != nil {
+		routeutils.WriteErrorJson(w, http.StatusBadRequest, err.Error())
+		return "", nil, image.Rectangle{}, render.Options{}
+	}
+	return address, source, crop, opts
+}
+
+// Render the canvas with the address's surviving pixels highlighted & everything else dimmed
+// ex: /highlight-user?address=0x...&worldId=13&format=png&scale=4
+func getUserHighlight(w http.ResponseWriter, r *http.Request) {
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = "png"
+	}
+	if _, ok := render.TimelapseContentTypes[format]; ok {
+		routeutils.WriteErrorJson(w, http.StatusMethodNotAllowed, "Animated highlights are created with POST /highlight-user")
+		return
+	} else if format != "png" && format != "json" {
+		routeutils.WriteErrorJson(w, http.StatusBadRequest, "Invalid format, expected png or json")
+		return
+	}
+
+	address, source, crop, opts := parseUserHighlight(w, r)
+	if source == nil {
+		return
+	}
+
+	surviving, count, err := source.SurvivingPixels(address)
+	if err != nil {
+		routeutils.WriteErrorJson(w, http.StatusInternalServerError, "Failed to retrieve user pixels")
+		return
+	}
+
+	if format == "json" {
+		highlight := UserHighlight{
+			Address:   address,
+			Surviving: count,
+			Positions: make([]int, 0, count),
+		}
+		for pos, survived := range surviving {
+			if survived {
+				highlight.Positions = append(highlight.Positions, pos)
+			}
+		}
+		highlightJson, err := json.Marshal(highlight)
+		if err != nil {
+			routeutils.WriteErrorJson(w, http.StatusInternalServerError, "Failed to marshal user highlight")
+			return
+		}
+		routeutils.WriteDataJson(w, string(highlightJson))
+		return
+	}
+
+	frame, err := source.Load()
+	if err != nil {
+		routeutils.WriteErrorJson(w, http.StatusInternalServerError, "Failed to get canvas")
+		return
+	}
+	palette, err := source.Palette()
+	if err != nil {
+		routeutils.WriteErrorJson(w, http.StatusInternalServerError, "Failed to retrieve color palette")
+		return
+	}
+
+	highlight := make([]bool, crop.Dx()*crop.Dy())
+	for y := crop.Min.Y; y < crop.Max.Y; y++ {
+		for x := crop.Min.X; x < crop.Max.X; x++ {
+			highlight[(y-crop.Min.Y)*crop.Dx()+x-crop.Min.X] = surviving[y*source.Width+x]
+		}
+	}
+
+	var buf bytes.Buffer
+	encoder := png.Encoder{CompressionLevel: png.BestCompression}
+	if err := encoder.Encode(&buf, render.Highlighted(frame.Crop(crop), palette, highlight, opts)); err != nil {
+		routeutils.WriteErrorJson(w, http.StatusInternalServerError, "Failed to render user highlight")
+		return
+	}
+
+	routeutils.WriteImage(w, "image/png", buf.Bytes())
+}
+
+// Queue a timelapse job animating the address's placements over the dimmed canvas, polled like /create-timelapse jobs
+// ex: curl -X POST "http://localhost:8080/highlight-user?address=0x...&worldId=13&format=gif&scale=4"
+func createUserHighlightTimelapse(w http.ResponseWriter, r *http.Request) {
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = "gif"
+	}
+	if _, ok := render.TimelapseContentTypes[format]; !ok {
+		routeutils.WriteErrorJson(w, http.StatusBadRequest, "Invalid format, expected gif or apng")
+		return
+	}
+
+	address, source, crop, renderOpts := parseUserHighlight(w, r)
+	if source == nil {
+		return
+	}
+
+	opts := render.TimelapseOptions{Crop: crop, Render: renderOpts}
+	var err error
+	opts.From, opts.To, err = parseTimeWindow(r)
+	if err != nil {
+		routeutils.WriteErrorJson(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := parseFrameStep(r, &opts); err != nil {
+		routeutils.WriteErrorJson(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if !checkTimelapseSize(w, source, render.HistoryFilter{From: opts.From, To: opts.To, Addresses: []string{address}}, opts) {
+		return
+	}
+
+	jobId, err := queueTimelapseJob(format, opts, func(opts render.TimelapseOptions) (*render.Timelapse, error) {
+		return source.BuildContributionTimelapse(address, opts)
+	})
+	if err != nil {
+		writeTimelapseJobError(w, err)
+		return
+	}
+	writeTimelapseJobId(w, jobId)
+}
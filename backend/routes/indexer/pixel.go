@@ -71,6 +71,7 @@ func processPixelPlacedEvent(event IndexerEvent) {
 	var message = map[string]string{
 		"position":    strconv.FormatInt(position, 10),
 		"color":       strconv.FormatInt(color, 10),
+		"address":     address,
 		"messageType": "colorPixel",
 	}
 	routeutils.SendMessageToWSS(message)
@@ -0,0 +1,74 @@
+package indexer
+
+import (
+	"context"
+	"time"
+
+	"github.com/keep-starknet-strange/art-peace/backend/core"
+)
+
+type activeProtectedRegion struct {
+	Key  int    `json:"key"`
+	Mode string `json:"mode"`
+}
+
+type protectedRegionViolation struct {
+	Key      int  `json:"key"`
+	Restored bool `json:"restored"`
+}
+
+// Protected region containing the world position at the time of the placement, nil if there is none
+// Restore regions take priority over flag only regions
+func findProtectedRegion(worldId int64, pos int64, placedAt time.Time) *activeProtectedRegion {
+	width, err := core.PostgresQueryOne[int]("SELECT width FROM Worlds WHERE world_id = $1", worldId)
+	if err != nil {
+		PrintIndexerError("findProtectedRegion", "Failed to query world width", worldId, pos, err)
+		return nil
+	}
+	x := int(pos) % *width
+	y := int(pos) / *width
+
+	region, err := core.PostgresQueryOne[activeProtectedRegion]("SELECT key, mode FROM ProtectedRegions WHERE world_id = $1 AND start_time <= TO_TIMESTAMP($4) AND end_time > TO_TIMESTAMP($4) AND x_start <= $2 AND x_end >= $2 AND y_start <= $3 AND y_end >= $3 ORDER BY mode = 'restore' DESC, key ASC LIMIT 1", worldId, x, y, placedAt.Unix())
+	if err != nil {
+		return nil
+	}
+	return region
+}
+
+// Color the position had before the placement being restored
+func protectedRestoreColor(worldId int64, pos int64) int64 {
+	previousColor, err := core.PostgresQueryOne[int64]("SELECT color FROM WorldsPixels WHERE world_id = $1 AND position = $2 ORDER BY time DESC LIMIT 1", worldId, pos)
+	if err != nil {
+		return 0
+	}
+	return *previousColor
+}
+
+func insertProtectedRegionViolation(region *activeProtectedRegion, worldId int64, placedBy string, pos int64, colorVal int64, restoredColor int64) {
+	restored := region.Mode == "restore"
+	var restoredColorVal interface{}
+	if restored {
+		restoredColorVal = restoredColor
+	}
+
+	_, err := core.ArtPeaceBackend.Databases.Postgres.Exec(context.Background(), "INSERT INTO ProtectedRegionViolations (region_key, world_id, address, position, color, restored, restored_color) VALUES ($1, $2, $3, $4, $5, $6, $7)", region.Key, worldId, placedBy, pos, colorVal, restored, restoredColorVal)
+	if err != nil {
+		PrintIndexerError("insertProtectedRegionViolation", "Failed to insert into ProtectedRegionViolations", region.Key, worldId, placedBy, pos, colorVal, err)
+	}
+}
+
+// Remove the violation recorded for the placement being reverted
+// Returns true if the placement was restored, meaning it never reached WorldsPixels
+func revertProtectedRegionViolation(worldId int64, placedBy string, pos int64) bool {
+	// Violations are recorded after the placement, so only ones newer than the address's last kept pixel belong to it
+	violation, err := core.PostgresQueryOne[protectedRegionViolation]("SELECT key, restored FROM ProtectedRegionViolations WHERE world_id = $1 AND address = $2 AND position = $3 AND time >= COALESCE((SELECT MAX(time) FROM WorldsPixels WHERE world_id = $1 AND address = $2 AND position = $3), TO_TIMESTAMP(0)) ORDER BY time DESC LIMIT 1", worldId, placedBy, pos)
+	if err != nil {
+		return false
+	}
+
+	_, err = core.ArtPeaceBackend.Databases.Postgres.Exec(context.Background(), "DELETE FROM ProtectedRegionViolations WHERE key = $1", violation.Key)
+	if err != nil {
+		PrintIndexerError("revertProtectedRegionViolation", "Failed to delete from ProtectedRegionViolations", worldId, placedBy, pos, err)
+	}
+	return violation.Restored
+}
@@ -4,12 +4,13 @@ import (
 	"fmt"
 	"net/http"
 	"sync"
+	"time"
 
 	routeutils "github.com/keep-starknet-strange/art-peace/backend/routes/utils"
 )
 
 func InitIndexerRoutes() {
-	http.HandleFunc("/consume-indexer-msg", consumeIndexerMsg)
+	routeutils.Post("/consume-indexer-msg", consumeIndexerMsg)
 }
 
 type IndexerCursor struct {
@@ -23,6 +24,12 @@ type IndexerEvent struct {
 		Keys        []string `json:"keys"`
 		Data        []string `json:"data"`
 	} `json:"event"`
+	// Timestamp of the event's block, set when the message is received
+	Timestamp time.Time `json:"-"`
+}
+
+type IndexerBlockHeader struct {
+	Timestamp time.Time `json:"timestamp"`
 }
 
 type IndexerMessage struct {
@@ -31,8 +38,9 @@ type IndexerMessage struct {
 		EndCursor IndexerCursor `json:"end_cursor"`
 		Finality  string        `json:"finality"`
 		Batch     []struct {
-			Status string         `json:"status"`
-			Events []IndexerEvent `json:"events"`
+			Status string             `json:"status"`
+			Header IndexerBlockHeader `json:"header"`
+			Events []IndexerEvent     `json:"events"`
 		} `json:"batch"`
 	} `json:"data"`
 }
@@ -262,6 +270,7 @@ func consumeIndexerMsg(w http.ResponseWriter, r *http.Request) {
 		fmt.Println("No events in batch")
 		return
 	}
+	stampEvents(message)
 
 	if message.Data.Finality == DATA_STATUS_FINALIZED {
 		// TODO: Track diffs with accepted messages? / check if accepted message processed
@@ -285,6 +294,21 @@ func consumeIndexerMsg(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// Give events their block's timestamp, or the time they were received for indexers not sending headers
+func stampEvents(message *IndexerMessage) {
+	received := time.Now()
+	for batchIdx := range message.Data.Batch {
+		batch := &message.Data.Batch[batchIdx]
+		timestamp := batch.Header.Timestamp
+		if timestamp.IsZero() {
+			timestamp = received
+		}
+		for eventIdx := range batch.Events {
+			batch.Events[eventIdx].Timestamp = timestamp
+		}
+	}
+}
+
 func ProcessMessageEvents(message IndexerMessage) {
 	for _, event := range message.Data.Batch[0].Events {
 		eventKey := event.Event.Keys[0]
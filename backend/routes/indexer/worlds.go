@@ -531,10 +531,21 @@ func processCanvasPixelPlacedEvent(event IndexerEvent) {
 		return
 	}
 
-	_, err = core.ArtPeaceBackend.Databases.Postgres.Exec(context.Background(), "INSERT INTO WorldsPixels (world_id, address, position, color) VALUES ($1, $2, $3, $4)", canvasId, placedBy, pos, colorVal)
-	if err != nil {
-		PrintIndexerError("processCanvasPixelPlacedEvent", "Failed to insert into WorldsPixels", canvasIdHex, placedBy, posHex, colorHex, err)
-		return
+	region := findProtectedRegion(canvasId, pos, event.Timestamp)
+	if region != nil && region.Mode == "restore" {
+		// Keep the protected color instead of applying the placement
+		restoredColor := protectedRestoreColor(canvasId, pos)
+		insertProtectedRegionViolation(region, canvasId, placedBy, pos, colorVal, restoredColor)
+		colorVal = restoredColor
+	} else {
+		_, err = core.ArtPeaceBackend.Databases.Postgres.Exec(context.Background(), "INSERT INTO WorldsPixels (world_id, address, position, color) VALUES ($1, $2, $3, $4)", canvasId, placedBy, pos, colorVal)
+		if err != nil {
+			PrintIndexerError("processCanvasPixelPlacedEvent", "Failed to insert into WorldsPixels", canvasIdHex, placedBy, posHex, colorHex, err)
+			return
+		}
+		if region != nil {
+			insertProtectedRegionViolation(region, canvasId, placedBy, pos, colorVal, 0)
+		}
 	}
 
 	go func() {
@@ -554,6 +565,7 @@ func processCanvasPixelPlacedEvent(event IndexerEvent) {
 			"worldId":     strconv.Itoa(int(canvasId)),
 			"position":    strconv.Itoa(int(pos)),
 			"color":       strconv.Itoa(int(colorVal)),
+			"address":     placedBy,
 			"messageType": "colorWorldPixel",
 		}
 		routeutils.SendMessageToWSS(message)
@@ -687,6 +699,11 @@ func revertCanvasPixelPlacedEvent(event IndexerEvent) {
 		return
 	}
 
+	if revertProtectedRegionViolation(worldId, placedBy, pos) {
+		// Restored placements were never stored
+		return
+	}
+
 	_, err = core.ArtPeaceBackend.Databases.Postgres.Exec(context.Background(), "DELETE FROM WorldsPixels WHERE world_id = $1 AND address = $2 AND position = $3 ORDER BY time DESC limit 1", worldId, placedBy, pos)
 	if err != nil {
 		PrintIndexerError("revertPixelPlacedEvent", "Failed to delete from WorldsPixels", worldIdHex, placedBy, posHex, err)
@@ -8,49 +8,47 @@ import (
 	"os"
 	"os/exec"
 	"strconv"
+	"time"
 
+	"github.com/keep-starknet-strange/art-peace/backend/auth"
 	"github.com/keep-starknet-strange/art-peace/backend/core"
 	routeutils "github.com/keep-starknet-strange/art-peace/backend/routes/utils"
 )
 
 func InitNFTRoutes() {
-	http.HandleFunc("/get-canvas-nft-address", getCanvasNFTAddress)
-	http.HandleFunc("/set-canvas-nft-address", setCanvasNFTAddress)
-	http.HandleFunc("/get-nft", getNFT)
-	http.HandleFunc("/get-nfts", getNFTs)
-	http.HandleFunc("/get-new-nfts", getNewNFTs)
-	http.HandleFunc("/get-my-nfts", getMyNFTs)
-	http.HandleFunc("/get-nft-likes", getNftLikeCount)
-	http.HandleFunc("/get-nft-pixel-data", getNftPixelData)
+	routeutils.Get("/get-canvas-nft-address", getCanvasNFTAddress)
+	routeutils.Post("/set-canvas-nft-address", setCanvasNFTAddress, routeutils.RequirePermission(auth.PermissionSetup))
+	routeutils.Get("/get-nft", getNFT)
+	routeutils.Get("/nfts/{tokenId}", getNFT)
+	routeutils.Get("/get-nfts", getNFTs)
+	routeutils.Get("/get-new-nfts", getNewNFTs)
+	routeutils.Get("/get-my-nfts", getMyNFTs)
+	routeutils.Get("/get-nft-likes", getNftLikeCount)
+	routeutils.Get("/get-nft-pixel-data", getNftPixelData)
 	// http.HandleFunc("/like-nft", LikeNFT)
 	// http.HandleFunc("/unlike-nft", UnLikeNFT)
-	http.HandleFunc("/get-liked-nfts", getLikedNFTs)
-	http.HandleFunc("/get-top-nfts", getTopNFTs)
-	http.HandleFunc("/get-hot-nfts", getHotNFTs)
+	routeutils.Get("/get-liked-nfts", getLikedNFTs)
+	routeutils.Get("/get-top-nfts", getTopNFTs, routeutils.RateLimit(routeutils.RateLimitHeavyReads))
+	routeutils.Get("/get-hot-nfts", getHotNFTs, routeutils.RateLimit(routeutils.RateLimitHeavyReads))
 	if !core.ArtPeaceBackend.BackendConfig.Production {
-		http.HandleFunc("/mint-nft-devnet", mintNFTDevnet)
-		http.HandleFunc("/like-nft-devnet", likeNFTDevnet)
-		http.HandleFunc("/unlike-nft-devnet", unlikeNFTDevnet)
+		routeutils.Post("/mint-nft-devnet", mintNFTDevnet, routeutils.NonProduction)
+		routeutils.Post("/like-nft-devnet", likeNFTDevnet, routeutils.NonProduction)
+		routeutils.Post("/unlike-nft-devnet", unlikeNFTDevnet, routeutils.NonProduction)
 	}
 	// Create a static file server for the nft images
 	// TODO: Versioning here?
 }
 
 func InitNFTStaticRoutes() {
-	http.Handle("/nft/", http.StripPrefix("/nft/", http.FileServer(http.Dir("./nfts"))))
+	routeutils.Handle(http.MethodGet, "/nft/", http.StripPrefix("/nft/", http.FileServer(http.Dir("./nfts"))))
 }
 
 func getCanvasNFTAddress(w http.ResponseWriter, r *http.Request) {
 	contractAddress := os.Getenv("CANVAS_NFT_CONTRACT_ADDRESS")
-	routeutils.WriteDataJson(w, "\""+contractAddress+"\"")
+	routeutils.WriteStringDataJson(w, contractAddress)
 }
 
 func setCanvasNFTAddress(w http.ResponseWriter, r *http.Request) {
-	// Only allow admin to set contract address
-	if routeutils.AdminMiddleware(w, r) {
-		return
-	}
-
 	data, err := io.ReadAll(r.Body)
 	if err != nil {
 		routeutils.WriteErrorJson(w, http.StatusBadRequest, "Failed to read reques  t body")
@@ -71,8 +69,16 @@ type NFTData struct {
 	DayIndex    int    `json:"dayIndex"`
 	Minter      string `json:"minter"`
 	Owner       string `json:"owner"`
-	Likes       int    `json:"likes"`
-	Liked       bool   `json:"liked"`
+	// Block time of the mint
+	MintedAt time.Time `json:"mintedAt"`
+	Likes    int       `json:"likes"`
+	Liked    bool      `json:"liked"`
+	// Score of the hot ranking
+	Hotness int `json:"hotness,omitempty"`
+}
+
+func nextNewestNFT(last NFTData) interface{} {
+	return newestCursor{Id: last.TokenID}
 }
 
 type NFTLikesRequest struct {
@@ -82,18 +88,12 @@ type NFTLikesRequest struct {
 
 func getMyNFTs(w http.ResponseWriter, r *http.Request) {
 	address := r.URL.Query().Get("address")
-	pageLength, err := strconv.Atoi(r.URL.Query().Get("pageLength"))
-	if err != nil || pageLength <= 0 {
-		pageLength = 25
-	}
-	if pageLength > 50 {
-		pageLength = 50
-	}
-	page, err := strconv.Atoi(r.URL.Query().Get("page"))
-	if err != nil || page <= 0 {
-		page = 1
+	pagination := routeutils.ParsePagination(r)
+	after := firstNewestCursor()
+	if err := pagination.ParseCursor(&after); err != nil {
+		writeInvalidCursor(w)
+		return
 	}
-	offset := (page - 1) * pageLength
 
 	query := `
         SELECT 
@@ -113,14 +113,15 @@ func getMyNFTs(w http.ResponseWriter, r *http.Request) {
         ) nftlikes ON nfts.token_id = nftlikes.nftKey
         WHERE 
             nfts.owner = $1
+            AND nfts.token_id < $4
         ORDER BY nfts.token_id DESC
         LIMIT $2 OFFSET $3`
-	nfts, err := core.PostgresQueryJson[NFTData](query, address, pageLength, offset)
+	nfts, err := core.PostgresQueryJson[NFTData](query, address, pagination.Limit(), pagination.Offset(), after.Id)
 	if err != nil {
 		routeutils.WriteErrorJson(w, http.StatusInternalServerError, "Failed to retrieve NFTs")
 		return
 	}
-	routeutils.WriteDataJson(w, string(nfts))
+	routeutils.WritePageJson(w, nfts, pagination, nextNewestNFT)
 }
 
 func getNFT(w http.ResponseWriter, r *http.Request) {
@@ -141,18 +142,12 @@ func getNFTs(w http.ResponseWriter, r *http.Request) {
 	if address == "" {
 		address = "0"
 	}
-	pageLength, err := strconv.Atoi(r.URL.Query().Get("pageLength"))
-	if err != nil || pageLength <= 0 {
-		pageLength = 25
-	}
-	if pageLength > 50 {
-		pageLength = 50
-	}
-	page, err := strconv.Atoi(r.URL.Query().Get("page"))
-	if err != nil || page <= 0 {
-		page = 1
+	pagination := routeutils.ParsePagination(r)
+	after := firstNewestCursor()
+	if err := pagination.ParseCursor(&after); err != nil {
+		writeInvalidCursor(w)
+		return
 	}
-	offset := (page - 1) * pageLength
 
 	query := `
         SELECT 
@@ -170,14 +165,15 @@ func getNFTs(w http.ResponseWriter, r *http.Request) {
             GROUP BY 
                 nftKey
         ) nftlikes ON nfts.token_id = nftlikes.nftKey
+        WHERE nfts.token_id < $4
         ORDER BY nfts.token_id DESC
         LIMIT $2 OFFSET $3`
-	nfts, err := core.PostgresQueryJson[NFTData](query, address, pageLength, offset)
+	nfts, err := core.PostgresQueryJson[NFTData](query, address, pagination.Limit(), pagination.Offset(), after.Id)
 	if err != nil {
 		routeutils.WriteErrorJson(w, http.StatusInternalServerError, "Failed to retrieve NFTs")
 		return
 	}
-	routeutils.WriteDataJson(w, string(nfts))
+	routeutils.WritePageJson(w, nfts, pagination, nextNewestNFT)
 }
 
 func getNewNFTs(w http.ResponseWriter, r *http.Request) {
@@ -185,18 +181,12 @@ func getNewNFTs(w http.ResponseWriter, r *http.Request) {
 	if address == "" {
 		address = "0"
 	}
-	pageLength, err := strconv.Atoi(r.URL.Query().Get("pageLength"))
-	if err != nil || pageLength <= 0 {
-		pageLength = 25
-	}
-	if pageLength > 50 {
-		pageLength = 50
-	}
-	page, err := strconv.Atoi(r.URL.Query().Get("page"))
-	if err != nil || page <= 0 {
-		page = 1
+	pagination := routeutils.ParsePagination(r)
+	after := firstNewestCursor()
+	if err := pagination.ParseCursor(&after); err != nil {
+		writeInvalidCursor(w)
+		return
 	}
-	offset := (page - 1) * pageLength
 
 	query := `
         SELECT 
@@ -214,14 +204,15 @@ func getNewNFTs(w http.ResponseWriter, r *http.Request) {
             GROUP BY 
                 nftKey
         ) nftlikes ON nfts.token_id = nftlikes.nftKey
+        WHERE nfts.token_id < $4
         ORDER BY nfts.token_id DESC
         LIMIT $2 OFFSET $3`
-	nfts, err := core.PostgresQueryJson[NFTData](query, address, pageLength, offset)
+	nfts, err := core.PostgresQueryJson[NFTData](query, address, pagination.Limit(), pagination.Offset(), after.Id)
 	if err != nil {
 		routeutils.WriteErrorJson(w, http.StatusInternalServerError, "Failed to retrieve NFTs")
 		return
 	}
-	routeutils.WriteDataJson(w, string(nfts))
+	routeutils.WritePageJson(w, nfts, pagination, nextNewestNFT)
 }
 
 func getNftPixelData(w http.ResponseWriter, r *http.Request) {
@@ -284,11 +275,6 @@ func getNftPixelData(w http.ResponseWriter, r *http.Request) {
 }
 
 func mintNFTDevnet(w http.ResponseWriter, r *http.Request) {
-	// Disable this in production
-	if routeutils.NonProductionMiddleware(w, r) {
-		return
-	}
-
 	// TODO: map[string]int instead of map[string]string
 	jsonBody, err := routeutils.ReadJsonBody[map[string]string](r)
 	if err != nil {
@@ -394,52 +380,53 @@ func getTopNFTs(w http.ResponseWriter, r *http.Request) {
 	if address == "" {
 		address = "0"
 	}
-	pageLength, err := strconv.Atoi(r.URL.Query().Get("pageLength"))
-	if err != nil || pageLength <= 0 {
-		pageLength = 25
-	}
-	if pageLength > 50 {
-		pageLength = 50
+	pagination := routeutils.ParsePagination(r)
+	after := firstRankingCursor()
+	if err := pagination.ParseCursor(&after); err != nil {
+		writeInvalidCursor(w)
+		return
 	}
-	page, err := strconv.Atoi(r.URL.Query().Get("page"))
-	if err != nil || page <= 0 {
-		page = 1
+	if err := after.snapshot("nftlikes"); err != nil {
+		routeutils.WriteErrorJson(w, http.StatusInternalServerError, "Failed to retrieve NFTs")
+		return
 	}
-	offset := (page - 1) * pageLength
 
+	// Likes after the snapshot are left out of the ranking
 	query := `
-        SELECT 
-            nfts.*, 
-            COALESCE(like_count, 0) AS likes,
-            COALESCE((SELECT true FROM nftlikes WHERE liker = $1 AND nftlikes.nftkey = nfts.token_id), false) as liked
-        FROM 
-            nfts
-        LEFT JOIN (
+        SELECT * FROM (
             SELECT 
-                nftKey, 
-                COUNT(*) AS like_count
+                nfts.*, 
+                COALESCE(like_count, 0) AS likes,
+                COALESCE((SELECT true FROM nftlikes WHERE liker = $1 AND nftlikes.nftkey = nfts.token_id), false) as liked
             FROM 
-                nftlikes
-            GROUP BY 
-                nftKey
-        ) nftlikes ON nfts.token_id = nftlikes.nftKey
+                nfts
+            LEFT JOIN (
+                SELECT 
+                    nftKey, 
+                    COUNT(*) AS like_count
+                FROM 
+                    nftlikes
+                WHERE 
+                    key <= $4
+                GROUP BY 
+                    nftKey
+            ) nftlikes ON nfts.token_id = nftlikes.nftKey
+        ) ranked
+        WHERE (likes, token_id) < ($5, $6)
         ORDER BY 
-            likes DESC
+            likes DESC, token_id DESC
         LIMIT $2 OFFSET $3`
-	nfts, err := core.PostgresQueryJson[NFTData](query, address, pageLength, offset)
+	nfts, err := core.PostgresQueryJson[NFTData](query, address, pagination.Limit(), pagination.Offset(), after.AsOf, after.Score, after.Id)
 	if err != nil {
 		routeutils.WriteErrorJson(w, http.StatusInternalServerError, "Failed to retrieve NFTs")
 		return
 	}
-	routeutils.WriteDataJson(w, string(nfts))
+	routeutils.WritePageJson(w, nfts, pagination, func(last NFTData) interface{} {
+		return rankingCursor{AsOf: after.AsOf, Score: last.Likes, Id: last.TokenID}
+	})
 }
 
 func likeNFTDevnet(w http.ResponseWriter, r *http.Request) {
-	// Disable this in production
-	if routeutils.NonProductionMiddleware(w, r) {
-		return
-	}
-
 	jsonBody, err := routeutils.ReadJsonBody[map[string]string](r)
 	if err != nil {
 		routeutils.WriteErrorJson(w, http.StatusBadRequest, "Failed to read request body")
@@ -463,11 +450,6 @@ func likeNFTDevnet(w http.ResponseWriter, r *http.Request) {
 }
 
 func unlikeNFTDevnet(w http.ResponseWriter, r *http.Request) {
-	// Disable this in production
-	if routeutils.NonProductionMiddleware(w, r) {
-		return
-	}
-
 	jsonBody, err := routeutils.ReadJsonBody[map[string]string](r)
 	if err != nil {
 		routeutils.WriteErrorJson(w, http.StatusBadRequest, "Failed to read request body")
@@ -502,52 +484,58 @@ func getHotNFTs(w http.ResponseWriter, r *http.Request) {
 	if hotLimit > 500 {
 		hotLimit = 500
 	}
-	pageLength, err := strconv.Atoi(r.URL.Query().Get("pageLength"))
-	if err != nil || pageLength <= 0 {
-		pageLength = 25
-	}
-	if pageLength > 50 {
-		pageLength = 50
+	pagination := routeutils.ParsePagination(r)
+	after := firstRankingCursor()
+	if err := pagination.ParseCursor(&after); err != nil {
+		writeInvalidCursor(w)
+		return
 	}
-	page, err := strconv.Atoi(r.URL.Query().Get("page"))
-	if err != nil || page <= 0 {
-		page = 1
+	if err := after.snapshot("nftlikes"); err != nil {
+		routeutils.WriteErrorJson(w, http.StatusInternalServerError, "Failed to retrieve Hot NFTs")
+		return
 	}
-	offset := (page - 1) * pageLength
 
+	// Hotness counts the last likes up to the snapshot
 	query := `
-      SELECT
-          nfts.*,
-          COALESCE(like_count, 0) AS likes,
-          COALESCE((
-              SELECT true FROM nftlikes
-              WHERE liker = $1 AND nftlikes.nftkey = nfts.token_id),
-          false) as liked
-      FROM
-          nfts
-      LEFT JOIN (
-          SELECT
-              nftKey,
-              COUNT(*) AS like_count FROM nftlikes GROUP BY nftKey
-      ) nftlikes ON nfts.token_id = nftlikes.nftKey
-      LEFT JOIN (
+      SELECT * FROM (
           SELECT
-              latestlikes.nftKey,
-              COUNT(*) as rank
-          FROM (
-              SELECT * FROM nftlikes
-              ORDER BY key DESC LIMIT $2
-          ) latestlikes
-          GROUP BY nftkey
-      ) rank ON nfts.token_id = rank.nftkey
-      ORDER BY COALESCE(rank, 0) DESC
+              nfts.*,
+              COALESCE(like_count, 0) AS likes,
+              COALESCE((
+                  SELECT true FROM nftlikes
+                  WHERE liker = $1 AND nftlikes.nftkey = nfts.token_id),
+              false) as liked,
+              COALESCE(rank.hotness, 0) AS hotness
+          FROM
+              nfts
+          LEFT JOIN (
+              SELECT
+                  nftKey,
+                  COUNT(*) AS like_count FROM nftlikes GROUP BY nftKey
+          ) nftlikes ON nfts.token_id = nftlikes.nftKey
+          LEFT JOIN (
+              SELECT
+                  latestlikes.nftKey,
+                  COUNT(*) as hotness
+              FROM (
+                  SELECT * FROM nftlikes
+                  WHERE key <= $5
+                  ORDER BY key DESC LIMIT $2
+              ) latestlikes
+              GROUP BY nftkey
+          ) rank ON nfts.token_id = rank.nftkey
+      ) ranked
+      WHERE (hotness, token_id) < ($6, $7)
+      ORDER BY hotness DESC, token_id DESC
       LIMIT $3 OFFSET $4;`
-	nfts, err := core.PostgresQueryJson[NFTData](query, address, hotLimit, pageLength, offset)
+	nfts, err := core.PostgresQueryJson[NFTData](query, address, hotLimit, pagination.Limit(), pagination.Offset(), after.AsOf, after.Score, after.Id)
 	if err != nil {
 		routeutils.WriteErrorJson(w, http.StatusInternalServerError, "Failed to retrieve Hot NFTs")
 		return
 	}
-	routeutils.WriteDataJson(w, string(nfts))
+	routeutils.WritePageJson(w, nfts, pagination, func(last NFTData) interface{} {
+		return rankingCursor{AsOf: after.AsOf, Score: last.Hotness, Id: last.TokenID}
+	})
 }
 
 func getLikedNFTs(w http.ResponseWriter, r *http.Request) {
@@ -557,19 +545,12 @@ func getLikedNFTs(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	pageLength, err := strconv.Atoi(r.URL.Query().Get("pageLength"))
-	if err != nil || pageLength <= 0 {
-		pageLength = 25
-	}
-	if pageLength > 50 {
-		pageLength = 50
-	}
-
-	page, err := strconv.Atoi(r.URL.Query().Get("page"))
-	if err != nil || page <= 0 {
-		page = 1
+	pagination := routeutils.ParsePagination(r)
+	after := firstNewestCursor()
+	if err := pagination.ParseCursor(&after); err != nil {
+		writeInvalidCursor(w)
+		return
 	}
-	offset := (page - 1) * pageLength
 
 	query := `
         SELECT 
@@ -593,13 +574,14 @@ func getLikedNFTs(w http.ResponseWriter, r *http.Request) {
                 FROM nftlikes 
                 WHERE liker = $1
             )
+            AND nfts.token_id < $4
         ORDER BY nfts.token_id DESC
         LIMIT $2 OFFSET $3`
 
-	nfts, err := core.PostgresQueryJson[NFTData](query, address, pageLength, offset)
+	nfts, err := core.PostgresQueryJson[NFTData](query, address, pagination.Limit(), pagination.Offset(), after.Id)
 	if err != nil {
 		routeutils.WriteErrorJson(w, http.StatusInternalServerError, "Failed to retrieve liked NFTs")
 		return
 	}
-	routeutils.WriteDataJson(w, string(nfts))
+	routeutils.WritePageJson(w, nfts, pagination, nextNewestNFT)
 }
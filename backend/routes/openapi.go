@@ -0,0 +1,44 @@
+package routes
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	routeutils "github.com/keep-starknet-strange/art-peace/backend/routes/utils"
+)
+
+// The document & the client package are generated from this package's handlers
+//go:generate go run ../cmd/openapi -dir ..
+
+//go:embed openapi.json
+var openAPIDocument []byte
+
+func InitOpenAPIRoutes() {
+	routeutils.Get("/openapi.json", getOpenAPIDocument)
+	checkOpenAPIDocument()
+}
+
+func getOpenAPIDocument(w http.ResponseWriter, r *http.Request) {
+	routeutils.SetupHeaders(w)
+	w.WriteHeader(http.StatusOK)
+	w.Write(openAPIDocument)
+}
+
+// Warn about registered routes the document is missing, it is out of date until regenerated
+func checkOpenAPIDocument() {
+	var document struct {
+		Paths map[string]map[string]json.RawMessage `json:"paths"`
+	}
+	if err := json.Unmarshal(openAPIDocument, &document); err != nil {
+		fmt.Println("Failed to read openapi.json", err)
+		return
+	}
+	for _, route := range routeutils.Routes() {
+		if _, ok := document.Paths[routeutils.OpenAPIPath(route.Path)][strings.ToLower(route.Method)]; !ok {
+			fmt.Println("Route missing from openapi.json, run go generate ./routes :", route.Method, route.Path)
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package routes
+
+import (
+	"bytes"
+	"encoding/json"
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/keep-starknet-strange/art-peace/backend/config"
+	"github.com/keep-starknet-strange/art-peace/backend/core"
+	routeutils "github.com/keep-starknet-strange/art-peace/backend/routes/utils"
+)
+
+// The embedded document is the one generated from the handlers
+func TestOpenAPIDocumentUpToDate(t *testing.T) {
+	if testing.Short() {
+		t.Skip("type checks the routes package")
+	}
+	cmd := exec.Command("go", "run", "./cmd/openapi", "-dir", ".", "-print")
+	cmd.Dir = ".."
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	generated, err := cmd.Output()
+	if err != nil {
+		t.Fatalf("generate document: %v\n%s%s", err, generated, stderr.String())
+	}
+	if !bytes.Equal(generated, openAPIDocument) {
+		t.Fatal("openapi.json is out of date, run go generate ./routes")
+	}
+}
+
+// Every route the package registers, in any mode, is in the document
+func TestOpenAPIDocumentHasRoutes(t *testing.T) {
+	core.ArtPeaceBackend = &core.Backend{BackendConfig: &config.BackendConfig{}}
+	InitRoutes()
+	InitWebsocketRoutes()
+	InitEventsRoutes()
+	InitNFTStaticRoutes()
+	InitWorldsStaticRoutes()
+
+	var document struct {
+		Paths map[string]map[string]json.RawMessage `json:"paths"`
+	}
+	if err := json.Unmarshal(openAPIDocument, &document); err != nil {
+		t.Fatal(err)
+	}
+	routes := routeutils.Routes()
+	if len(routes) == 0 {
+		t.Fatal("no routes registered")
+	}
+	for _, route := range routes {
+		path := routeutils.OpenAPIPath(route.Path)
+		if _, ok := document.Paths[path][strings.ToLower(route.Method)]; !ok {
+			t.Errorf("%s %s is missing from openapi.json as %s", route.Method, route.Path, path)
+		}
+	}
+}
@@ -8,18 +8,20 @@ import (
 	"os/exec"
 	"strconv"
 
+	"github.com/keep-starknet-strange/art-peace/backend/auth"
 	"github.com/keep-starknet-strange/art-peace/backend/core"
 	routeutils "github.com/keep-starknet-strange/art-peace/backend/routes/utils"
 )
 
 func InitPixelRoutes() {
-	http.HandleFunc("/get-pixel", getPixel)
-	http.HandleFunc("/get-pixel-info", getPixelInfo)
+	routeutils.Get("/get-pixel", getPixel)
+	routeutils.Get("/canvas/pixels/{position}", getPixel)
+	routeutils.Get("/get-pixel-info", getPixelInfo)
 	if !core.ArtPeaceBackend.BackendConfig.Production {
-		http.HandleFunc("/place-pixel-devnet", placePixelDevnet)
-		http.HandleFunc("/place-extra-pixels-devnet", placeExtraPixelsDevnet)
+		routeutils.Post("/place-pixel-devnet", placePixelDevnet, routeutils.NonProduction)
+		routeutils.Post("/place-extra-pixels-devnet", placeExtraPixelsDevnet, routeutils.NonProduction)
 	}
-	http.HandleFunc("/place-pixel-redis", placePixelRedis)
+	routeutils.Post("/place-pixel-redis", placePixelRedis, routeutils.RequirePermission(auth.PermissionSetup))
 }
 
 func getPixel(w http.ResponseWriter, r *http.Request) {
@@ -77,16 +79,11 @@ func getPixelInfo(w http.ResponseWriter, r *http.Request) {
 	if queryRes.Name == "" {
 		routeutils.WriteDataJson(w, "\"0x"+queryRes.Address+"\"")
 	} else {
-		routeutils.WriteDataJson(w, "\""+queryRes.Name+"\"")
+		routeutils.WriteStringDataJson(w, queryRes.Name)
 	}
 }
 
 func placePixelDevnet(w http.ResponseWriter, r *http.Request) {
-	// Disable this in production
-	if routeutils.NonProductionMiddleware(w, r) {
-		return
-	}
-
 	jsonBody, err := routeutils.ReadJsonBody[map[string]string](r)
 	if err != nil {
 		routeutils.WriteErrorJson(w, http.StatusBadRequest, "Invalid JSON request body")
@@ -147,11 +144,6 @@ type ExtraPixelJson struct {
 }
 
 func placeExtraPixelsDevnet(w http.ResponseWriter, r *http.Request) {
-	// Disable this in production
-	if routeutils.NonProductionMiddleware(w, r) {
-		return
-	}
-
 	jsonBody, err := routeutils.ReadJsonBody[ExtraPixelJson](r)
 	if err != nil {
 		routeutils.WriteErrorJson(w, http.StatusBadRequest, "Invalid JSON request body")
@@ -179,11 +171,6 @@ func placeExtraPixelsDevnet(w http.ResponseWriter, r *http.Request) {
 }
 
 func placePixelRedis(w http.ResponseWriter, r *http.Request) {
-	// Only allow admin to place pixels on redis
-	if routeutils.AdminMiddleware(w, r) {
-		return
-	}
-
 	jsonBody, err := routeutils.ReadJsonBody[map[string]uint](r)
 	if err != nil {
 		routeutils.WriteErrorJson(w, http.StatusBadRequest, "Invalid JSON request body")
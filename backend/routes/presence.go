@@ -0,0 +1,336 @@
+package routes
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/keep-starknet-strange/art-peace/backend/auth"
+	"github.com/keep-starknet-strange/art-peace/backend/core"
+	routeutils "github.com/keep-starknet-strange/art-peace/backend/routes/utils"
+)
+
+const (
+	// Clients are dropped from a world once they stop sending updates for this long
+	presenceTTL = 30 * time.Second
+	// Shortest time between two updates of the same client
+	presenceMinInterval       = 250 * time.Millisecond
+	presenceBroadcastInterval = 2 * time.Second
+	presenceMaxCursors        = 100
+	// Each websocket server refreshes its viewers in Redis every broadcast, those of a stopped server expire after this long
+	presenceReplicaTTL = 3 * presenceBroadcastInterval
+	// Set of the replica ids with viewers in Redis
+	presenceReplicasKey = "presence-replicas"
+)
+
+// Hash of a replica's worlds, field worldId holding its WorldPresence
+func presenceKey(replicaId string) string {
+	return "presence-" + replicaId
+}
+
+type presenceRect struct {
+	X      int `json:"x"`
+	Y      int `json:"y"`
+	Width  int `json:"width"`
+	Height int `json:"height"`
+}
+
+type presencePoint struct {
+	X int `json:"x"`
+	Y int `json:"y"`
+}
+
+type presenceCursor struct {
+	X       int    `json:"x"`
+	Y       int    `json:"y"`
+	Address string `json:"address,omitempty"`
+}
+
+type presenceEntry struct {
+	worldId  int
+	viewport *presenceRect
+	cursor   *presencePoint
+	// Address of the client's session, empty for anonymous clients
+	address  string
+	lastSeen time.Time
+}
+
+type WorldPresence struct {
+	WorldId int              `json:"worldId"`
+	Viewers int              `json:"viewers"`
+	Cursors []presenceCursor `json:"cursors"`
+}
+
+// Viewers of each world connected to this server, keyed by connection
+// Counts & cursors are merged with the other servers' through Redis
+type presenceTracker struct {
+	lock    sync.Mutex
+	clients map[*core.WSClient]*presenceEntry
+	// Last summary sent per world, so unchanged worlds aren't rebroadcast
+	sent map[int]string
+}
+
+var Presence = &presenceTracker{
+	clients: make(map[*core.WSClient]*presenceEntry),
+	sent:    make(map[int]string),
+}
+
+func (p *presenceTracker) update(client *core.WSClient, msg *wsClientMessage, address string) error {
+	if msg.WorldId < 0 {
+		return fmt.Errorf("invalid worldId")
+	}
+	if msg.Viewport != nil && (msg.Viewport.Width <= 0 || msg.Viewport.Height <= 0) {
+		return fmt.Errorf("invalid viewport")
+	}
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	now := time.Now()
+	entry, ok := p.clients[client]
+	if ok && now.Sub(entry.lastSeen) < presenceMinInterval {
+		return fmt.Errorf("too many presence updates")
+	}
+	if !ok || entry.worldId != msg.WorldId {
+		entry = &presenceEntry{worldId: msg.WorldId}
+		p.clients[client] = entry
+	}
+
+	entry.lastSeen = now
+	entry.address = address
+	// Plain presence messages only keep the client alive, viewport messages move it
+	if msg.MessageType == "viewport" {
+		entry.viewport = msg.Viewport
+		entry.cursor = msg.Cursor
+	}
+	return nil
+}
+
+func (p *presenceTracker) remove(client *core.WSClient) {
+	p.lock.Lock()
+	delete(p.clients, client)
+	p.lock.Unlock()
+}
+
+// Must be called with the lock held
+func (p *presenceTracker) summaries() map[int]*WorldPresence {
+	now := time.Now()
+	worlds := make(map[int]*WorldPresence)
+	for client, entry := range p.clients {
+		if now.Sub(entry.lastSeen) > presenceTTL {
+			delete(p.clients, client)
+			continue
+		}
+
+		world, ok := worlds[entry.worldId]
+		if !ok {
+			world = &WorldPresence{WorldId: entry.worldId, Cursors: make([]presenceCursor, 0)}
+			worlds[entry.worldId] = world
+		}
+		world.Viewers++
+		if entry.cursor != nil {
+			world.Cursors = append(world.Cursors, presenceCursor{
+				X:       entry.cursor.X,
+				Y:       entry.cursor.Y,
+				Address: entry.address,
+			})
+		}
+	}
+
+	sortCursors(worlds)
+	return worlds
+}
+
+func sortCursors(worlds map[int]*WorldPresence) {
+	for _, world := range worlds {
+		// Stable order so unchanged worlds produce the same summary
+		sort.Slice(world.Cursors, func(i, j int) bool {
+			if world.Cursors[i].Y != world.Cursors[j].Y {
+				return world.Cursors[i].Y < world.Cursors[j].Y
+			}
+			if world.Cursors[i].X != world.Cursors[j].X {
+				return world.Cursors[i].X < world.Cursors[j].X
+			}
+			return world.Cursors[i].Address < world.Cursors[j].Address
+		})
+		if len(world.Cursors) > presenceMaxCursors {
+			world.Cursors = world.Cursors[:presenceMaxCursors]
+		}
+	}
+}
+
+// Replace this server's viewers in Redis
+func (p *presenceTracker) publish(replicaId string) error {
+	p.lock.Lock()
+	worlds := p.summaries()
+	p.lock.Unlock()
+
+	fields := make(map[string]interface{}, len(worlds))
+	for worldId, world := range worlds {
+		worldJson, err := json.Marshal(world)
+		if err != nil {
+			return err
+		}
+		fields[strconv.Itoa(worldId)] = string(worldJson)
+	}
+
+	ctx := context.Background()
+	key := presenceKey(replicaId)
+	pipe := core.ArtPeaceBackend.Databases.Redis.TxPipeline()
+	pipe.Del(ctx, key)
+	if len(fields) > 0 {
+		pipe.HSet(ctx, key, fields)
+		pipe.Expire(ctx, key, presenceReplicaTTL)
+	}
+	pipe.SAdd(ctx, presenceReplicasKey, replicaId)
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// Viewers of every websocket server, servers without any or which stopped refreshing are skipped
+func mergedPresence() (map[int]*WorldPresence, error) {
+	ctx := context.Background()
+	redis := core.ArtPeaceBackend.Databases.Redis
+	replicas, err := redis.SMembers(ctx, presenceReplicasKey).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	worlds := make(map[int]*WorldPresence)
+	for _, replicaId := range replicas {
+		fields, err := redis.HGetAll(ctx, presenceKey(replicaId)).Result()
+		if err != nil {
+			return nil, err
+		}
+		if len(fields) == 0 {
+			// Live servers add themselves back on their next refresh
+			redis.SRem(ctx, presenceReplicasKey, replicaId)
+			continue
+		}
+		for _, worldJson := range fields {
+			var replicaWorld WorldPresence
+			if err := json.Unmarshal([]byte(worldJson), &replicaWorld); err != nil {
+				continue
+			}
+			world, ok := worlds[replicaWorld.WorldId]
+			if !ok {
+				world = &WorldPresence{WorldId: replicaWorld.WorldId, Cursors: make([]presenceCursor, 0)}
+				worlds[replicaWorld.WorldId] = world
+			}
+			world.Viewers += replicaWorld.Viewers
+			world.Cursors = append(world.Cursors, replicaWorld.Cursors...)
+		}
+	}
+	sortCursors(worlds)
+	return worlds, nil
+}
+
+// Summaries of the worlds whose viewers changed since the last broadcast
+func (p *presenceTracker) changes(worlds map[int]*WorldPresence) []map[string]string {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	messages := make([]map[string]string, 0)
+	for worldId, world := range worlds {
+		cursors, err := json.Marshal(world.Cursors)
+		if err != nil {
+			continue
+		}
+		message := map[string]string{
+			"messageType": "presence",
+			"worldId":     strconv.Itoa(worldId),
+			"viewers":     strconv.Itoa(world.Viewers),
+			"cursors":     string(cursors),
+		}
+		summary := message["viewers"] + message["cursors"]
+		if p.sent[worldId] == summary {
+			continue
+		}
+		p.sent[worldId] = summary
+		messages = append(messages, message)
+	}
+
+	// Worlds everyone left get a last summary with no viewers
+	for worldId := range p.sent {
+		if _, ok := worlds[worldId]; ok {
+			continue
+		}
+		delete(p.sent, worldId)
+		messages = append(messages, map[string]string{
+			"messageType": "presence",
+			"worldId":     strconv.Itoa(worldId),
+			"viewers":     "0",
+			"cursors":     "[]",
+		})
+	}
+	return messages
+}
+
+func StartPresenceServer() {
+	replicaId := core.ArtPeaceBackend.BackendConfig.WebSocket.ReplicaId
+	for {
+		time.Sleep(presenceBroadcastInterval)
+		if err := Presence.publish(replicaId); err != nil {
+			fmt.Println("Failed to publish presence:", err)
+			continue
+		}
+		worlds, err := mergedPresence()
+		if err != nil {
+			fmt.Println("Failed to read presence:", err)
+			continue
+		}
+		messages := Presence.changes(worlds)
+		if len(messages) > 0 {
+			core.ArtPeaceBackend.WSHub.BroadcastEphemeral(messages)
+		}
+	}
+}
+
+// Cursors are shown under the address of the client's session, checked on each update so logouts apply
+func handlePresenceMessage(client *core.WSClient, session string, msg *wsClientMessage) {
+	address := ""
+	if session != "" {
+		sessionAddress, err := auth.SessionAddress(session)
+		if err == nil {
+			address = sessionAddress
+		}
+	}
+	if err := Presence.update(client, msg, address); err != nil {
+		routeutils.WriteWebSocketMessage(client, map[string]string{
+			"messageType": "presenceError",
+			"error":       err.Error(),
+		})
+	}
+}
+
+func getWorldPresence(w http.ResponseWriter, r *http.Request) {
+	worldIdStr := r.URL.Query().Get("worldId")
+	if worldIdStr == "" {
+		worldIdStr = "0"
+	}
+	worldId, err := strconv.Atoi(worldIdStr)
+	if err != nil || worldId < 0 {
+		routeutils.WriteErrorJson(w, http.StatusBadRequest, "Invalid worldId")
+		return
+	}
+
+	worlds, err := mergedPresence()
+	if err != nil {
+		routeutils.WriteErrorJson(w, http.StatusInternalServerError, "Failed to retrieve world presence")
+		return
+	}
+	world, ok := worlds[worldId]
+	if !ok {
+		world = &WorldPresence{WorldId: worldId, Cursors: make([]presenceCursor, 0)}
+	}
+	presence, err := json.Marshal(world)
+	if err != nil {
+		routeutils.WriteErrorJson(w, http.StatusInternalServerError, "Failed to marshal world presence")
+		return
+	}
+	routeutils.WriteDataJson(w, string(presence))
+}
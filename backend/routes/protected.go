@@ -0,0 +1,267 @@
+package routes
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/keep-starknet-strange/art-peace/backend/auth"
+	"github.com/keep-starknet-strange/art-peace/backend/core"
+	"github.com/keep-starknet-strange/art-peace/backend/routes/response"
+	routeutils "github.com/keep-starknet-strange/art-peace/backend/routes/utils"
+)
+
+func InitProtectedRoutes() {
+	routeutils.Get("/get-protected-regions", getProtectedRegions)
+	routeutils.Get("/get-protected-region-violations", getProtectedRegionViolations)
+	routeutils.Get("/worlds/{worldId}/protected-regions", getProtectedRegions)
+	routeutils.Get("/worlds/{worldId}/protected-region-violations", getProtectedRegionViolations)
+	routeutils.Post("/add-protected-region", addProtectedRegion, routeutils.AuditApiKey)
+	routeutils.Post("/remove-protected-region", removeProtectedRegion, routeutils.AuditApiKey)
+}
+
+type ProtectedRegion struct {
+	Key       int       `json:"key"`
+	WorldId   int       `json:"worldId"`
+	Name      string    `json:"name"`
+	XStart    int       `json:"xStart"`
+	XEnd      int       `json:"xEnd"`
+	YStart    int       `json:"yStart"`
+	YEnd      int       `json:"yEnd"`
+	StartTime time.Time `json:"startTime"`
+	EndTime   time.Time `json:"endTime"`
+	Mode      string    `json:"mode"`
+	CreatedBy string    `json:"createdBy"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+type ProtectedRegionViolation struct {
+	Key           int       `json:"key"`
+	RegionKey     int       `json:"regionKey"`
+	WorldId       int       `json:"worldId"`
+	Address       string    `json:"address"`
+	Position      int       `json:"position"`
+	Color         int       `json:"color"`
+	Restored      bool      `json:"restored"`
+	RestoredColor *int      `json:"restoredColor"`
+	Time          time.Time `json:"time"`
+}
+
+type AddProtectedRegionRequest struct {
+	Address   string `json:"address"`
+	WorldId   int    `json:"worldId"`
+	Name      string `json:"name"`
+	XStart    int    `json:"xStart"`
+	XEnd      int    `json:"xEnd"`
+	YStart    int    `json:"yStart"`
+	YEnd      int    `json:"yEnd"`
+	StartTime int64  `json:"startTime"`
+	EndTime   int64  `json:"endTime"`
+	Mode      string `json:"mode"`
+}
+
+type RemoveProtectedRegionRequest struct {
+	Address string `json:"address"`
+	WorldId int    `json:"worldId"`
+	Key     int    `json:"key"`
+}
+
+// Admins can manage regions on any world, hosts only on their own
+// Return true if the request was stopped
+func hostOrAdminMiddleware(w http.ResponseWriter, r *http.Request, worldId int, address string) bool {
+	if core.ArtPeaceBackend.AdminMode {
+		return false
+	}
+	// Moderator keys manage every world, world-host keys only their own
+	if r.Header.Get(routeutils.ApiKeyHeader) != "" {
+		key := routeutils.RequestApiKey(r)
+		if key == nil {
+			routeutils.WriteErrorJson(w, http.StatusUnauthorized, "Invalid API key")
+			return true
+		}
+		if !key.AllowsWorld(auth.PermissionWorlds, worldId) {
+			routeutils.WriteErrorCodeJson(w, http.StatusForbidden, response.CodePermissionDenied, "API key can't manage this world")
+			return true
+		}
+		return false
+	}
+	if routeutils.AuthMiddleware(w, r) {
+		return true
+	}
+	// The address in the request has to be the one logged in, compared in the session's padded form
+	sessionAddress := routeutils.SessionAddress(r)
+	if address != "" {
+		parsed, err := auth.ParseFelt(address)
+		if err != nil {
+			routeutils.WriteErrorJson(w, http.StatusBadRequest, "Invalid address")
+			return true
+		}
+		if auth.FormatAddress(parsed) != sessionAddress {
+			routeutils.WriteErrorJson(w, http.StatusForbidden, "Address does not match the session")
+			return true
+		}
+	}
+
+	host, err := core.PostgresQueryOne[string]("SELECT host FROM worlds WHERE world_id = $1", worldId)
+	if err != nil {
+		routeutils.WriteErrorJson(w, http.StatusNotFound, "World not found")
+		return true
+	}
+	hostAddress, err := auth.ParseFelt(*host)
+	if err != nil || sessionAddress != auth.FormatAddress(hostAddress) {
+		routeutils.WriteErrorJson(w, http.StatusUnauthorized, "Admin or world host is required")
+		return true
+	}
+	return false
+}
+
+// ex: /get-protected-regions?worldId=13&active=true
+func getProtectedRegions(w http.ResponseWriter, r *http.Request) {
+	worldId, err := strconv.Atoi(r.URL.Query().Get("worldId"))
+	if err != nil {
+		routeutils.WriteErrorJson(w, http.StatusBadRequest, "Invalid worldId")
+		return
+	}
+
+	query := "SELECT * FROM ProtectedRegions WHERE world_id = $1"
+	if r.URL.Query().Get("active") == "true" {
+		query += " AND start_time <= NOW() AND end_time > NOW()"
+	}
+	query += " ORDER BY key ASC"
+
+	regions, err := core.PostgresQueryJson[ProtectedRegion](query, worldId)
+	if err != nil {
+		routeutils.WriteErrorJson(w, http.StatusInternalServerError, "Failed to retrieve protected regions")
+		return
+	}
+	routeutils.WriteDataJson(w, string(regions))
+}
+
+func getProtectedRegionViolations(w http.ResponseWriter, r *http.Request) {
+	worldId, err := strconv.Atoi(r.URL.Query().Get("worldId"))
+	if err != nil {
+		routeutils.WriteErrorJson(w, http.StatusBadRequest, "Invalid worldId")
+		return
+	}
+
+	pagination := routeutils.ParsePagination(r)
+	after := firstNewestCursor()
+	if err := pagination.ParseCursor(&after); err != nil {
+		writeInvalidCursor(w)
+		return
+	}
+
+	// Newest first, by key as violations are inserted in time order
+	violations, err := core.PostgresQueryJson[ProtectedRegionViolation]("SELECT * FROM ProtectedRegionViolations WHERE world_id = $1 AND key < $4 ORDER BY key DESC LIMIT $2 OFFSET $3", worldId, pagination.Limit(), pagination.Offset(), after.Id)
+	if err != nil {
+		routeutils.WriteErrorJson(w, http.StatusInternalServerError, "Failed to retrieve protected region violations")
+		return
+	}
+	routeutils.WritePageJson(w, violations, pagination, func(last ProtectedRegionViolation) interface{} {
+		return newestCursor{Id: last.Key}
+	})
+}
+
+func addProtectedRegion(w http.ResponseWriter, r *http.Request) {
+	jsonBody, err := routeutils.ReadJsonBody[AddProtectedRegionRequest](r)
+	if err != nil {
+		routeutils.WriteErrorJson(w, http.StatusBadRequest, "Invalid JSON request body")
+		return
+	}
+
+	if hostOrAdminMiddleware(w, r, jsonBody.WorldId, jsonBody.Address) {
+		return
+	}
+
+	if jsonBody.Name == "" {
+		routeutils.WriteErrorJson(w, http.StatusBadRequest, "Missing name")
+		return
+	}
+	if jsonBody.Mode != "flag" && jsonBody.Mode != "restore" {
+		routeutils.WriteErrorJson(w, http.StatusBadRequest, "Invalid mode, expected flag or restore")
+		return
+	}
+
+	world, err := core.PostgresQueryOne[WorldData]("SELECT * FROM worlds WHERE world_id = $1", jsonBody.WorldId)
+	if err != nil {
+		routeutils.WriteErrorJson(w, http.StatusNotFound, "World not found")
+		return
+	}
+
+	// Rectangle bounds are inclusive, like canvas clears
+	if jsonBody.XStart < 0 || jsonBody.YStart < 0 || jsonBody.XStart > jsonBody.XEnd || jsonBody.YStart > jsonBody.YEnd {
+		routeutils.WriteErrorJson(w, http.StatusBadRequest, "Invalid range")
+		return
+	}
+	if jsonBody.XEnd >= world.Width || jsonBody.YEnd >= world.Height {
+		routeutils.WriteErrorJson(w, http.StatusBadRequest, "Position out of range")
+		return
+	}
+
+	startTime := jsonBody.StartTime
+	if startTime == 0 {
+		startTime = time.Now().Unix()
+	}
+	if jsonBody.EndTime <= startTime {
+		routeutils.WriteErrorJson(w, http.StatusBadRequest, "endTime must be after startTime")
+		return
+	}
+
+	createdBy := strings.TrimPrefix(jsonBody.Address, "0x")
+	if parsed, err := auth.ParseFelt(jsonBody.Address); err == nil {
+		createdBy = auth.FormatAddress(parsed)
+	}
+	var key int
+	err = core.ArtPeaceBackend.Databases.Postgres.QueryRow(context.Background(), "INSERT INTO ProtectedRegions (world_id, name, x_start, x_end, y_start, y_end, start_time, end_time, mode, created_by) VALUES ($1, $2, $3, $4, $5, $6, TO_TIMESTAMP($7), TO_TIMESTAMP($8), $9, $10) RETURNING key", jsonBody.WorldId, jsonBody.Name, jsonBody.XStart, jsonBody.XEnd, jsonBody.YStart, jsonBody.YEnd, startTime, jsonBody.EndTime, jsonBody.Mode, createdBy).Scan(&key)
+	if err != nil {
+		routeutils.WriteErrorJson(w, http.StatusInternalServerError, "Failed to insert protected region")
+		return
+	}
+
+	sendProtectedRegionsChanged(jsonBody.WorldId)
+
+	keyJson, err := json.Marshal(map[string]int{"key": key})
+	if err != nil {
+		routeutils.WriteErrorJson(w, http.StatusInternalServerError, "Failed to marshal protected region key")
+		return
+	}
+	routeutils.WriteDataJson(w, string(keyJson))
+}
+
+func removeProtectedRegion(w http.ResponseWriter, r *http.Request) {
+	jsonBody, err := routeutils.ReadJsonBody[RemoveProtectedRegionRequest](r)
+	if err != nil {
+		routeutils.WriteErrorJson(w, http.StatusBadRequest, "Invalid JSON request body")
+		return
+	}
+
+	if hostOrAdminMiddleware(w, r, jsonBody.WorldId, jsonBody.Address) {
+		return
+	}
+
+	result, err := core.ArtPeaceBackend.Databases.Postgres.Exec(context.Background(), "DELETE FROM ProtectedRegions WHERE key = $1 AND world_id = $2", jsonBody.Key, jsonBody.WorldId)
+	if err != nil {
+		routeutils.WriteErrorJson(w, http.StatusInternalServerError, "Failed to remove protected region")
+		return
+	}
+	if result.RowsAffected() == 0 {
+		routeutils.WriteErrorJson(w, http.StatusNotFound, "Protected region not found")
+		return
+	}
+
+	sendProtectedRegionsChanged(jsonBody.WorldId)
+
+	routeutils.WriteResultJson(w, "Protected region removed")
+}
+
+// Let clients know to refetch the regions they are rendering
+func sendProtectedRegionsChanged(worldId int) {
+	var message = map[string]string{
+		"worldId":     strconv.Itoa(worldId),
+		"messageType": "protectedRegionsChanged",
+	}
+	go routeutils.SendMessageToWSS(message)
+}
@@ -10,6 +10,7 @@ import (
 	"strconv"
 	"time"
 
+	"github.com/keep-starknet-strange/art-peace/backend/auth"
 	"github.com/keep-starknet-strange/art-peace/backend/core"
 	"github.com/keep-starknet-strange/art-peace/backend/quests"
 	routeutils "github.com/keep-starknet-strange/art-peace/backend/routes/utils"
@@ -113,32 +114,27 @@ type QuestProgress struct {
 }
 
 func InitQuestsRoutes() {
-	http.HandleFunc("/init-quests", InitQuests)
-	http.HandleFunc("/get-daily-quests", GetDailyQuests)
-	http.HandleFunc("/get-main-quests", GetMainQuests)
-	http.HandleFunc("/get-main-user-quests", GetMainUserQuests)
-	http.HandleFunc("/get-todays-quests", getTodaysQuests)
-	http.HandleFunc("/get-todays-user-quests", getTodaysUserQuests)
-	http.HandleFunc("/get-completed-daily-quests", GetCompletedDailyQuests)
-	http.HandleFunc("/get-completed-main-quests", GetCompletedMainQuests)
-	http.HandleFunc("/get-user-quest-status", GetUserQuestStatus)
-	http.HandleFunc("/get-today-start-time", GetTodayStartTime)
-	http.HandleFunc("/get-daily-quest-progress", GetDailyQuestProgress)
-	http.HandleFunc("/get-today-quest-progress", GetTodayQuestProgress)
-	http.HandleFunc("/get-main-quest-progress", GetMainQuestProgress)
+	routeutils.Post("/init-quests", InitQuests, routeutils.RequirePermission(auth.PermissionSetup))
+	routeutils.Get("/get-daily-quests", GetDailyQuests)
+	routeutils.Get("/get-main-quests", GetMainQuests)
+	routeutils.Get("/get-main-user-quests", GetMainUserQuests)
+	routeutils.Get("/get-todays-quests", getTodaysQuests)
+	routeutils.Get("/get-todays-user-quests", getTodaysUserQuests)
+	routeutils.Get("/get-completed-daily-quests", GetCompletedDailyQuests)
+	routeutils.Get("/get-completed-main-quests", GetCompletedMainQuests)
+	routeutils.Get("/get-user-quest-status", GetUserQuestStatus)
+	routeutils.Get("/get-today-start-time", GetTodayStartTime)
+	routeutils.Get("/get-daily-quest-progress", GetDailyQuestProgress)
+	routeutils.Get("/get-today-quest-progress", GetTodayQuestProgress)
+	routeutils.Get("/get-main-quest-progress", GetMainQuestProgress)
 	if !core.ArtPeaceBackend.BackendConfig.Production {
-		http.HandleFunc("/claim-today-quest-devnet", ClaimTodayQuestDevnet)
-		http.HandleFunc("/claim-main-quest-devnet", ClaimMainQuestDevnet)
-		http.HandleFunc("/increase-day-devnet", IncreaseDayDevnet)
+		routeutils.Post("/claim-today-quest-devnet", ClaimTodayQuestDevnet, routeutils.NonProduction)
+		routeutils.Post("/claim-main-quest-devnet", ClaimMainQuestDevnet, routeutils.NonProduction)
+		routeutils.Post("/increase-day-devnet", IncreaseDayDevnet, routeutils.NonProduction)
 	}
 }
 
 func InitQuests(w http.ResponseWriter, r *http.Request) {
-	// Only allow admin to initialize colors
-	if routeutils.AdminMiddleware(w, r) {
-		return
-	}
-
 	// TODO: check if quests already exist
 	questJson, err := routeutils.ReadJsonBody[QuestsConfig](r)
 	if err != nil {
@@ -579,15 +575,10 @@ func GetTodayStartTime(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	routeutils.WriteDataJson(w, "\""+string((*todayStartTime).UTC().Format(time.RFC3339))+"\"")
+	routeutils.WriteStringDataJson(w, string((*todayStartTime).UTC().Format(time.RFC3339)))
 }
 
 func ClaimTodayQuestDevnet(w http.ResponseWriter, r *http.Request) {
-	// Disable this in production
-	if routeutils.NonProductionMiddleware(w, r) {
-		return
-	}
-
 	jsonBody, err := routeutils.ReadJsonBody[map[string]string](r)
 	if err != nil {
 		routeutils.WriteErrorJson(w, http.StatusBadRequest, "Invalid JSON request body")
@@ -623,11 +614,6 @@ func ClaimTodayQuestDevnet(w http.ResponseWriter, r *http.Request) {
 }
 
 func ClaimMainQuestDevnet(w http.ResponseWriter, r *http.Request) {
-	// Disable this in production
-	if routeutils.NonProductionMiddleware(w, r) {
-		return
-	}
-
 	jsonBody, err := routeutils.ReadJsonBody[map[string]string](r)
 	if err != nil {
 		routeutils.WriteErrorJson(w, http.StatusBadRequest, "Invalid JSON request body")
@@ -663,11 +649,6 @@ func ClaimMainQuestDevnet(w http.ResponseWriter, r *http.Request) {
 }
 
 func IncreaseDayDevnet(w http.ResponseWriter, r *http.Request) {
-	// Disable this in production
-	if routeutils.NonProductionMiddleware(w, r) {
-		return
-	}
-
 	shellCmd := core.ArtPeaceBackend.BackendConfig.Scripts.IncreaseDayDevnet
 	contract := os.Getenv("ART_PEACE_CONTRACT_ADDRESS")
 
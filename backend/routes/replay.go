@@ -0,0 +1,324 @@
+package routes
+
+import (
+	"encoding/base64"
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/keep-starknet-strange/art-peace/backend/core"
+	"github.com/keep-starknet-strange/art-peace/backend/render"
+	routeutils "github.com/keep-starknet-strange/art-peace/backend/routes/utils"
+)
+
+const (
+	replayTick = 100 * time.Millisecond
+	// Longest wall clock wait between two replayed placements, longer gaps are skipped
+	replayMaxIdle      = 2 * time.Second
+	replayMinSpeed     = 0.1
+	replayMaxSpeed     = 100000
+	replayMaxPixels    = 500000
+	replayDefaultSpeed = 60
+	// Sessions open at once on this server & per IP, each holds up to replayMaxPixels placements
+	replayMaxSessions      = 64
+	replayMaxSessionsPerIP = 2
+)
+
+// Open replay sessions, counted from before their placements are loaded
+var replaySlots = struct {
+	lock  sync.Mutex
+	total int
+	perIP map[string]int
+}{perIP: make(map[string]int)}
+
+func acquireReplaySlot(ip string) error {
+	replaySlots.lock.Lock()
+	defer replaySlots.lock.Unlock()
+	if replaySlots.total >= replayMaxSessions {
+		return fmt.Errorf("too many replays are running, retry later")
+	}
+	if replaySlots.perIP[ip] >= replayMaxSessionsPerIP {
+		return fmt.Errorf("at most %d replays can run at once per IP", replayMaxSessionsPerIP)
+	}
+	replaySlots.total++
+	replaySlots.perIP[ip]++
+	return nil
+}
+
+func releaseReplaySlot(ip string) {
+	replaySlots.lock.Lock()
+	defer replaySlots.lock.Unlock()
+	replaySlots.total--
+	replaySlots.perIP[ip]--
+	if replaySlots.perIP[ip] <= 0 {
+		delete(replaySlots.perIP, ip)
+	}
+}
+
+// Replay of a world's WorldsPixels history streamed to one connection
+type replaySession struct {
+	client     *core.WSClient
+	ip         string
+	source     *render.Source
+	placements []render.Placement
+	closeOnce  sync.Once
+
+	lock   sync.Mutex
+	from   time.Time
+	to     time.Time
+	clock  time.Time
+	next   int
+	speed  float64
+	paused bool
+	ended  bool
+	stop   chan struct{}
+}
+
+func validReplaySpeed(speed float64) bool {
+	return speed >= replayMinSpeed && speed <= replayMaxSpeed
+}
+
+func startReplaySession(client *core.WSClient, ip string, msg *wsClientMessage) (*replaySession, error) {
+	if msg.From <= 0 {
+		return nil, fmt.Errorf("missing from")
+	}
+	to := msg.To
+	if to == 0 {
+		to = time.Now().Unix()
+	}
+	if msg.From >= to {
+		return nil, fmt.Errorf("from must be before to")
+	}
+	speed := msg.Speed
+	if speed == 0 {
+		speed = replayDefaultSpeed
+	}
+	if !validReplaySpeed(speed) {
+		return nil, fmt.Errorf("speed must be between %v and %v", replayMinSpeed, replayMaxSpeed)
+	}
+
+	source, err := render.WorldSource(msg.WorldId)
+	if err != nil {
+		return nil, fmt.Errorf("world not found")
+	}
+
+	if err := acquireReplaySlot(ip); err != nil {
+		return nil, err
+	}
+	count, err := source.CountPlacements(render.HistoryFilter{From: msg.From, To: to})
+	if err != nil {
+		releaseReplaySlot(ip)
+		return nil, fmt.Errorf("failed to retrieve world pixels")
+	}
+	if count > replayMaxPixels {
+		releaseReplaySlot(ip)
+		return nil, fmt.Errorf("replay has more than %d pixels, use a shorter time range", replayMaxPixels)
+	}
+	// Bounded again, placements may have been indexed since the count
+	placements, err := source.PlacementsUpTo(render.HistoryFilter{From: msg.From, To: to}, replayMaxPixels)
+	if err != nil {
+		releaseReplaySlot(ip)
+		return nil, fmt.Errorf("failed to retrieve world pixels")
+	}
+
+	session := &replaySession{
+		client:     client,
+		ip:         ip,
+		source:     source,
+		placements: placements,
+		from:       time.Unix(msg.From, 0).UTC(),
+		to:         time.Unix(to, 0).UTC(),
+		speed:      speed,
+		stop:       make(chan struct{}),
+	}
+	if err := session.seek(session.from); err != nil {
+		session.close()
+		return nil, err
+	}
+	go session.run()
+	return session, nil
+}
+
+// Move the replay clock & send the canvas as it was at that time
+func (s *replaySession) seek(at time.Time) error {
+	if at.Before(s.from) {
+		at = s.from
+	}
+	if at.After(s.to) {
+		at = s.to
+	}
+
+	frame, err := s.source.FrameAt(at.Unix())
+	if err != nil {
+		return fmt.Errorf("failed to rebuild canvas")
+	}
+
+	// Hold the lock while sending so no pixel from before the seek is sent after the canvas
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.clock = at
+	s.next = sort.Search(len(s.placements), func(i int) bool {
+		return !s.placements[i].Time.Before(at)
+	})
+	s.ended = false
+
+	// Same bitfield format as /get-world-canvas
+	return routeutils.WriteWebSocketMessage(s.client, map[string]string{
+		"messageType": "replayCanvas",
+		"worldId":     strconv.Itoa(s.source.WorldId),
+		"time":        strconv.FormatInt(at.Unix(), 10),
+		"canvas":      base64.StdEncoding.EncodeToString(frame.EncodeBitfield(core.ArtPeaceBackend.CanvasConfig.ColorsBitWidth)),
+	})
+}
+
+func (s *replaySession) setPaused(paused bool) {
+	s.lock.Lock()
+	s.paused = paused
+	s.lock.Unlock()
+	s.sendState()
+}
+
+func (s *replaySession) setSpeed(speed float64) error {
+	if !validReplaySpeed(speed) {
+		return fmt.Errorf("speed must be between %v and %v", replayMinSpeed, replayMaxSpeed)
+	}
+	s.lock.Lock()
+	s.speed = speed
+	s.lock.Unlock()
+	s.sendState()
+	return nil
+}
+
+func (s *replaySession) close() {
+	s.closeOnce.Do(func() {
+		close(s.stop)
+		releaseReplaySlot(s.ip)
+	})
+}
+
+func (s *replaySession) sendState() {
+	s.lock.Lock()
+	status := "playing"
+	if s.ended {
+		status = "ended"
+	} else if s.paused {
+		status = "paused"
+	}
+	message := map[string]string{
+		"messageType": "replayState",
+		"worldId":     strconv.Itoa(s.source.WorldId),
+		"status":      status,
+		"time":        strconv.FormatInt(s.clock.Unix(), 10),
+		"speed":       strconv.FormatFloat(s.speed, 'f', -1, 64),
+	}
+	s.lock.Unlock()
+
+	routeutils.WriteWebSocketMessage(s.client, message)
+}
+
+// Advance the replay clock every tick & send the placements it passed as one batch
+func (s *replaySession) run() {
+	ticker := time.NewTicker(replayTick)
+	defer ticker.Stop()
+
+	s.sendState()
+	for {
+		select {
+		case <-s.stop:
+			return
+		case <-ticker.C:
+		}
+
+		s.lock.Lock()
+		if s.paused || s.ended {
+			s.lock.Unlock()
+			continue
+		}
+
+		step := time.Duration(float64(replayTick) * s.speed)
+		if s.next < len(s.placements) {
+			// Skip over quiet periods instead of streaming nothing
+			gap := s.placements[s.next].Time.Sub(s.clock)
+			maxGap := time.Duration(float64(replayMaxIdle) * s.speed)
+			if gap > maxGap {
+				s.clock = s.placements[s.next].Time.Add(-maxGap)
+			}
+		}
+		s.clock = s.clock.Add(step)
+
+		batch := make([]map[string]string, 0)
+		for s.next < len(s.placements) && s.placements[s.next].Time.Before(s.clock) {
+			placement := s.placements[s.next]
+			batch = append(batch, map[string]string{
+				"messageType": "replayPixel",
+				"worldId":     strconv.Itoa(s.source.WorldId),
+				"position":    strconv.Itoa(placement.Position),
+				"color":       strconv.Itoa(placement.Color),
+				"time":        strconv.FormatInt(placement.Time.Unix(), 10),
+			})
+			s.next++
+		}
+		// Nothing is left to stream once the last placement is sent
+		ended := s.next >= len(s.placements)
+		if ended {
+			s.clock = s.to
+		}
+		s.ended = ended
+
+		if len(batch) > 0 {
+			if err := routeutils.WriteWebSocketMessage(s.client, batch); err != nil {
+				s.lock.Unlock()
+				return
+			}
+		}
+		s.lock.Unlock()
+		if ended {
+			s.sendState()
+		}
+	}
+}
+
+// Handle replay control messages, returning the connection's current session
+func handleReplayMessage(client *core.WSClient, ip string, session *replaySession, msg *wsClientMessage) *replaySession {
+	var err error
+	switch msg.MessageType {
+	case "replayStart":
+		if session != nil {
+			session.close()
+		}
+		session, err = startReplaySession(client, ip, msg)
+	case "replayStop":
+		if session != nil {
+			session.close()
+			session = nil
+		}
+	case "replayPause", "replayResume", "replaySeek", "replaySpeed":
+		if session == nil {
+			err = fmt.Errorf("no replay session")
+			break
+		}
+		switch msg.MessageType {
+		case "replayPause":
+			session.setPaused(true)
+		case "replayResume":
+			session.setPaused(false)
+		case "replaySeek":
+			err = session.seek(time.Unix(msg.Time, 0).UTC())
+			if err == nil {
+				session.sendState()
+			}
+		case "replaySpeed":
+			err = session.setSpeed(msg.Speed)
+		}
+	}
+
+	if err != nil {
+		routeutils.WriteWebSocketMessage(client, map[string]string{
+			"messageType": "replayError",
+			"error":       err.Error(),
+		})
+	}
+	return session
+}
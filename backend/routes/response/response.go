@@ -0,0 +1,105 @@
+package response
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// Bodies of every JSON response, built with encoding/json so any text is escaped
+// The data, result & error fields keep the shape clients already read
+
+type ErrorCode string
+
+// Stable codes clients can branch on, the error message is only meant for people
+const (
+	CodeBadRequest             ErrorCode = "bad_request"
+	CodeUnauthorized           ErrorCode = "unauthorized"
+	CodeForbidden              ErrorCode = "forbidden"
+	CodeNotFound               ErrorCode = "not_found"
+	CodeMethodNotAllowed       ErrorCode = "method_not_allowed"
+	CodeConflict               ErrorCode = "conflict"
+	CodePayloadTooLarge        ErrorCode = "payload_too_large"
+	CodeRateLimited            ErrorCode = "rate_limited"
+	CodeInternal               ErrorCode = "internal_error"
+	CodeNotImplemented         ErrorCode = "not_implemented"
+	CodeUnavailable            ErrorCode = "unavailable"
+	CodeAuthenticationRequired ErrorCode = "authentication_required"
+	CodeInvalidSignature       ErrorCode = "invalid_signature"
+	CodeNonceNotFound          ErrorCode = "nonce_not_found"
+	CodeApiKeyRequired         ErrorCode = "api_key_required"
+	CodePermissionDenied       ErrorCode = "permission_denied"
+	CodeProductionDisabled     ErrorCode = "production_disabled"
+	// Malformed cursor or one with the fields of another route, restart from the first page
+	CodeInvalidCursor ErrorCode = "invalid_cursor"
+)
+
+var statusCodes = map[int]ErrorCode{
+	http.StatusBadRequest:            CodeBadRequest,
+	http.StatusUnauthorized:          CodeUnauthorized,
+	http.StatusForbidden:             CodeForbidden,
+	http.StatusNotFound:              CodeNotFound,
+	http.StatusMethodNotAllowed:      CodeMethodNotAllowed,
+	http.StatusConflict:              CodeConflict,
+	http.StatusRequestEntityTooLarge: CodePayloadTooLarge,
+	http.StatusTooManyRequests:       CodeRateLimited,
+	http.StatusInternalServerError:   CodeInternal,
+	http.StatusNotImplemented:        CodeNotImplemented,
+	http.StatusServiceUnavailable:    CodeUnavailable,
+}
+
+// Default code of errors written without one
+func CodeForStatus(status int) ErrorCode {
+	if code, ok := statusCodes[status]; ok {
+		return code
+	}
+	if status >= http.StatusInternalServerError {
+		return CodeInternal
+	}
+	return CodeBadRequest
+}
+
+type Error struct {
+	Error     string    `json:"error"`
+	Code      ErrorCode `json:"code"`
+	RequestId string    `json:"requestId,omitempty"`
+}
+
+type Result struct {
+	Result    string `json:"result"`
+	RequestId string `json:"requestId,omitempty"`
+}
+
+type Data struct {
+	Data       json.RawMessage `json:"data"`
+	Pagination *Pagination     `json:"pagination,omitempty"`
+	RequestId  string          `json:"requestId,omitempty"`
+}
+
+// Page of a list route, nextPage & nextCursor are only set when there are more items
+// page & nextPage are left out when paging with cursors
+// There is no total : count is the number of items of this page, & hasNext comes from fetching one item past it
+type Pagination struct {
+	Page       int    `json:"page,omitempty"`
+	PageLength int    `json:"pageLength"`
+	Count      int    `json:"count"`
+	HasNext    bool   `json:"hasNext"`
+	NextPage   *int   `json:"nextPage,omitempty"`
+	NextCursor string `json:"nextCursor,omitempty"`
+}
+
+// Marshal a body, falling back to an internal error that can't fail to marshal
+func Marshal(body interface{}) []byte {
+	bodyBytes, err := json.Marshal(body)
+	if err != nil {
+		return []byte(`{"error":"Failed to marshal response","code":"internal_error"}`)
+	}
+	return bodyBytes
+}
+
+// Data that isn't valid JSON is sent as a string
+func RawData(data string) json.RawMessage {
+	if json.Valid([]byte(data)) {
+		return json.RawMessage(data)
+	}
+	return json.RawMessage(Marshal(data))
+}
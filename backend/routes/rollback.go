@@ -0,0 +1,368 @@
+package routes
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/keep-starknet-strange/art-peace/backend/auth"
+	"github.com/keep-starknet-strange/art-peace/backend/core"
+	"github.com/keep-starknet-strange/art-peace/backend/render"
+	routeutils "github.com/keep-starknet-strange/art-peace/backend/routes/utils"
+)
+
+func InitRollbackRoutes() {
+	routeutils.Post("/rollback-pixels", rollbackPixels, routeutils.RequirePermission(auth.PermissionCanvas))
+	routeutils.Get("/get-pixel-rollbacks", getPixelRollbacks, routeutils.RequirePermission(auth.PermissionCanvas))
+}
+
+type RollbackPixelsRequest struct {
+	WorldId   int      `json:"worldId"`
+	Addresses []string `json:"addresses"`
+	// Unix seconds, 0 meaning unbounded
+	From   int64 `json:"from"`
+	To     int64 `json:"to"`
+	DryRun bool  `json:"dryRun"`
+}
+
+type RolledBackPixel struct {
+	Position      int `json:"position"`
+	X             int `json:"x"`
+	Y             int `json:"y"`
+	CurrentColor  int `json:"currentColor"`
+	RestoredColor int `json:"restoredColor"`
+}
+
+type RollbackResult struct {
+	RollbackKey       int                `json:"rollbackKey"`
+	DryRun            bool               `json:"dryRun"`
+	PlacementsRemoved int                `json:"placementsRemoved"`
+	Pixels            []*RolledBackPixel `json:"pixels"`
+	// Positions the live canvas couldn't be updated at yet, retried in the background
+	PendingPositions []int `json:"pendingPositions"`
+}
+
+type PixelRollback struct {
+	Key               int        `json:"key"`
+	WorldId           int        `json:"worldId"`
+	Addresses         []string   `json:"addresses"`
+	FromTime          *time.Time `json:"fromTime"`
+	ToTime            *time.Time `json:"toTime"`
+	PlacementsRemoved int        `json:"placementsRemoved"`
+	PositionsRestored int        `json:"positionsRestored"`
+	Time              time.Time  `json:"time"`
+}
+
+// A placement at one of the positions the rollback touches
+type rollbackPlacement struct {
+	RowId    string    `json:"rowId"`
+	Address  string    `json:"address"`
+	Position int       `json:"position"`
+	Color    int       `json:"color"`
+	Time     time.Time `json:"time"`
+	// Made by the addresses inside the time window
+	RolledBack bool `json:"rolledBack"`
+}
+
+// Placements by the addresses inside the time window
+const rollbackPlacementsWhere = "world_id = $1 AND address = ANY($2) AND ($3::bigint = 0 OR time >= TO_TIMESTAMP($3::bigint)) AND ($4::bigint = 0 OR time < TO_TIMESTAMP($4::bigint))"
+
+const (
+	// Restored colors are written to Redis in batches of this many pixels, each batch a single BITFIELD
+	rollbackRedisBatch    = 512
+	rollbackRedisAttempts = 3
+	// Positions still failing are retried in the background, with their color read again each time
+	rollbackReconcileInterval = 10 * time.Second
+	rollbackReconcileAttempts = 60
+)
+
+// Color shown at a position given its last placement, accounting for later admin clears
+func clearedColor(placement *rollbackPlacement, x int, y int, clears []render.CanvasClear) int {
+	var placedAt time.Time
+	color := 0
+	if placement != nil {
+		placedAt = placement.Time
+		color = placement.Color
+	}
+	for _, clear := range clears {
+		if x >= clear.XStart && x <= clear.XEnd && y >= clear.YStart && y <= clear.YEnd && placedAt.Before(clear.Time) {
+			return 0
+		}
+	}
+	return color
+}
+
+// Restore every position touched by the addresses in the window to the color it would have without their placements
+// ex: curl -X POST -d '{"worldId":13,"addresses":["0x..."],"from":1700000000,"to":1700003600,"dryRun":true}' http://localhost:8080/rollback-pixels
+func rollbackPixels(w http.ResponseWriter, r *http.Request) {
+	jsonBody, err := routeutils.ReadJsonBody[RollbackPixelsRequest](r)
+	if err != nil {
+		routeutils.WriteErrorJson(w, http.StatusBadRequest, "Invalid JSON request body")
+		return
+	}
+	if len(jsonBody.Addresses) == 0 {
+		routeutils.WriteErrorJson(w, http.StatusBadRequest, "Missing addresses")
+		return
+	}
+	if jsonBody.From < 0 || jsonBody.To < 0 || (jsonBody.From != 0 && jsonBody.To != 0 && jsonBody.From >= jsonBody.To) {
+		routeutils.WriteErrorJson(w, http.StatusBadRequest, "Invalid time window")
+		return
+	}
+	// Compared with the stored rows in their padded form
+	addresses := make([]string, len(jsonBody.Addresses))
+	for idx, address := range jsonBody.Addresses {
+		addresses[idx], err = auth.NormalizeAddress(address)
+		if err != nil {
+			routeutils.WriteErrorJson(w, http.StatusBadRequest, "Invalid address "+address)
+			return
+		}
+	}
+
+	world, err := core.PostgresQueryOne[WorldData]("SELECT * FROM worlds WHERE world_id = $1", jsonBody.WorldId)
+	if err != nil {
+		routeutils.WriteErrorJson(w, http.StatusNotFound, "World not found")
+		return
+	}
+
+	ctx := context.Background()
+	tx, err := core.ArtPeaceBackend.Databases.Postgres.Begin(ctx)
+	if err != nil {
+		routeutils.WriteErrorJson(w, http.StatusInternalServerError, "Failed to start rollback")
+		return
+	}
+	// Dry runs never commit
+	defer tx.Rollback(ctx)
+
+	// Every placement at the affected positions, newest first, locked until commit so the rows removed
+	// & the colors restored are the ones read here, even with a concurrent rollback or pixel revert
+	lock := " FOR UPDATE"
+	if jsonBody.DryRun {
+		lock = ""
+	}
+	args := []interface{}{jsonBody.WorldId, addresses, jsonBody.From, jsonBody.To}
+	history, err := core.PostgresTxQuery[rollbackPlacement](tx, "SELECT ctid::text AS row_id, address, position, color, time, ("+rollbackPlacementsWhere+") AS rolled_back FROM WorldsPixels WHERE world_id = $1 AND position IN (SELECT position FROM WorldsPixels WHERE "+rollbackPlacementsWhere+") ORDER BY position, time DESC"+lock, args...)
+	if err != nil {
+		routeutils.WriteErrorJson(w, http.StatusInternalServerError, "Failed to retrieve placements")
+		return
+	}
+
+	clears, err := core.PostgresTxQuery[render.CanvasClear](tx, "SELECT time, x_start, x_end, y_start, y_end FROM CanvasClears WHERE world_id = $1 ORDER BY time ASC", jsonBody.WorldId)
+	if err != nil {
+		routeutils.WriteErrorJson(w, http.StatusInternalServerError, "Failed to retrieve canvas clears")
+		return
+	}
+
+	// Last placement at each position, with and without the rolled back placements
+	placements := make([]*rollbackPlacement, 0)
+	current := make(map[int]*rollbackPlacement)
+	remaining := make(map[int]*rollbackPlacement)
+	positions := make([]int, 0)
+	for idx := range history {
+		placement := &history[idx]
+		if _, ok := current[placement.Position]; !ok {
+			current[placement.Position] = placement
+			positions = append(positions, placement.Position)
+		}
+		if placement.RolledBack {
+			placements = append(placements, placement)
+		} else if _, ok := remaining[placement.Position]; !ok {
+			remaining[placement.Position] = placement
+		}
+	}
+
+	result := RollbackResult{
+		DryRun:            jsonBody.DryRun,
+		PlacementsRemoved: len(placements),
+		Pixels:            make([]*RolledBackPixel, 0),
+		PendingPositions:  make([]int, 0),
+	}
+	for _, position := range positions {
+		x := position % world.Width
+		y := position / world.Width
+		currentColor := clearedColor(current[position], x, y, clears)
+		restoredColor := clearedColor(remaining[position], x, y, clears)
+		if currentColor == restoredColor {
+			continue
+		}
+		result.Pixels = append(result.Pixels, &RolledBackPixel{
+			Position:      position,
+			X:             x,
+			Y:             y,
+			CurrentColor:  currentColor,
+			RestoredColor: restoredColor,
+		})
+	}
+
+	if !jsonBody.DryRun {
+		result.RollbackKey, err = applyRollback(tx, jsonBody, addresses, placements, len(result.Pixels))
+		if err != nil {
+			routeutils.WriteErrorJson(w, http.StatusInternalServerError, "Failed to apply rollback")
+			return
+		}
+
+		// Postgres is committed, the live canvas follows it even if Redis is failing for now
+		written, pending := writeRollbackPixels(jsonBody.WorldId, result.Pixels)
+		for _, pixel := range pending {
+			result.PendingPositions = append(result.PendingPositions, pixel.Position)
+		}
+		if len(pending) > 0 {
+			go reconcileRollbackPixels(jsonBody.WorldId, world.Width, append([]int{}, result.PendingPositions...))
+		}
+		go sendRolledBackPixels(jsonBody.WorldId, written)
+	}
+
+	resultJson, err := json.Marshal(result)
+	if err != nil {
+		routeutils.WriteErrorJson(w, http.StatusInternalServerError, "Failed to marshal rollback")
+		return
+	}
+	routeutils.WriteDataJson(w, string(resultJson))
+}
+
+// Archive & delete the placements read in the transaction, record the rollback & commit, returning its key
+func applyRollback(tx pgx.Tx, request *RollbackPixelsRequest, addresses []string, placements []*rollbackPlacement, positionsRestored int) (int, error) {
+	ctx := context.Background()
+
+	var fromTime, toTime interface{}
+	if request.From != 0 {
+		fromTime = time.Unix(request.From, 0).UTC()
+	}
+	if request.To != 0 {
+		toTime = time.Unix(request.To, 0).UTC()
+	}
+
+	var rollbackKey int
+	err := tx.QueryRow(ctx, "INSERT INTO PixelRollbacks (world_id, addresses, from_time, to_time, placements_removed, positions_restored) VALUES ($1, $2, $3, $4, $5, $6) RETURNING key", request.WorldId, addresses, fromTime, toTime, len(placements), positionsRestored).Scan(&rollbackKey)
+	if err != nil {
+		return 0, err
+	}
+
+	rows := make([][]interface{}, len(placements))
+	rowIds := make([]string, len(placements))
+	for idx, placement := range placements {
+		rows[idx] = []interface{}{rollbackKey, placement.Address, placement.Position, placement.Color, placement.Time}
+		rowIds[idx] = placement.RowId
+	}
+	_, err = tx.CopyFrom(ctx, pgx.Identifier{"pixelrollbackplacements"}, []string{"rollback_key", "address", "position", "color", "time"}, pgx.CopyFromRows(rows))
+	if err != nil {
+		return 0, err
+	}
+
+	// The rows are locked, so their ids still point to the placements archived above
+	deleted, err := tx.Exec(ctx, "DELETE FROM WorldsPixels WHERE ctid = ANY($1::text[]::tid[])", rowIds)
+	if err != nil {
+		return 0, err
+	}
+	if deleted.RowsAffected() != int64(len(rowIds)) {
+		return 0, fmt.Errorf("deleted %d placements out of %d", deleted.RowsAffected(), len(rowIds))
+	}
+
+	return rollbackKey, tx.Commit(ctx)
+}
+
+// Set pixels in the Redis canvas, one BITFIELD per batch retried a few times
+// Returns the pixels written & the ones whose batch kept failing
+func writeRollbackPixels(worldId int, pixels []*RolledBackPixel) ([]*RolledBackPixel, []*RolledBackPixel) {
+	bitWidth := core.ArtPeaceBackend.CanvasConfig.ColorsBitWidth
+	bitfieldType := "u" + strconv.Itoa(int(bitWidth))
+	canvasKey := "canvas-" + strconv.Itoa(worldId)
+	ctx := context.Background()
+
+	written := make([]*RolledBackPixel, 0, len(pixels))
+	pending := make([]*RolledBackPixel, 0)
+	for start := 0; start < len(pixels); start += rollbackRedisBatch {
+		batch := pixels[start:min(start+rollbackRedisBatch, len(pixels))]
+		args := make([]interface{}, 0, 4*len(batch))
+		for _, pixel := range batch {
+			args = append(args, "SET", bitfieldType, uint(pixel.Position)*bitWidth, pixel.RestoredColor)
+		}
+
+		var err error
+		for attempt := 0; attempt < rollbackRedisAttempts; attempt++ {
+			if attempt > 0 {
+				time.Sleep(time.Duration(attempt) * 100 * time.Millisecond)
+			}
+			if err = core.ArtPeaceBackend.Databases.Redis.BitField(ctx, canvasKey, args...).Err(); err == nil {
+				break
+			}
+		}
+		if err != nil {
+			fmt.Println("Failed to set rolled back pixels on redis", worldId, len(batch), err)
+			pending = append(pending, batch...)
+			continue
+		}
+		written = append(written, batch...)
+	}
+	return written, pending
+}
+
+// Bring the Redis canvas back in line with Postgres at positions a rollback couldn't write
+// The color is read again on each attempt, so placements made since the rollback aren't overwritten
+func reconcileRollbackPixels(worldId int, width int, positions []int) {
+	for attempt := 0; attempt < rollbackReconcileAttempts && len(positions) > 0; attempt++ {
+		time.Sleep(rollbackReconcileInterval)
+
+		last, err := core.PostgresQuery[rollbackPlacement]("SELECT DISTINCT ON (position) address, position, color, time FROM WorldsPixels WHERE world_id = $1 AND position = ANY($2) ORDER BY position, time DESC", worldId, positions)
+		if err != nil {
+			fmt.Println("Failed to read placements to reconcile", worldId, err)
+			continue
+		}
+		clears, err := core.PostgresQuery[render.CanvasClear]("SELECT time, x_start, x_end, y_start, y_end FROM CanvasClears WHERE world_id = $1 ORDER BY time ASC", worldId)
+		if err != nil {
+			fmt.Println("Failed to read canvas clears to reconcile", worldId, err)
+			continue
+		}
+		lastByPosition := make(map[int]*rollbackPlacement)
+		for idx := range last {
+			lastByPosition[last[idx].Position] = &last[idx]
+		}
+
+		pixels := make([]*RolledBackPixel, len(positions))
+		for idx, position := range positions {
+			x, y := position%width, position/width
+			pixels[idx] = &RolledBackPixel{Position: position, X: x, Y: y, RestoredColor: clearedColor(lastByPosition[position], x, y, clears)}
+		}
+		written, pending := writeRollbackPixels(worldId, pixels)
+		sendRolledBackPixels(worldId, written)
+
+		positions = make([]int, 0, len(pending))
+		for _, pixel := range pending {
+			positions = append(positions, pixel.Position)
+		}
+	}
+	if len(positions) > 0 {
+		fmt.Println("Gave up reconciling rolled back pixels, the canvas differs from Postgres at", worldId, positions)
+	}
+}
+
+func sendRolledBackPixels(worldId int, pixels []*RolledBackPixel) {
+	for _, pixel := range pixels {
+		var message = map[string]string{
+			"worldId":     strconv.Itoa(worldId),
+			"position":    strconv.Itoa(pixel.Position),
+			"color":       strconv.Itoa(pixel.RestoredColor),
+			"messageType": "colorWorldPixel",
+		}
+		routeutils.SendMessageToWSS(message)
+	}
+}
+
+func getPixelRollbacks(w http.ResponseWriter, r *http.Request) {
+	worldId, err := strconv.Atoi(r.URL.Query().Get("worldId"))
+	if err != nil {
+		routeutils.WriteErrorJson(w, http.StatusBadRequest, "Invalid worldId")
+		return
+	}
+
+	rollbacks, err := core.PostgresQueryJson[PixelRollback]("SELECT * FROM PixelRollbacks WHERE world_id = $1 ORDER BY time DESC", worldId)
+	if err != nil {
+		routeutils.WriteErrorJson(w, http.StatusInternalServerError, "Failed to retrieve pixel rollbacks")
+		return
+	}
+	routeutils.WriteDataJson(w, string(rollbacks))
+}
@@ -9,15 +9,10 @@ import (
 )
 
 func InitRoundsRoutes() {
-	http.HandleFunc("/get-rounds-config", getRoundsConfig)
+	routeutils.Get("/get-rounds-config", getRoundsConfig)
 }
 
 func getRoundsConfig(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodGet {
-		routeutils.WriteErrorJson(w, http.StatusMethodNotAllowed, "Method not allowed")
-		return
-	}
-
 	config := core.ArtPeaceBackend.RoundsConfig
 
 	// Marshal the config to JSON
@@ -7,7 +7,8 @@ import (
 )
 
 func InitBaseRoutes() {
-	http.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+	// Only the root itself, unknown paths are not found
+	routeutils.Get("/{$}", func(w http.ResponseWriter, r *http.Request) {
 		routeutils.SetupHeaders(w)
 		w.WriteHeader(http.StatusOK)
 	})
@@ -29,4 +30,15 @@ func InitRoutes() {
 	InitStencilsRoutes()
 	InitStencilsStaticRoutes()
 	InitRoundsRoutes()
+	InitExportRoutes()
+	InitHeatmapRoutes()
+	InitDiffRoutes()
+	InitProtectedRoutes()
+	InitRollbackRoutes()
+	InitTimelapseRoutes()
+	InitHighlightRoutes()
+	InitChatRoutes()
+	InitAuthRoutes()
+	InitGraphQLRoutes()
+	InitOpenAPIRoutes()
 }
@@ -14,33 +14,34 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/keep-starknet-strange/art-peace/backend/auth"
 	"github.com/keep-starknet-strange/art-peace/backend/core"
 	routeutils "github.com/keep-starknet-strange/art-peace/backend/routes/utils"
 )
 
 func InitStencilsRoutes() {
-	http.HandleFunc("/get-stencil", getStencil)
-	http.HandleFunc("/get-stencils", getStencils)
-	http.HandleFunc("/get-new-stencils", getNewStencils)
-	http.HandleFunc("/get-favorite-stencils", getFavoriteStencils)
+	routeutils.Get("/get-stencil", getStencil)
+	routeutils.Get("/get-stencils", getStencils)
+	routeutils.Get("/get-new-stencils", getNewStencils)
+	routeutils.Get("/get-favorite-stencils", getFavoriteStencils)
 	// TODO: Hot/top use user interactivity instead of favorite count
-	http.HandleFunc("/get-top-stencils", getTopStencils)
-	http.HandleFunc("/get-hot-stencils", getHotStencils)
-	http.HandleFunc("/add-stencil-img", addStencilImg)
-	http.HandleFunc("/add-stencil-data", addStencilData)
-	http.HandleFunc("/get-stencil-pixel-data", getStencilPixelData)
-	http.HandleFunc("/get-stencil-owner", getStencilOwner)
+	routeutils.Get("/get-top-stencils", getTopStencils, routeutils.RateLimit(routeutils.RateLimitHeavyReads))
+	routeutils.Get("/get-hot-stencils", getHotStencils, routeutils.RateLimit(routeutils.RateLimitHeavyReads))
+	routeutils.Post("/add-stencil-img", addStencilImg, routeutils.RateLimit(routeutils.RateLimitUploads))
+	routeutils.Post("/add-stencil-data", addStencilData)
+	routeutils.Get("/get-stencil-pixel-data", getStencilPixelData)
+	routeutils.Get("/get-stencil-owner", getStencilOwner)
 	if !core.ArtPeaceBackend.BackendConfig.Production {
-		http.HandleFunc("/add-stencil-devnet", addStencilDevnet)
-		http.HandleFunc("/remove-stencil-devnet", removeStencilDevnet)
-		http.HandleFunc("/favorite-stencil-devnet", favoriteStencilDevnet)
-		http.HandleFunc("/unfavorite-stencil-devnet", unfavoriteStencilDevnet)
+		routeutils.Post("/add-stencil-devnet", addStencilDevnet, routeutils.NonProduction)
+		routeutils.Post("/remove-stencil-devnet", removeStencilDevnet, routeutils.NonProduction)
+		routeutils.Post("/favorite-stencil-devnet", favoriteStencilDevnet, routeutils.NonProduction)
+		routeutils.Post("/unfavorite-stencil-devnet", unfavoriteStencilDevnet, routeutils.NonProduction)
 	}
-	http.HandleFunc("/delete-stencil", deleteStencil)
+	routeutils.Post("/delete-stencil", deleteStencil, routeutils.RequirePermission(auth.PermissionStencils))
 }
 
 func InitStencilsStaticRoutes() {
-	http.Handle("/stencils/", http.StripPrefix("/stencils/", http.FileServer(http.Dir("./stencils"))))
+	routeutils.Handle(http.MethodGet, "/stencils/", http.StripPrefix("/stencils/", http.FileServer(http.Dir("./stencils"))))
 }
 
 type StencilData struct {
@@ -53,6 +54,12 @@ type StencilData struct {
 	Position  int    `json:"position"`
 	Favorites int    `json:"favorites"`
 	Favorited bool   `json:"favorited"`
+	// Score of the hot ranking
+	Hotness int `json:"hotness,omitempty"`
+}
+
+func nextNewestStencil(last StencilData) interface{} {
+	return stencilCursor{StencilId: last.StencilId, WorldId: last.WorldId}
 }
 
 func getStencil(w http.ResponseWriter, r *http.Request) {
@@ -94,25 +101,19 @@ func getStencils(w http.ResponseWriter, r *http.Request) {
 	if address == "" {
 		address = "0"
 	}
-	pageLength, err := strconv.Atoi(r.URL.Query().Get("pageLength"))
-	if err != nil || pageLength <= 0 {
-		pageLength = 25
-	}
-	if pageLength > 50 {
-		pageLength = 50
-	}
-	page, err := strconv.Atoi(r.URL.Query().Get("page"))
-	if err != nil || page <= 0 {
-		page = 1
+	pagination := routeutils.ParsePagination(r)
+	after := firstStencilCursor()
+	if err := pagination.ParseCursor(&after); err != nil {
+		writeInvalidCursor(w)
+		return
 	}
-	offset := (page - 1) * pageLength
 
 	var stencils []byte
 	if checkWorldId {
 		query := `
           SELECT 
               stencils.*, 
-              COALESCE(favortie_count, 0) AS favorites,
+              COALESCE(stencilfavorites.favorites, 0) AS favorites,
               COALESCE((SELECT true FROM stencilfavorites WHERE user_address = $1 AND stencilfavorites.stencil_id = stencils.stencil_id AND stencilfavorites.world_id = stencils.world_id), false) as favorited
           FROM 
               stencils
@@ -126,15 +127,15 @@ func getStencils(w http.ResponseWriter, r *http.Request) {
               GROUP BY 
                   (world_id, stencil_id)
           ) stencilfavorites ON stencils.world_id = stencilfavorites.world_id AND stencils.stencil_id = stencilfavorites.stencil_id
-          WHERE stencils.world_id = $2
-          ORDER BY stencils.stencil_id DESC
+          WHERE stencils.world_id = $2 AND (stencils.stencil_id, stencils.world_id) < ($5, $6)
+          ORDER BY stencils.stencil_id DESC, stencils.world_id DESC
           LIMIT $3 OFFSET $4`
-		stencils, err = core.PostgresQueryJson[StencilData](query, address, worldIdInt, pageLength, offset)
+		stencils, err = core.PostgresQueryJson[StencilData](query, address, worldIdInt, pagination.Limit(), pagination.Offset(), after.StencilId, after.WorldId)
 	} else {
 		query := `
           SELECT 
               stencils.*, 
-              COALESCE(favortie_count, 0) AS favorites,
+              COALESCE(stencilfavorites.favorites, 0) AS favorites,
               COALESCE((SELECT true FROM stencilfavorites WHERE user_address = $1 AND stencilfavorites.stencil_id = stencils.stencil_id AND stencilfavorites.world_id = stencils.world_id), false) as favorited
           FROM 
               stencils
@@ -148,15 +149,16 @@ func getStencils(w http.ResponseWriter, r *http.Request) {
               GROUP BY 
                   (world_id, stencil_id)
           ) stencilfavorites ON stencils.world_id = stencilfavorites.world_id AND stencils.stencil_id = stencilfavorites.stencil_id
-          ORDER BY stencils.stencil_id DESC
+          WHERE (stencils.stencil_id, stencils.world_id) < ($4, $5)
+          ORDER BY stencils.stencil_id DESC, stencils.world_id DESC
           LIMIT $2 OFFSET $3`
-		stencils, err = core.PostgresQueryJson[StencilData](query, address, pageLength, offset)
+		stencils, err = core.PostgresQueryJson[StencilData](query, address, pagination.Limit(), pagination.Offset(), after.StencilId, after.WorldId)
 	}
 	if err != nil {
 		routeutils.WriteErrorJson(w, http.StatusInternalServerError, "Failed to retrieve Worlds")
 		return
 	}
-	routeutils.WriteDataJson(w, string(stencils))
+	routeutils.WritePageJson(w, stencils, pagination, nextNewestStencil)
 }
 
 func getNewStencils(w http.ResponseWriter, r *http.Request) {
@@ -175,18 +177,12 @@ func getNewStencils(w http.ResponseWriter, r *http.Request) {
 	if address == "" {
 		address = "0"
 	}
-	pageLength, err := strconv.Atoi(r.URL.Query().Get("pageLength"))
-	if err != nil || pageLength <= 0 {
-		pageLength = 25
-	}
-	if pageLength > 50 {
-		pageLength = 50
-	}
-	page, err := strconv.Atoi(r.URL.Query().Get("page"))
-	if err != nil || page <= 0 {
-		page = 1
+	pagination := routeutils.ParsePagination(r)
+	after := firstStencilCursor()
+	if err := pagination.ParseCursor(&after); err != nil {
+		writeInvalidCursor(w)
+		return
 	}
-	offset := (page - 1) * pageLength
 
 	var stencils []byte
 	if checkWorldId {
@@ -194,7 +190,7 @@ func getNewStencils(w http.ResponseWriter, r *http.Request) {
           SELECT 
               stencils.*, 
               COALESCE(stencilfavorites.favorites, 0) AS favorites,
-              COALESCE((SELECT true FROM stencilfavorites WHERE user_address = $1 AND stencilfavorites.stencil_id = stencils.stencil_id AND stencilfavorites.world_id = stencils.stencil_id), false) as favorited
+              COALESCE((SELECT true FROM stencilfavorites WHERE user_address = $1 AND stencilfavorites.stencil_id = stencils.stencil_id AND stencilfavorites.world_id = stencils.world_id), false) as favorited
           FROM 
               stencils
           LEFT JOIN (
@@ -207,16 +203,16 @@ func getNewStencils(w http.ResponseWriter, r *http.Request) {
               GROUP BY 
                   (world_id, stencil_id)
           ) stencilfavorites ON stencils.world_id = stencilfavorites.world_id AND stencils.stencil_id = stencilfavorites.stencil_id
-          WHERE stencils.world_id = $2 and stencilfavorites.favorites > 0
-          ORDER BY stencils.stencil_id DESC
+          WHERE stencils.world_id = $2 and stencilfavorites.favorites > 0 AND (stencils.stencil_id, stencils.world_id) < ($5, $6)
+          ORDER BY stencils.stencil_id DESC, stencils.world_id DESC
           LIMIT $3 OFFSET $4`
-		stencils, err = core.PostgresQueryJson[StencilData](query, address, worldIdInt, pageLength, offset)
+		stencils, err = core.PostgresQueryJson[StencilData](query, address, worldIdInt, pagination.Limit(), pagination.Offset(), after.StencilId, after.WorldId)
 	} else {
 		query := `
           SELECT 
               stencils.*, 
               COALESCE(stencilfavorites.favorites, 0) AS favorites,
-              COALESCE((SELECT true FROM stencilfavorites WHERE user_address = $1 AND stencilfavorites.stencil_id = stencils.stencil_id AND stencilfavorites.world_id = stencils.stencil_id), false) as favorited
+              COALESCE((SELECT true FROM stencilfavorites WHERE user_address = $1 AND stencilfavorites.stencil_id = stencils.stencil_id AND stencilfavorites.world_id = stencils.world_id), false) as favorited
           FROM 
               stencils
           LEFT JOIN (
@@ -229,17 +225,17 @@ func getNewStencils(w http.ResponseWriter, r *http.Request) {
               GROUP BY 
                   (world_id, stencil_id)
           ) stencilfavorites ON stencils.world_id = stencilfavorites.world_id AND stencils.stencil_id = stencilfavorites.stencil_id
-          WHERE stencilfavorites.favorites > 0
-          ORDER BY stencils.stencil_id DESC
+          WHERE stencilfavorites.favorites > 0 AND (stencils.stencil_id, stencils.world_id) < ($4, $5)
+          ORDER BY stencils.stencil_id DESC, stencils.world_id DESC
           LIMIT $2 OFFSET $3`
-		stencils, err = core.PostgresQueryJson[StencilData](query, address, pageLength, offset)
+		stencils, err = core.PostgresQueryJson[StencilData](query, address, pagination.Limit(), pagination.Offset(), after.StencilId, after.WorldId)
 	}
 	if err != nil {
 		fmt.Println(err)
 		routeutils.WriteErrorJson(w, http.StatusInternalServerError, "Failed to retrieve Worlds")
 		return
 	}
-	routeutils.WriteDataJson(w, string(stencils))
+	routeutils.WritePageJson(w, stencils, pagination, nextNewestStencil)
 }
 
 func getHotStencils(w http.ResponseWriter, r *http.Request) {
@@ -266,87 +262,100 @@ func getHotStencils(w http.ResponseWriter, r *http.Request) {
 	if hotLimit > 500 {
 		hotLimit = 500
 	}
-	pageLength, err := strconv.Atoi(r.URL.Query().Get("pageLength"))
-	if err != nil || pageLength <= 0 {
-		pageLength = 25
-	}
-	if pageLength > 50 {
-		pageLength = 50
+	pagination := routeutils.ParsePagination(r)
+	after := firstRankingCursor()
+	if err := pagination.ParseCursor(&after); err != nil {
+		writeInvalidCursor(w)
+		return
 	}
-	page, err := strconv.Atoi(r.URL.Query().Get("page"))
-	if err != nil || page <= 0 {
-		page = 1
+	if err := after.snapshot("stencilfavorites"); err != nil {
+		routeutils.WriteErrorJson(w, http.StatusInternalServerError, "Failed to retrieve Hot Worlds")
+		return
 	}
-	offset := (page - 1) * pageLength
 
+	// Hotness counts the last favorites up to the snapshot
 	var stencils []byte
 	if checkWorldId {
 		query := `
-        SELECT
-            stencils.*,
-            COALESCE(stencilfavorites.favorite_count, 0) AS favorites,
-            COALESCE((
-                SELECT true FROM stencilfavorites
-                WHERE user_address = $1 AND stencilfavorites.stencil_id = stencils.stencil_id AND stencilfavorites.world_id = stencils.world_id),
-            false) as favorited
-        FROM
-            stencils
-        LEFT JOIN (
+        SELECT * FROM (
             SELECT
-                stencil_id,
-                world_id,
-                COUNT(*) AS favorite_count FROM stencilfavorites GROUP BY (world_id, stencil_id)
-        ) stencilfavorites ON stencils.world_id = stencilfavorites.world_id AND stencils.stencil_id = stencilfavorites.stencil_id
-        LEFT JOIN (
-            SELECT
-                latestfavorites.stencil_id,
-                COUNT(*) as rank
-            FROM (
-                SELECT * FROM stencilfavorites
-                ORDER BY key DESC LIMIT $3
-            ) latestfavorites
-            GROUP BY (stencil_id, world_id)
-        ) rank ON stencils.stencil_id = rank.stencil_id AND stencils.world_id = rank.world_id
-        WHERE stencils.world_id = $2
-        ORDER BY COALESCE(rank, 0) DESC
+                stencils.*,
+                COALESCE(stencilfavorites.favorite_count, 0) AS favorites,
+                COALESCE((
+                    SELECT true FROM stencilfavorites
+                    WHERE user_address = $1 AND stencilfavorites.stencil_id = stencils.stencil_id AND stencilfavorites.world_id = stencils.world_id),
+                false) as favorited,
+                COALESCE(rank.hotness, 0) AS hotness
+            FROM
+                stencils
+            LEFT JOIN (
+                SELECT
+                    stencil_id,
+                    world_id,
+                    COUNT(*) AS favorite_count FROM stencilfavorites GROUP BY (world_id, stencil_id)
+            ) stencilfavorites ON stencils.world_id = stencilfavorites.world_id AND stencils.stencil_id = stencilfavorites.stencil_id
+            LEFT JOIN (
+                SELECT
+                    latestfavorites.stencil_id,
+                    latestfavorites.world_id,
+                    COUNT(*) as hotness
+                FROM (
+                    SELECT * FROM stencilfavorites
+                    WHERE key <= $6
+                    ORDER BY key DESC LIMIT $3
+                ) latestfavorites
+                GROUP BY (stencil_id, world_id)
+            ) rank ON stencils.stencil_id = rank.stencil_id AND stencils.world_id = rank.world_id
+            WHERE stencils.world_id = $2
+        ) ranked
+        WHERE (hotness, stencil_id, world_id) < ($7, $8, $9)
+        ORDER BY hotness DESC, stencil_id DESC, world_id DESC
         LIMIT $4 OFFSET $5;`
-		stencils, err = core.PostgresQueryJson[StencilData](query, address, worldIdInt, hotLimit, pageLength, offset)
+		stencils, err = core.PostgresQueryJson[StencilData](query, address, worldIdInt, hotLimit, pagination.Limit(), pagination.Offset(), after.AsOf, after.Score, after.Id, after.WorldId)
 	} else {
 		query := `
-        SELECT
-            stencils.*,
-            COALESCE(stencilfavorites.favorite_count, 0) AS favorites,
-            COALESCE((
-                SELECT true FROM stencilfavorites
-                WHERE user_address = $1 AND stencilfavorites.stencil_id = stencils.stencil_id AND stencilfavorites.world_id = stencils.world_id),
-            false) as favorited
-        FROM
-            stencils
-        LEFT JOIN (
-            SELECT
-                stencil_id,
-                world_id,
-                COUNT(*) AS favorite_count FROM stencilfavorites GROUP BY (world_id, stencil_id)
-        ) stencilfavorites ON stencils.world_id = stencilfavorites.world_id AND stencils.stencil_id = stencilfavorites.stencil_id
-        LEFT JOIN (
+        SELECT * FROM (
             SELECT
-                latestfavorites.stencil_id,
-                COUNT(*) as rank
-            FROM (
-                SELECT * FROM stencilfavorites
-                ORDER BY key DESC LIMIT $2
-            ) latestfavorites
-            GROUP BY (stencil_id, world_id)
-        ) rank ON stencils.stencil_id = rank.stencil_id AND stencils.world_id = rank.world_id
-        ORDER BY COALESCE(rank, 0) DESC
+                stencils.*,
+                COALESCE(stencilfavorites.favorite_count, 0) AS favorites,
+                COALESCE((
+                    SELECT true FROM stencilfavorites
+                    WHERE user_address = $1 AND stencilfavorites.stencil_id = stencils.stencil_id AND stencilfavorites.world_id = stencils.world_id),
+                false) as favorited,
+                COALESCE(rank.hotness, 0) AS hotness
+            FROM
+                stencils
+            LEFT JOIN (
+                SELECT
+                    stencil_id,
+                    world_id,
+                    COUNT(*) AS favorite_count FROM stencilfavorites GROUP BY (world_id, stencil_id)
+            ) stencilfavorites ON stencils.world_id = stencilfavorites.world_id AND stencils.stencil_id = stencilfavorites.stencil_id
+            LEFT JOIN (
+                SELECT
+                    latestfavorites.stencil_id,
+                    latestfavorites.world_id,
+                    COUNT(*) as hotness
+                FROM (
+                    SELECT * FROM stencilfavorites
+                    WHERE key <= $5
+                    ORDER BY key DESC LIMIT $2
+                ) latestfavorites
+                GROUP BY (stencil_id, world_id)
+            ) rank ON stencils.stencil_id = rank.stencil_id AND stencils.world_id = rank.world_id
+        ) ranked
+        WHERE (hotness, stencil_id, world_id) < ($6, $7, $8)
+        ORDER BY hotness DESC, stencil_id DESC, world_id DESC
         LIMIT $3 OFFSET $4;`
-		stencils, err = core.PostgresQueryJson[StencilData](query, address, hotLimit, pageLength, offset)
+		stencils, err = core.PostgresQueryJson[StencilData](query, address, hotLimit, pagination.Limit(), pagination.Offset(), after.AsOf, after.Score, after.Id, after.WorldId)
 	}
 	if err != nil {
 		routeutils.WriteErrorJson(w, http.StatusInternalServerError, "Failed to retrieve Hot Worlds")
 		return
 	}
-	routeutils.WriteDataJson(w, string(stencils))
+	routeutils.WritePageJson(w, stencils, pagination, func(last StencilData) interface{} {
+		return rankingCursor{AsOf: after.AsOf, Score: last.Hotness, Id: last.StencilId, WorldId: last.WorldId}
+	})
 }
 
 func getTopStencils(w http.ResponseWriter, r *http.Request) {
@@ -365,72 +374,83 @@ func getTopStencils(w http.ResponseWriter, r *http.Request) {
 	if address == "" {
 		address = "0"
 	}
-	pageLength, err := strconv.Atoi(r.URL.Query().Get("pageLength"))
-	if err != nil || pageLength <= 0 {
-		pageLength = 25
-	}
-	if pageLength > 50 {
-		pageLength = 50
+	pagination := routeutils.ParsePagination(r)
+	after := firstRankingCursor()
+	if err := pagination.ParseCursor(&after); err != nil {
+		writeInvalidCursor(w)
+		return
 	}
-	page, err := strconv.Atoi(r.URL.Query().Get("page"))
-	if err != nil || page <= 0 {
-		page = 1
+	if err := after.snapshot("stencilfavorites"); err != nil {
+		routeutils.WriteErrorJson(w, http.StatusInternalServerError, "Failed to retrieve Worlds")
+		return
 	}
-	offset := (page - 1) * pageLength
 
+	// Favorites after the snapshot are left out of the ranking
 	var stencils []byte
 	if checkWorldId {
 		query := `
-          SELECT 
-              stencils.*, 
-              COALESCE(stencilfavorites.favorite_count, 0) AS favorites,
-              COALESCE((SELECT true FROM stencilfavorites WHERE user_address = $1 AND stencilfavorites.stencil_id = stencils.stencil_id AND stencilfavorites.world_id = stencils.world_id), false) as favorited
-          FROM 
-              stencils
-          LEFT JOIN (
-              SELECT 
-                  stencil_id,
-                  world_id, 
-                  COUNT(*) AS favorite_count
-              FROM 
-                  stencilfavorites
-              GROUP BY 
-                  (world_id, stencil_id)
-          ) stencilfavorites ON stencils.world_id = stencilfavorites.world_id AND stencils.stencil_id = stencilfavorites.stencil_id
-          WHERE stencils.world_id = $2 AND stencilfavorites.favorite_count > 0
+          SELECT * FROM (
+            SELECT 
+                stencils.*, 
+                COALESCE(stencilfavorites.favorite_count, 0) AS favorites,
+                COALESCE((SELECT true FROM stencilfavorites WHERE user_address = $1 AND stencilfavorites.stencil_id = stencils.stencil_id AND stencilfavorites.world_id = stencils.world_id), false) as favorited
+            FROM 
+                stencils
+            LEFT JOIN (
+                SELECT 
+                    stencil_id,
+                    world_id, 
+                    COUNT(*) AS favorite_count
+                FROM 
+                    stencilfavorites
+                WHERE 
+                    key <= $5
+                GROUP BY 
+                    (world_id, stencil_id)
+            ) stencilfavorites ON stencils.world_id = stencilfavorites.world_id AND stencils.stencil_id = stencilfavorites.stencil_id
+            WHERE stencils.world_id = $2 AND stencilfavorites.favorite_count > 0
+          ) ranked
+          WHERE (favorites, stencil_id, world_id) < ($6, $7, $8)
           ORDER BY 
-              favorites DESC
+              favorites DESC, stencil_id DESC, world_id DESC
           LIMIT $3 OFFSET $4`
-		stencils, err = core.PostgresQueryJson[StencilData](query, address, worldIdInt, pageLength, offset)
+		stencils, err = core.PostgresQueryJson[StencilData](query, address, worldIdInt, pagination.Limit(), pagination.Offset(), after.AsOf, after.Score, after.Id, after.WorldId)
 	} else {
 		query := `
-          SELECT 
-              stencils.*, 
-              COALESCE(stencilfavorites.favorite_count, 0) AS favorites,
-              COALESCE((SELECT true FROM stencilfavorites WHERE user_address = $1 AND stencilfavorites.stencil_id = stencils.stencil_id AND stencilfavorites.world_id = stencils.world_id), false) as favorited
-          FROM 
-              stencils
-          LEFT JOIN (
-              SELECT 
-                  stencil_id,
-                  world_id, 
-                  COUNT(*) AS favorite_count
-              FROM 
-                  stencilfavorites
-              GROUP BY 
-                  (world_id, stencil_id)
-          ) stencilfavorites ON stencils.world_id = stencilfavorites.world_id AND stencils.stencil_id = stencilfavorites.stencil_id
-          WHERE stencilfavorites.favorite_count > 0
+          SELECT * FROM (
+            SELECT 
+                stencils.*, 
+                COALESCE(stencilfavorites.favorite_count, 0) AS favorites,
+                COALESCE((SELECT true FROM stencilfavorites WHERE user_address = $1 AND stencilfavorites.stencil_id = stencils.stencil_id AND stencilfavorites.world_id = stencils.world_id), false) as favorited
+            FROM 
+                stencils
+            LEFT JOIN (
+                SELECT 
+                    stencil_id,
+                    world_id, 
+                    COUNT(*) AS favorite_count
+                FROM 
+                    stencilfavorites
+                WHERE 
+                    key <= $4
+                GROUP BY 
+                    (world_id, stencil_id)
+            ) stencilfavorites ON stencils.world_id = stencilfavorites.world_id AND stencils.stencil_id = stencilfavorites.stencil_id
+            WHERE stencilfavorites.favorite_count > 0
+          ) ranked
+          WHERE (favorites, stencil_id, world_id) < ($5, $6, $7)
           ORDER BY 
-              favorites DESC
+              favorites DESC, stencil_id DESC, world_id DESC
           LIMIT $2 OFFSET $3`
-		stencils, err = core.PostgresQueryJson[StencilData](query, address, pageLength, offset)
+		stencils, err = core.PostgresQueryJson[StencilData](query, address, pagination.Limit(), pagination.Offset(), after.AsOf, after.Score, after.Id, after.WorldId)
 	}
 	if err != nil {
 		routeutils.WriteErrorJson(w, http.StatusInternalServerError, "Failed to retrieve Worlds")
 		return
 	}
-	routeutils.WriteDataJson(w, string(stencils))
+	routeutils.WritePageJson(w, stencils, pagination, func(last StencilData) interface{} {
+		return rankingCursor{AsOf: after.AsOf, Score: last.Favorites, Id: last.StencilId, WorldId: last.WorldId}
+	})
 }
 
 func getFavoriteStencils(w http.ResponseWriter, r *http.Request) {
@@ -449,19 +469,18 @@ func getFavoriteStencils(w http.ResponseWriter, r *http.Request) {
 	if address == "" {
 		address = "0"
 	}
-	pageLength, err := strconv.Atoi(r.URL.Query().Get("pageLength"))
-	if err != nil || pageLength <= 0 {
-		pageLength = 25
-	}
-	if pageLength > 50 {
-		pageLength = 50
+	pagination := routeutils.ParsePagination(r)
+	after := firstRankingCursor()
+	if err := pagination.ParseCursor(&after); err != nil {
+		writeInvalidCursor(w)
+		return
 	}
-	page, err := strconv.Atoi(r.URL.Query().Get("page"))
-	if err != nil || page <= 0 {
-		page = 1
+	if err := after.snapshot("stencilfavorites"); err != nil {
+		routeutils.WriteErrorJson(w, http.StatusInternalServerError, "Failed to retrieve Worlds")
+		return
 	}
-	offset := (page - 1) * pageLength
 
+	// Ranked like the top stencils
 	var stencils []byte
 	if checkWorldId {
 		query := `
@@ -479,15 +498,17 @@ func getFavoriteStencils(w http.ResponseWriter, r *http.Request) {
                     COUNT(*) AS favorite_count
                 FROM 
                     stencilfavorites
+                WHERE 
+                    key <= $5
                 GROUP BY 
                     (world_id, stencil_id)
             ) stencilfavorites ON stencils.world_id = stencilfavorites.world_id AND stencils.stencil_id = stencilfavorites.stencil_id
           ) w
-          WHERE w.favorited = true and w.world_id = $2
+          WHERE w.favorited = true and w.world_id = $2 AND (w.favorites, w.stencil_id, w.world_id) < ($6, $7, $8)
           ORDER BY 
-              w.favorites DESC
+              w.favorites DESC, w.stencil_id DESC, w.world_id DESC
           LIMIT $3 OFFSET $4`
-		stencils, err = core.PostgresQueryJson[StencilData](query, address, worldIdInt, pageLength, offset)
+		stencils, err = core.PostgresQueryJson[StencilData](query, address, worldIdInt, pagination.Limit(), pagination.Offset(), after.AsOf, after.Score, after.Id, after.WorldId)
 	} else {
 		query := `
           SELECT * FROM (
@@ -504,21 +525,25 @@ func getFavoriteStencils(w http.ResponseWriter, r *http.Request) {
                     COUNT(*) AS favorite_count
                 FROM 
                     stencilfavorites
+                WHERE 
+                    key <= $4
                 GROUP BY 
                     (world_id, stencil_id)
             ) stencilfavorites ON stencils.world_id = stencilfavorites.world_id AND stencils.stencil_id = stencilfavorites.stencil_id
           ) w
-          WHERE w.favorited = true
+          WHERE w.favorited = true AND (w.favorites, w.stencil_id, w.world_id) < ($5, $6, $7)
           ORDER BY 
-              w.favorites DESC
+              w.favorites DESC, w.stencil_id DESC, w.world_id DESC
           LIMIT $2 OFFSET $3`
-		stencils, err = core.PostgresQueryJson[StencilData](query, address, pageLength, offset)
+		stencils, err = core.PostgresQueryJson[StencilData](query, address, pagination.Limit(), pagination.Offset(), after.AsOf, after.Score, after.Id, after.WorldId)
 	}
 	if err != nil {
 		routeutils.WriteErrorJson(w, http.StatusInternalServerError, "Failed to retrieve Worlds")
 		return
 	}
-	routeutils.WriteDataJson(w, string(stencils))
+	routeutils.WritePageJson(w, stencils, pagination, func(last StencilData) interface{} {
+		return rankingCursor{AsOf: after.AsOf, Score: last.Favorites, Id: last.StencilId, WorldId: last.WorldId}
+	})
 }
 
 func addStencilImg(w http.ResponseWriter, r *http.Request) {
@@ -710,11 +735,6 @@ func addStencilData(w http.ResponseWriter, r *http.Request) {
 }
 
 func addStencilDevnet(w http.ResponseWriter, r *http.Request) {
-	// Disable this in production
-	if routeutils.NonProductionMiddleware(w, r) {
-		return
-	}
-
 	jsonBody, err := routeutils.ReadJsonBody[map[string]string](r)
 	if err != nil {
 		routeutils.WriteErrorJson(w, http.StatusBadRequest, "Failed to read request body")
@@ -760,11 +780,6 @@ func addStencilDevnet(w http.ResponseWriter, r *http.Request) {
 }
 
 func removeStencilDevnet(w http.ResponseWriter, r *http.Request) {
-	// Disable this in production
-	if routeutils.NonProductionMiddleware(w, r) {
-		return
-	}
-
 	jsonBody, err := routeutils.ReadJsonBody[map[string]string](r)
 	if err != nil {
 		routeutils.WriteErrorJson(w, http.StatusBadRequest, "Failed to read request body")
@@ -796,11 +811,6 @@ func removeStencilDevnet(w http.ResponseWriter, r *http.Request) {
 }
 
 func favoriteStencilDevnet(w http.ResponseWriter, r *http.Request) {
-	// Disable this in production
-	if routeutils.NonProductionMiddleware(w, r) {
-		return
-	}
-
 	jsonBody, err := routeutils.ReadJsonBody[map[string]string](r)
 	if err != nil {
 		routeutils.WriteErrorJson(w, http.StatusBadRequest, "Failed to read request body")
@@ -832,11 +842,6 @@ func favoriteStencilDevnet(w http.ResponseWriter, r *http.Request) {
 }
 
 func unfavoriteStencilDevnet(w http.ResponseWriter, r *http.Request) {
-	// Disable this in production
-	if routeutils.NonProductionMiddleware(w, r) {
-		return
-	}
-
 	jsonBody, err := routeutils.ReadJsonBody[map[string]string](r)
 	if err != nil {
 		routeutils.WriteErrorJson(w, http.StatusBadRequest, "Failed to read request body")
@@ -970,11 +975,6 @@ func getStencilPixelData(w http.ResponseWriter, r *http.Request) {
 }
 
 func deleteStencil(w http.ResponseWriter, r *http.Request) {
-	// Only allow admin to delete stencils
-	if routeutils.AdminMiddleware(w, r) {
-		return
-	}
-
 	jsonBody, err := routeutils.ReadJsonBody[map[string]string](r)
 	if err != nil {
 		routeutils.WriteErrorJson(w, http.StatusBadRequest, "Failed to read request body")
@@ -1015,5 +1015,5 @@ func getStencilOwner(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	routeutils.WriteDataJson(w, "\""+*owner+"\"")
+	routeutils.WriteStringDataJson(w, *owner)
 }
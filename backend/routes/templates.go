@@ -21,21 +21,21 @@ import (
 )
 
 func InitTemplateRoutes() {
-	http.HandleFunc("/get-templates", getTemplates)
-	http.HandleFunc("/get-faction-templates", getFactionTemplates)
-	http.HandleFunc("/get-chain-faction-templates", getChainFactionTemplates)
-	http.HandleFunc("/build-template-img", buildTemplateImg)
-	http.HandleFunc("/add-template-img", addTemplateImg)
-	http.HandleFunc("/add-template-data", addTemplateData)
-	http.HandleFunc("/get-template-pixel-data", getTemplatePixelData)
+	routeutils.Get("/get-templates", getTemplates)
+	routeutils.Get("/get-faction-templates", getFactionTemplates)
+	routeutils.Get("/get-chain-faction-templates", getChainFactionTemplates)
+	routeutils.Post("/build-template-img", buildTemplateImg)
+	routeutils.Post("/add-template-img", addTemplateImg, routeutils.RateLimit(routeutils.RateLimitUploads))
+	routeutils.Post("/add-template-data", addTemplateData)
+	routeutils.Get("/get-template-pixel-data", getTemplatePixelData)
 	if !core.ArtPeaceBackend.BackendConfig.Production {
 		// http.HandleFunc("/add-template-devnet", addTemplateDevnet)
-		http.HandleFunc("/add-faction-template-devnet", addFactionTemplateDevnet)
-		http.HandleFunc("/remove-faction-template-devnet", removeFactionTemplateDevnet)
-		http.HandleFunc("/add-chain-faction-template-devnet", addChainFactionTemplateDevnet)
-		http.HandleFunc("/remove-chain-faction-template-devnet", removeChainFactionTemplateDevnet)
+		routeutils.Post("/add-faction-template-devnet", addFactionTemplateDevnet, routeutils.NonProduction)
+		routeutils.Post("/remove-faction-template-devnet", removeFactionTemplateDevnet, routeutils.NonProduction)
+		routeutils.Post("/add-chain-faction-template-devnet", addChainFactionTemplateDevnet, routeutils.NonProduction)
+		routeutils.Post("/remove-chain-faction-template-devnet", removeChainFactionTemplateDevnet, routeutils.NonProduction)
 	}
-	http.Handle("/templates/", http.StripPrefix("/templates/", http.FileServer(http.Dir("./templates/"))))
+	routeutils.Handle(http.MethodGet, "/templates/", http.StripPrefix("/templates/", http.FileServer(http.Dir("./templates/"))))
 }
 
 func hashTemplateImage(pixelData []byte) string {
@@ -515,11 +515,6 @@ func getTemplatePixelData(w http.ResponseWriter, r *http.Request) {
 }
 
 func addTemplateDevnet(w http.ResponseWriter, r *http.Request) {
-	// Disable this in production
-	if routeutils.NonProductionMiddleware(w, r) {
-		return
-	}
-
 	jsonBody, err := routeutils.ReadJsonBody[map[string]string](r)
 	if err != nil {
 		routeutils.WriteErrorJson(w, http.StatusBadRequest, "Failed to read request body")
@@ -572,11 +567,6 @@ func addTemplateDevnet(w http.ResponseWriter, r *http.Request) {
 }
 
 func addFactionTemplateDevnet(w http.ResponseWriter, r *http.Request) {
-	// Disable this in production
-	if routeutils.NonProductionMiddleware(w, r) {
-		return
-	}
-
 	jsonBody, err := routeutils.ReadJsonBody[map[string]string](r)
 	if err != nil {
 		routeutils.WriteErrorJson(w, http.StatusBadRequest, "Failed to read request body")
@@ -622,11 +612,6 @@ func addFactionTemplateDevnet(w http.ResponseWriter, r *http.Request) {
 }
 
 func removeFactionTemplateDevnet(w http.ResponseWriter, r *http.Request) {
-	// Disable this in production
-	if routeutils.NonProductionMiddleware(w, r) {
-		return
-	}
-
 	jsonBody, err := routeutils.ReadJsonBody[map[string]string](r)
 	if err != nil {
 		routeutils.WriteErrorJson(w, http.StatusBadRequest, "Failed to read request body")
@@ -652,11 +637,6 @@ func removeFactionTemplateDevnet(w http.ResponseWriter, r *http.Request) {
 }
 
 func addChainFactionTemplateDevnet(w http.ResponseWriter, r *http.Request) {
-	// Disable this in production
-	if routeutils.NonProductionMiddleware(w, r) {
-		return
-	}
-
 	jsonBody, err := routeutils.ReadJsonBody[map[string]string](r)
 	if err != nil {
 		routeutils.WriteErrorJson(w, http.StatusBadRequest, "Failed to read request body")
@@ -702,11 +682,6 @@ func addChainFactionTemplateDevnet(w http.ResponseWriter, r *http.Request) {
 }
 
 func removeChainFactionTemplateDevnet(w http.ResponseWriter, r *http.Request) {
-	// Disable this in production
-	if routeutils.NonProductionMiddleware(w, r) {
-		return
-	}
-
 	jsonBody, err := routeutils.ReadJsonBody[map[string]string](r)
 	if err != nil {
 		routeutils.WriteErrorJson(w, http.StatusBadRequest, "Failed to read request body")
@@ -0,0 +1,370 @@
+package routes
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/keep-starknet-strange/art-peace/backend/render"
+	routeutils "github.com/keep-starknet-strange/art-peace/backend/routes/utils"
+)
+
+func InitTimelapseRoutes() {
+	// Jobs don't survive restarts, neither do their files
+	os.RemoveAll(timelapseDir)
+
+	routeutils.Post("/create-timelapse", createTimelapse, renderRateLimit)
+	routeutils.Get("/get-timelapse-status", getTimelapseStatus)
+	routeutils.Get("/get-timelapse", getTimelapse)
+}
+
+const (
+	timelapseDir = "timelapses"
+	// Finished jobs & their files are removed after this long
+	timelapseJobTTL = time.Hour
+	// Jobs pending or running at once, new ones are rejected past it
+	maxQueuedTimelapses = 8
+	// Bytes of finished timelapses kept on disk, the oldest are removed past it
+	maxTimelapseBytes = 1 << 30
+)
+
+type TimelapseJob struct {
+	JobId    string     `json:"jobId"`
+	Format   string     `json:"format"`
+	Status   string     `json:"status"`
+	Replayed int        `json:"replayed"`
+	Total    int        `json:"total"`
+	Error    string     `json:"error,omitempty"`
+	Created  time.Time  `json:"created"`
+	Finished *time.Time `json:"finished,omitempty"`
+	path     string
+	size     int64
+}
+
+var timelapseJobs = make(map[string]*TimelapseJob)
+var timelapseJobsLock = sync.Mutex{}
+
+// Limit the number of timelapses rendered at once
+var timelapseWorkers = make(chan struct{}, 2)
+
+var errTimelapseQueueFull = errors.New("Too many timelapses are queued, retry later")
+
+func newTimelapseJobId() (string, error) {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(id), nil
+}
+
+// Remove expired jobs, then the oldest finished ones while their files are over maxTimelapseBytes
+func pruneTimelapseJobs() {
+	var stored int64
+	finished := make([]*TimelapseJob, 0, len(timelapseJobs))
+	for jobId, job := range timelapseJobs {
+		if job.Finished == nil {
+			continue
+		}
+		if time.Since(*job.Finished) > timelapseJobTTL {
+			os.Remove(job.path)
+			delete(timelapseJobs, jobId)
+			continue
+		}
+		stored += job.size
+		finished = append(finished, job)
+	}
+
+	sort.Slice(finished, func(i, j int) bool {
+		return finished[i].Finished.Before(*finished[j].Finished)
+	})
+	for _, job := range finished {
+		if stored <= maxTimelapseBytes {
+			break
+		}
+		os.Remove(job.path)
+		delete(timelapseJobs, job.JobId)
+		stored -= job.size
+	}
+}
+
+func unfinishedTimelapseJobs() int {
+	count := 0
+	for _, job := range timelapseJobs {
+		if job.Finished == nil {
+			count++
+		}
+	}
+	return count
+}
+
+// Parse the pixelsPerFrame & secondsPerFrame query params
+func parseFrameStep(r *http.Request, opts *render.TimelapseOptions) error {
+	var err error
+	if pixelsStr := r.URL.Query().Get("pixelsPerFrame"); pixelsStr != "" {
+		opts.PixelsPerFrame, err = strconv.Atoi(pixelsStr)
+		if err != nil || opts.PixelsPerFrame < 1 {
+			return fmt.Errorf("invalid pixelsPerFrame")
+		}
+	}
+	if secondsStr := r.URL.Query().Get("secondsPerFrame"); secondsStr != "" {
+		opts.SecondsPerFrame, err = strconv.ParseInt(secondsStr, 10, 64)
+		if err != nil || opts.SecondsPerFrame < 1 {
+			return fmt.Errorf("invalid secondsPerFrame")
+		}
+	}
+	return nil
+}
+
+// Start rendering a timelapse in the background & return its job id to poll
+// ex: curl -X POST "http://localhost:8080/create-timelapse?worldId=13&format=apng&pixelsPerFrame=100&scale=2"
+func createTimelapse(w http.ResponseWriter, r *http.Request) {
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = "gif"
+	}
+	if _, ok := render.TimelapseContentTypes[format]; !ok {
+		routeutils.WriteErrorJson(w, http.StatusBadRequest, "Invalid format, expected gif or apng")
+		return
+	}
+
+	source, err := render.SourceFromQuery(r.URL.Query().Get("worldId"), r.URL.Query().Get("round"))
+	if err != nil {
+		routeutils.WriteErrorJson(w, http.StatusNotFound, "World not found")
+		return
+	}
+
+	crop, renderOpts, err := parseRenderOptions(r, source)
+	if err != nil {
+		routeutils.WriteErrorJson(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	opts := render.TimelapseOptions{Crop: crop, Render: renderOpts}
+	opts.From, opts.To, err = parseTimeWindow(r)
+	if err != nil {
+		routeutils.WriteErrorJson(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := parseFrameStep(r, &opts); err != nil {
+		routeutils.WriteErrorJson(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if opts.PixelsPerFrame == 0 && opts.SecondsPerFrame == 0 {
+		routeutils.WriteErrorJson(w, http.StatusBadRequest, "Missing pixelsPerFrame or secondsPerFrame")
+		return
+	}
+	if delayStr := r.URL.Query().Get("delay"); delayStr != "" {
+		opts.FrameDelay, err = strconv.Atoi(delayStr)
+		if err != nil || opts.FrameDelay < 10 || opts.FrameDelay > 10000 {
+			routeutils.WriteErrorJson(w, http.StatusBadRequest, "delay must be between 10 and 10000 ms")
+			return
+		}
+	}
+
+	if !checkTimelapseSize(w, source, render.HistoryFilter{From: opts.From, To: opts.To}, opts) {
+		return
+	}
+
+	jobId, err := queueTimelapseJob(format, opts, source.BuildTimelapse)
+	if err != nil {
+		writeTimelapseJobError(w, err)
+		return
+	}
+	writeTimelapseJobId(w, jobId)
+}
+
+// Queue a timelapse built in the background by build, errTimelapseQueueFull when too many are waiting
+func queueTimelapseJob(format string, opts render.TimelapseOptions, build func(opts render.TimelapseOptions) (*render.Timelapse, error)) (string, error) {
+	jobId, err := newTimelapseJobId()
+	if err != nil {
+		return "", err
+	}
+	extension := "gif"
+	if format == "apng" {
+		extension = "png"
+	}
+	job := &TimelapseJob{
+		JobId:   jobId,
+		Format:  format,
+		Status:  "pending",
+		Created: time.Now(),
+		path:    filepath.Join(timelapseDir, jobId+"."+extension),
+	}
+
+	timelapseJobsLock.Lock()
+	pruneTimelapseJobs()
+	if unfinishedTimelapseJobs() >= maxQueuedTimelapses {
+		timelapseJobsLock.Unlock()
+		return "", errTimelapseQueueFull
+	}
+	timelapseJobs[jobId] = job
+	timelapseJobsLock.Unlock()
+
+	opts.Progress = func(replayed int, total int) {
+		timelapseJobsLock.Lock()
+		job.Replayed = replayed
+		job.Total = total
+		timelapseJobsLock.Unlock()
+	}
+	go runTimelapseJob(job, opts, build)
+	return jobId, nil
+}
+
+// Reject timelapses replaying too many placements or with too many frames before queueing them, false if an error was written
+func checkTimelapseSize(w http.ResponseWriter, source *render.Source, filter render.HistoryFilter, opts render.TimelapseOptions) bool {
+	count, err := source.CountPlacements(filter)
+	if err != nil {
+		routeutils.WriteErrorJson(w, http.StatusInternalServerError, "Failed to count placements")
+		return false
+	}
+	if count > render.MaxTimelapsePlacements {
+		routeutils.WriteErrorJson(w, http.StatusBadRequest, fmt.Sprintf("Timelapse replays more than %d placements, use a shorter time range", render.MaxTimelapsePlacements))
+		return false
+	}
+	if opts.PixelsPerFrame > 0 && count/opts.PixelsPerFrame >= render.MaxTimelapseFrames {
+		routeutils.WriteErrorJson(w, http.StatusBadRequest, fmt.Sprintf("Timelapse exceeds %d frames, use more pixels per frame", render.MaxTimelapseFrames))
+		return false
+	}
+	return true
+}
+
+func writeTimelapseJobError(w http.ResponseWriter, err error) {
+	if err == errTimelapseQueueFull {
+		routeutils.WriteErrorJson(w, http.StatusServiceUnavailable, err.Error())
+		return
+	}
+	routeutils.WriteErrorJson(w, http.StatusInternalServerError, "Failed to create timelapse job")
+}
+
+func writeTimelapseJobId(w http.ResponseWriter, jobId string) {
+	jobJson, err := json.Marshal(map[string]string{"jobId": jobId})
+	if err != nil {
+		routeutils.WriteErrorJson(w, http.StatusInternalServerError, "Failed to marshal timelapse job")
+		return
+	}
+	routeutils.WriteDataJson(w, string(jobJson))
+}
+
+func runTimelapseJob(job *TimelapseJob, opts render.TimelapseOptions, build func(opts render.TimelapseOptions) (*render.Timelapse, error)) {
+	timelapseWorkers <- struct{}{}
+	defer func() { <-timelapseWorkers }()
+
+	setStatus := func(status string, err error) {
+		timelapseJobsLock.Lock()
+		defer timelapseJobsLock.Unlock()
+		job.Status = status
+		if err != nil {
+			job.Error = err.Error()
+		}
+		if status == "done" || status == "failed" {
+			now := time.Now()
+			job.Finished = &now
+		}
+	}
+	setStatus("running", nil)
+
+	size, err := writeTimelapse(job.path, job.Format, opts, build)
+	if err == nil && size > maxTimelapseBytes {
+		err = fmt.Errorf("timelapse is over the %d bytes storage limit", maxTimelapseBytes)
+	}
+	if err != nil {
+		fmt.Println("Failed to generate timelapse", job.JobId, err)
+		os.Remove(job.path)
+		setStatus("failed", err)
+		return
+	}
+
+	timelapseJobsLock.Lock()
+	job.size = size
+	timelapseJobsLock.Unlock()
+	setStatus("done", nil)
+
+	timelapseJobsLock.Lock()
+	pruneTimelapseJobs()
+	timelapseJobsLock.Unlock()
+}
+
+// Build & encode the timelapse to path, returning the file size
+func writeTimelapse(path string, format string, opts render.TimelapseOptions, build func(opts render.TimelapseOptions) (*render.Timelapse, error)) (int64, error) {
+	timelapse, err := build(opts)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return 0, err
+	}
+	file, err := os.Create(path)
+	if err != nil {
+		return 0, err
+	}
+	defer file.Close()
+
+	if err := timelapse.Encode(file, format); err != nil {
+		return 0, err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		return 0, err
+	}
+	return info.Size(), nil
+}
+
+func lookupTimelapseJob(w http.ResponseWriter, r *http.Request) *TimelapseJob {
+	jobId := r.URL.Query().Get("jobId")
+	if jobId == "" {
+		routeutils.WriteErrorJson(w, http.StatusBadRequest, "Missing jobId")
+		return nil
+	}
+
+	timelapseJobsLock.Lock()
+	defer timelapseJobsLock.Unlock()
+	job, ok := timelapseJobs[jobId]
+	if !ok {
+		routeutils.WriteErrorJson(w, http.StatusNotFound, "Timelapse job not found")
+		return nil
+	}
+	jobCopy := *job
+	return &jobCopy
+}
+
+func getTimelapseStatus(w http.ResponseWriter, r *http.Request) {
+	job := lookupTimelapseJob(w, r)
+	if job == nil {
+		return
+	}
+
+	jobJson, err := json.Marshal(job)
+	if err != nil {
+		routeutils.WriteErrorJson(w, http.StatusInternalServerError, "Failed to marshal timelapse job")
+		return
+	}
+	routeutils.WriteDataJson(w, string(jobJson))
+}
+
+func getTimelapse(w http.ResponseWriter, r *http.Request) {
+	job := lookupTimelapseJob(w, r)
+	if job == nil {
+		return
+	}
+	if job.Status != "done" {
+		routeutils.WriteErrorJson(w, http.StatusConflict, "Timelapse is "+job.Status)
+		return
+	}
+
+	timelapse, err := os.ReadFile(job.path)
+	if err != nil {
+		routeutils.WriteErrorJson(w, http.StatusInternalServerError, "Failed to read timelapse")
+		return
+	}
+	routeutils.WriteImage(w, render.TimelapseContentTypes[job.Format], timelapse)
+}
@@ -8,39 +8,35 @@ import (
 	"strconv"
 	"time"
 
+	"github.com/keep-starknet-strange/art-peace/backend/auth"
 	"github.com/keep-starknet-strange/art-peace/backend/core"
 	routeutils "github.com/keep-starknet-strange/art-peace/backend/routes/utils"
 )
 
 func InitUserRoutes() {
-	http.HandleFunc("/get-user-vote", getUserColorVote)
-	http.HandleFunc("/get-username-store-address", getUsernameStoreAddress)
-	http.HandleFunc("/set-username-store-address", setUsernameStoreAddress)
-	http.HandleFunc("/get-last-placed-time", getLastPlacedTime)
-	http.HandleFunc("/get-chain-faction-pixels", getChainFactionPixels)
-	http.HandleFunc("/get-faction-pixels", getFactionPixels)
-	http.HandleFunc("/get-extra-pixels", getExtraPixels)
-	http.HandleFunc("/get-username", getUsername)
-	http.HandleFunc("/get-pixel-count", getPixelCount)
-	http.HandleFunc("/check-username-unique", checkUsernameUnique)
-	http.HandleFunc("/get-user-rewards", getUserRewards)
+	routeutils.Get("/get-user-vote", getUserColorVote)
+	routeutils.Get("/get-username-store-address", getUsernameStoreAddress)
+	routeutils.Post("/set-username-store-address", setUsernameStoreAddress, routeutils.RequirePermission(auth.PermissionSetup))
+	routeutils.Get("/get-last-placed-time", getLastPlacedTime)
+	routeutils.Get("/get-chain-faction-pixels", getChainFactionPixels)
+	routeutils.Get("/get-faction-pixels", getFactionPixels)
+	routeutils.Get("/get-extra-pixels", getExtraPixels)
+	routeutils.Get("/get-username", getUsername)
+	routeutils.Get("/get-pixel-count", getPixelCount)
+	routeutils.Get("/check-username-unique", checkUsernameUnique)
+	routeutils.Get("/get-user-rewards", getUserRewards)
 	if !core.ArtPeaceBackend.BackendConfig.Production {
-		http.HandleFunc("/new-username-devnet", newUsernameDevnet)
-		http.HandleFunc("/change-username-devnet", changeUsernameDevnet)
+		routeutils.Post("/new-username-devnet", newUsernameDevnet, routeutils.NonProduction)
+		routeutils.Post("/change-username-devnet", changeUsernameDevnet, routeutils.NonProduction)
 	}
 }
 
 func getUsernameStoreAddress(w http.ResponseWriter, r *http.Request) {
 	contractAddress := os.Getenv("USERNAME_STORE_CONTRACT_ADDRESS")
-	routeutils.WriteDataJson(w, "\""+contractAddress+"\"")
+	routeutils.WriteStringDataJson(w, contractAddress)
 }
 
 func setUsernameStoreAddress(w http.ResponseWriter, r *http.Request) {
-	// Only allow admin to set contract address
-	if routeutils.AdminMiddleware(w, r) {
-		return
-	}
-
 	data, err := io.ReadAll(r.Body)
 	if err != nil {
 		routeutils.WriteErrorJson(w, http.StatusBadRequest, "Failed to read request body")
@@ -118,7 +114,7 @@ func getUsername(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	routeutils.WriteDataJson(w, "\""+*name+"\"")
+	routeutils.WriteStringDataJson(w, *name)
 }
 
 func getPixelCount(w http.ResponseWriter, r *http.Request) {
@@ -151,15 +147,10 @@ func getLastPlacedTime(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Return the last placed time in utc z format
-	routeutils.WriteDataJson(w, "\""+string((*lastTime).UTC().Format(time.RFC3339))+"\"")
+	routeutils.WriteStringDataJson(w, string((*lastTime).UTC().Format(time.RFC3339)))
 }
 
 func newUsernameDevnet(w http.ResponseWriter, r *http.Request) {
-	// Disable this in production
-	if routeutils.NonProductionMiddleware(w, r) {
-		return
-	}
-
 	jsonBody, err := routeutils.ReadJsonBody[map[string]string](r)
 	if err != nil {
 		routeutils.WriteErrorJson(w, http.StatusBadRequest, "Invalid JSON request body")
@@ -192,11 +183,6 @@ func newUsernameDevnet(w http.ResponseWriter, r *http.Request) {
 }
 
 func changeUsernameDevnet(w http.ResponseWriter, r *http.Request) {
-	// Disable this in production
-	if routeutils.NonProductionMiddleware(w, r) {
-		return
-	}
-
 	jsonBody, err := routeutils.ReadJsonBody[map[string]string](r)
 	if err != nil {
 		routeutils.WriteErrorJson(w, http.StatusBadRequest, "Invalid JSON request body")
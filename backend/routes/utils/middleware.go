@@ -1,9 +1,14 @@
 package routeutils
 
 import (
+	"context"
+	"fmt"
 	"net/http"
+	"strings"
 
+	"github.com/keep-starknet-strange/art-peace/backend/auth"
 	"github.com/keep-starknet-strange/art-peace/backend/core"
+	"github.com/keep-starknet-strange/art-peace/backend/routes/response"
 )
 
 // Middleware functions for routes
@@ -11,24 +16,122 @@ import (
 
 func NonProductionMiddleware(w http.ResponseWriter, r *http.Request) bool {
 	if core.ArtPeaceBackend.BackendConfig.Production {
-		WriteErrorJson(w, http.StatusNotImplemented, "Route is disabled in production")
+		WriteErrorCodeJson(w, http.StatusNotImplemented, response.CodeProductionDisabled, "Route is disabled in production")
 		return true
 	}
 
 	return false
 }
 
+type sessionAddressKey struct{}
+
+// Session token from the Authorization: Bearer <token> header
+func SessionToken(r *http.Request) string {
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok {
+		return ""
+	}
+	return strings.TrimSpace(token)
+}
+
+// Address logged in with the request's session token, empty if there is none
+func SessionAddress(r *http.Request) string {
+	if address, ok := r.Context().Value(sessionAddressKey{}).(string); ok {
+		return address
+	}
+	address, err := auth.SessionAddress(SessionToken(r))
+	if err != nil {
+		fmt.Println("Failed to get session", err)
+		return ""
+	}
+	return address
+}
+
 func AuthMiddleware(w http.ResponseWriter, r *http.Request) bool {
-	// TODO: Implement authentication
+	if SessionAddress(r) == "" {
+		WriteErrorCodeJson(w, http.StatusUnauthorized, response.CodeAuthenticationRequired, "Authentication is required")
+		return true
+	}
+
 	return false
 }
 
-func AdminMiddleware(w http.ResponseWriter, r *http.Request) bool {
-	// TODO: Implement admin authentication
-	if core.ArtPeaceBackend.AdminMode {
-		return false
-	} else {
-		WriteErrorJson(w, http.StatusUnauthorized, "Admin is required")
-		return true
+// Requires a session & keeps its address on the request for SessionAddress
+func Auth(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		address := SessionAddress(r)
+		if address == "" {
+			WriteErrorCodeJson(w, http.StatusUnauthorized, response.CodeAuthenticationRequired, "Authentication is required")
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), sessionAddressKey{}, address)))
+	})
+}
+
+const ApiKeyHeader = "X-Api-Key"
+
+type apiKeyContextKey struct{}
+
+// Admin API key of the request, nil if there is none or it isn't active
+func RequestApiKey(r *http.Request) *auth.ApiKey {
+	if key, ok := r.Context().Value(apiKeyContextKey{}).(*auth.ApiKey); ok {
+		return key
+	}
+	key, err := auth.LookupApiKey(strings.TrimSpace(r.Header.Get(ApiKeyHeader)))
+	if err != nil {
+		fmt.Println("Failed to look up API key", err)
+		return nil
+	}
+	return key
+}
+
+// Records requests made with an API key in the admin audit log
+func AuditApiKey(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get(ApiKeyHeader) == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+		key := RequestApiKey(r)
+		writer := &statusWriter{ResponseWriter: w}
+		next.ServeHTTP(writer, r.WithContext(context.WithValue(r.Context(), apiKeyContextKey{}, key)))
+		recordAdminRequest(key, r, writer.status)
+	})
+}
+
+// Audit rows are limited per IP, so a client sending bad keys can't flood the log
+func recordAdminRequest(key *auth.ApiKey, r *http.Request, status int) {
+	if !allowAuditRecord(r) {
+		fmt.Println("Audit rate limit reached, not recording", r.Method, r.URL.Path, ClientIP(r))
+		return
+	}
+	auth.RecordAdminRequest(key, r.Method, r.URL.Path, r.URL.RawQuery, status, ClientIP(r))
+}
+
+// Requires an API key whose role has the permission, or the backend running in admin mode
+// Requests sending an API key are recorded in the admin audit log, including the denied ones, & so are admin mode requests
+func RequirePermission(permission auth.Permission) Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key := RequestApiKey(r)
+			writer := &statusWriter{ResponseWriter: w}
+			if core.ArtPeaceBackend.AdminMode || r.Header.Get(ApiKeyHeader) != "" {
+				defer func() {
+					recordAdminRequest(key, r, writer.status)
+				}()
+			}
+
+			if !core.ArtPeaceBackend.AdminMode {
+				if key == nil {
+					WriteErrorCodeJson(writer, http.StatusUnauthorized, response.CodeApiKeyRequired, "Admin API key is required")
+					return
+				}
+				if !key.Allows(permission) {
+					WriteErrorCodeJson(writer, http.StatusForbidden, response.CodePermissionDenied, "API key is missing the "+string(permission)+" permission")
+					return
+				}
+			}
+			next.ServeHTTP(writer, r.WithContext(context.WithValue(r.Context(), apiKeyContextKey{}, key)))
+		})
 	}
 }
@@ -0,0 +1,119 @@
+package routeutils
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/keep-starknet-strange/art-peace/backend/routes/response"
+)
+
+const (
+	DefaultPageLength = 25
+	MaxPageLength     = 50
+)
+
+// page & pageLength query params of list routes, or the cursor of the previous page
+// Cursors hold the sort values of the last item, so deep pages are read from the index & items inserted meanwhile don't shift them
+type Pagination struct {
+	Page       int
+	PageLength int
+	Cursor     string
+}
+
+func ParsePagination(r *http.Request) Pagination {
+	return ParsePaginationWithDefault(r, DefaultPageLength)
+}
+
+// For routes with a smaller default page
+func ParsePaginationWithDefault(r *http.Request, defaultPageLength int) Pagination {
+	pageLength, err := strconv.Atoi(r.URL.Query().Get("pageLength"))
+	if err != nil || pageLength <= 0 {
+		pageLength = defaultPageLength
+	}
+	if pageLength > MaxPageLength {
+		pageLength = MaxPageLength
+	}
+	cursor := r.URL.Query().Get("cursor")
+	if cursor != "" {
+		return Pagination{PageLength: pageLength, Cursor: cursor}
+	}
+	page, err := strconv.Atoi(r.URL.Query().Get("page"))
+	if err != nil || page <= 0 {
+		page = 1
+	}
+	return Pagination{Page: page, PageLength: pageLength}
+}
+
+// Pages after a cursor start right after it
+func (p Pagination) Offset() int {
+	if p.Cursor != "" {
+		return 0
+	}
+	return (p.Page - 1) * p.PageLength
+}
+
+// Queries fetch one more item than the page, to know if there is a next page
+func (p Pagination) Limit() int {
+	return p.PageLength + 1
+}
+
+// Read the cursor param into the route's cursor, which keeps the values it starts with when paging by number
+func (p Pagination) ParseCursor(cursor interface{}) error {
+	if p.Cursor == "" {
+		return nil
+	}
+	cursorJson, err := base64.RawURLEncoding.DecodeString(p.Cursor)
+	if err != nil {
+		return err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(cursorJson))
+	decoder.DisallowUnknownFields()
+	return decoder.Decode(cursor)
+}
+
+// Cursors are opaque to clients, only the route reading them knows their fields
+func EncodeCursor(cursor interface{}) string {
+	cursorJson, err := json.Marshal(cursor)
+	if err != nil {
+		return ""
+	}
+	return base64.RawURLEncoding.EncodeToString(cursorJson)
+}
+
+// Write a page of items from a JSON array, with its pagination metadata
+// The extra item fetched because of Limit is dropped, & the next cursor is built from the last item kept
+func WritePageJson[Item any](w http.ResponseWriter, items []byte, pagination Pagination, cursor func(last Item) interface{}) {
+	var list []json.RawMessage
+	if err := json.Unmarshal(items, &list); err != nil || list == nil {
+		list = make([]json.RawMessage, 0)
+	}
+
+	meta := &response.Pagination{
+		Page:       pagination.Page,
+		PageLength: pagination.PageLength,
+	}
+	if len(list) > pagination.PageLength {
+		list = list[:pagination.PageLength]
+		meta.HasNext = true
+		if pagination.Cursor == "" {
+			nextPage := pagination.Page + 1
+			meta.NextPage = &nextPage
+		}
+		var last Item
+		if err := json.Unmarshal(list[len(list)-1], &last); err == nil {
+			meta.NextCursor = EncodeCursor(cursor(last))
+		}
+	}
+	meta.Count = len(list)
+
+	SetupHeaders(w)
+	w.WriteHeader(http.StatusOK)
+	w.Write(response.Marshal(response.Data{
+		Data:       response.Marshal(list),
+		Pagination: meta,
+		RequestId:  w.Header().Get(RequestIdHeader),
+	}))
+}
@@ -0,0 +1,142 @@
+package routeutils
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/keep-starknet-strange/art-peace/backend/config"
+	"github.com/keep-starknet-strange/art-peace/backend/core"
+	"github.com/keep-starknet-strange/art-peace/backend/routes/response"
+)
+
+// Route groups with their own limits in the backend config's rate_limits
+const (
+	// Canvas renders : exports, heatmaps, diffs, highlights & timelapses
+	RateLimitRender = "render"
+	// Aggregate queries : hot & top rankings, leaderboards
+	RateLimitHeavyReads = "heavy_reads"
+	// Image uploads written to disk
+	RateLimitUploads = "uploads"
+	// Login nonces & signatures
+	RateLimitAuth = "auth"
+	// Admin audit log rows, requests over it are served but not recorded
+	RateLimitAudit = "audit"
+)
+
+// Token bucket kept in Redis, so every backend replica shares it
+// Refills continuously up to burst, using the Redis clock so replica clocks don't matter
+// Returns allowed, tokens left, ms until the next token & ms until the bucket is full
+var tokenBucketScript = redis.NewScript(`
+local burst = tonumber(ARGV[1])
+local rate = tonumber(ARGV[2])
+local time = redis.call('TIME')
+local now = tonumber(time[1]) * 1000 + math.floor(tonumber(time[2]) / 1000)
+
+local bucket = redis.call('HMGET', KEYS[1], 'tokens', 'time')
+local tokens = tonumber(bucket[1]) or burst
+local last = tonumber(bucket[2]) or now
+tokens = math.min(burst, tokens + math.max(0, now - last) * rate)
+
+local allowed = 0
+if tokens >= 1 then
+  tokens = tokens - 1
+  allowed = 1
+end
+
+local full = math.ceil((burst - tokens) / rate)
+redis.call('HSET', KEYS[1], 'tokens', tostring(tokens), 'time', now)
+redis.call('PEXPIRE', KEYS[1], full + 1000)
+
+local retry = 0
+if allowed == 0 then
+  retry = math.ceil((1 - tokens) / rate)
+end
+return {allowed, math.floor(tokens), retry, full}
+`)
+
+// Limit the requests of a route group per IP, & per address as well for logged in clients
+// Logging in doesn't give a fresh budget, as new accounts only cost a signature
+// Groups missing from the config aren't limited, clients are let through if Redis fails
+func RateLimit(group string) Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			limit, ok := core.ArtPeaceBackend.BackendConfig.RateLimits[group]
+			if !ok || limit.Burst <= 0 || limit.PerMinute <= 0 {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			keys := []string{"rate-limit-" + group + "-ip-" + ClientIP(r)}
+			if address := SessionAddress(r); address != "" {
+				keys = append(keys, "rate-limit-"+group+"-address-"+address)
+			}
+			// Headers describe the bucket with the fewest tokens, or the one that refused the request
+			var result []int64
+			for _, key := range keys {
+				bucket, err := takeToken(limit, key)
+				if err != nil {
+					fmt.Println("Failed to check rate limit", group, err)
+					continue
+				}
+				if result == nil || bucket[0] == 0 || bucket[1] < result[1] {
+					result = bucket
+				}
+				if bucket[0] == 0 {
+					break
+				}
+			}
+			if result == nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+			allowed, remaining, retryMs, fullMs := result[0] == 1, result[1], result[2], result[3]
+
+			w.Header().Set("RateLimit-Limit", strconv.Itoa(limit.Burst))
+			w.Header().Set("RateLimit-Remaining", strconv.FormatInt(remaining, 10))
+			w.Header().Set("RateLimit-Reset", strconv.FormatInt(msToSeconds(fullMs), 10))
+			w.Header().Set("RateLimit-Policy", fmt.Sprintf("%d;w=60;burst=%d", limit.PerMinute, limit.Burst))
+			if !allowed {
+				w.Header().Set("Retry-After", strconv.FormatInt(msToSeconds(retryMs), 10))
+				WriteErrorCodeJson(w, http.StatusTooManyRequests, response.CodeRateLimited, "Too many requests")
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// Allowed, tokens left, ms until the next token & ms until the bucket is full
+func takeToken(limit config.RateLimitConfig, key string) ([]int64, error) {
+	rate := float64(limit.PerMinute) / 60000
+	result, err := tokenBucketScript.Run(context.Background(), core.ArtPeaceBackend.Databases.Redis, []string{key}, limit.Burst, rate).Int64Slice()
+	if err != nil {
+		return nil, err
+	}
+	if len(result) != 4 {
+		return nil, fmt.Errorf("unexpected token bucket result %v", result)
+	}
+	return result, nil
+}
+
+// Whether the client's audit limit allows recording one more of its requests
+func allowAuditRecord(r *http.Request) bool {
+	limit, ok := core.ArtPeaceBackend.BackendConfig.RateLimits[RateLimitAudit]
+	if !ok || limit.Burst <= 0 || limit.PerMinute <= 0 {
+		return true
+	}
+	result, err := takeToken(limit, "rate-limit-"+RateLimitAudit+"-ip-"+ClientIP(r))
+	if err != nil {
+		fmt.Println("Failed to check rate limit", RateLimitAudit, err)
+		return true
+	}
+	return result[0] == 1
+}
+
+func msToSeconds(ms int64) int64 {
+	return int64(math.Ceil(float64(ms) / 1000))
+}
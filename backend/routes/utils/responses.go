@@ -4,11 +4,10 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
-	"strconv"
 	"strings"
 
-	"github.com/gorilla/websocket"
 	"github.com/keep-starknet-strange/art-peace/backend/core"
+	"github.com/keep-starknet-strange/art-peace/backend/routes/response"
 )
 
 func SetupAccessHeaders(w http.ResponseWriter) {
@@ -23,6 +22,9 @@ func SetupAccessHeaders(w http.ResponseWriter) {
 
 	headers := strings.Join(config.AllowHeaders, ", ")
 	w.Header().Set("Access-Control-Allow-Headers", headers)
+
+	// Response headers readable by browsers on other origins
+	w.Header().Set("Access-Control-Expose-Headers", "X-Request-Id, RateLimit-Limit, RateLimit-Remaining, RateLimit-Reset, RateLimit-Policy, Retry-After")
 }
 
 func SetupHeaders(w http.ResponseWriter) {
@@ -31,88 +33,82 @@ func SetupHeaders(w http.ResponseWriter) {
 }
 
 func BasicErrorJson(err string) []byte {
-	return []byte(`{"error": "` + err + `"}`)
+	return response.Marshal(response.Error{Error: err, Code: response.CodeBadRequest})
 }
 
 func WriteErrorJson(w http.ResponseWriter, errCode int, err string) {
+	WriteErrorCodeJson(w, errCode, response.CodeForStatus(errCode), err)
+}
+
+// Error with a code more specific than the status' default one
+func WriteErrorCodeJson(w http.ResponseWriter, errCode int, code response.ErrorCode, err string) {
 	SetupHeaders(w)
 	w.WriteHeader(errCode)
-	w.Write(BasicErrorJson(err))
+	w.Write(response.Marshal(response.Error{
+		Error:     err,
+		Code:      code,
+		RequestId: w.Header().Get(RequestIdHeader),
+	}))
 }
 
 func BasicResultJson(result string) []byte {
-	return []byte(`{"result": "` + result + `"}`)
+	return response.Marshal(response.Result{Result: result})
 }
 
 func WriteResultJson(w http.ResponseWriter, result string) {
 	SetupHeaders(w)
 	w.WriteHeader(http.StatusOK)
-	w.Write(BasicResultJson(result))
+	w.Write(response.Marshal(response.Result{
+		Result:    result,
+		RequestId: w.Header().Get(RequestIdHeader),
+	}))
 }
 
+// Data is JSON, like the output of core.PostgresQueryJson
 func BasicDataJson(data string) []byte {
-	return []byte(`{"data": ` + data + `}`)
+	return response.Marshal(response.Data{Data: response.RawData(data)})
 }
 
 func WriteDataJson(w http.ResponseWriter, data string) {
 	SetupHeaders(w)
 	w.WriteHeader(http.StatusOK)
-	w.Write(BasicDataJson(data))
+	w.Write(response.Marshal(response.Data{
+		Data:      response.RawData(data),
+		RequestId: w.Header().Get(RequestIdHeader),
+	}))
+}
+
+// Data that is a single string, escaped
+func WriteStringDataJson(w http.ResponseWriter, data string) {
+	WriteDataJson(w, string(response.Marshal(data)))
 }
 
-func SendWebSocketMessage(message map[string]string) {
+// Queue a message for a single client
+func WriteWebSocketMessage(client *core.WSClient, message interface{}) error {
 	messageBytes, err := json.Marshal(message)
 	if err != nil {
-		fmt.Println("Failed to marshal websocket message")
-		return
+		return err
 	}
-	core.ArtPeaceBackend.WSConnectionsLock.Lock()
-	for idx, conn := range core.ArtPeaceBackend.WSConnections {
-		if err := conn.WriteMessage(websocket.TextMessage, messageBytes); err != nil {
-			fmt.Println(err)
-			// Remove problematic connection
-			conn.Close()
-			if idx < len(core.ArtPeaceBackend.WSConnections) {
-				core.ArtPeaceBackend.WSConnections = append(core.ArtPeaceBackend.WSConnections[:idx], core.ArtPeaceBackend.WSConnections[idx+1:]...)
-			} else {
-				core.ArtPeaceBackend.WSConnections = core.ArtPeaceBackend.WSConnections[:idx]
-			}
-		}
+	if !client.Send(messageBytes) {
+		return fmt.Errorf("websocket client evicted")
 	}
-	core.ArtPeaceBackend.WSConnectionsLock.Unlock()
+	return nil
 }
 
 func SendWebSocketMessages(messages []map[string]string) {
-	messageBytes, err := json.Marshal(messages)
-	if err != nil {
-		fmt.Println("Failed to marshal websocket message")
-		return
-	}
-	core.ArtPeaceBackend.WSConnectionsLock.Lock()
-	for idx, conn := range core.ArtPeaceBackend.WSConnections {
-		if err := conn.WriteMessage(websocket.TextMessage, messageBytes); err != nil {
-			fmt.Println(err)
-			// Remove problematic connection
-			conn.Close()
-			if idx < len(core.ArtPeaceBackend.WSConnections) {
-				core.ArtPeaceBackend.WSConnections = append(core.ArtPeaceBackend.WSConnections[:idx], core.ArtPeaceBackend.WSConnections[idx+1:]...)
-			} else {
-				core.ArtPeaceBackend.WSConnections = core.ArtPeaceBackend.WSConnections[:idx]
-			}
-		}
-	}
-	core.ArtPeaceBackend.WSConnectionsLock.Unlock()
+	core.ArtPeaceBackend.WSHub.BroadcastMessages(messages)
 }
 
+// Publish a message to every websocket server
 func SendMessageToWSS(message map[string]string) {
-	websocketHost := core.ArtPeaceBackend.BackendConfig.WsHost + ":" + strconv.Itoa(core.ArtPeaceBackend.BackendConfig.WsPort) + "/ws-msg"
-	messageBytes, err := json.Marshal(message)
-	if err != nil {
-		fmt.Println("Failed to marshal websocket message")
-		return
-	}
-	_, err = http.Post("http://"+websocketHost, "application/json", strings.NewReader(string(messageBytes)))
-	if err != nil {
-		fmt.Println("Failed to send message to websocket server", err)
+	if err := core.PublishWSMessage(message); err != nil {
+		fmt.Println("Failed to publish websocket message", err)
 	}
 }
+
+func WriteImage(w http.ResponseWriter, contentType string, image []byte) {
+	SetupAccessHeaders(w)
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(http.StatusOK)
+	w.Write(image)
+}
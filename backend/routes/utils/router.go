@@ -0,0 +1,345 @@
+package routeutils
+
+import (
+	"bufio"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/keep-starknet-strange/art-peace/backend/core"
+)
+
+// Middleware wraps a handler, a route's chain runs in the order it is given
+type Middleware func(http.Handler) http.Handler
+
+// Router registers routes on a ServeMux with their methods & middleware
+// Path parameters use the ServeMux syntax, ex: /worlds/{worldId}/canvas
+type Router struct {
+	mux *http.ServeMux
+
+	lock sync.Mutex
+	// Applied to every route, before the route's own middleware
+	middleware []Middleware
+	// Methods registered for each path, for OPTIONS & Allow headers
+	methods map[string][]string
+}
+
+func NewRouter(mux *http.ServeMux) *Router {
+	return &Router{
+		mux:        mux,
+		middleware: []Middleware{RequestId, Recovery, Logging, Cors},
+		methods:    make(map[string][]string),
+	}
+}
+
+var DefaultRouter = NewRouter(http.DefaultServeMux)
+
+// Add middleware to every route, including the ones already registered
+func (rt *Router) Use(middleware ...Middleware) {
+	rt.lock.Lock()
+	rt.middleware = append(rt.middleware, middleware...)
+	rt.lock.Unlock()
+}
+
+var pathParamRegexp = regexp.MustCompile(`\{([A-Za-z0-9_]+)(?:\.\.\.)?\}`)
+
+func (rt *Router) Handle(method string, path string, handler http.Handler, middleware ...Middleware) {
+	rt.lock.Lock()
+	defer rt.lock.Unlock()
+
+	if _, ok := rt.methods[path]; !ok {
+		rt.mux.Handle(http.MethodOptions+" "+path, rt.chain(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Allow", rt.allow(path))
+			w.WriteHeader(http.StatusNoContent)
+		}), nil))
+	}
+	rt.methods[path] = append(rt.methods[path], method)
+
+	// Path parameters are also set as query parameters, so handlers reading the query work on both paths
+	params := make([]string, 0)
+	for _, match := range pathParamRegexp.FindAllStringSubmatch(path, -1) {
+		params = append(params, match[1])
+	}
+	if len(params) > 0 {
+		handler = pathParamsToQuery(params, handler)
+	}
+
+	rt.mux.Handle(method+" "+path, rt.chain(handler, middleware))
+}
+
+// The chain is built on the first request, so middleware added with Use after registering applies
+func (rt *Router) chain(handler http.Handler, middleware []Middleware) http.Handler {
+	var once sync.Once
+	var chained http.Handler
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		once.Do(func() {
+			rt.lock.Lock()
+			all := append(append([]Middleware{}, rt.middleware...), middleware...)
+			rt.lock.Unlock()
+
+			chained = handler
+			for idx := len(all) - 1; idx >= 0; idx-- {
+				chained = all[idx](chained)
+			}
+		})
+		chained.ServeHTTP(w, r)
+	})
+}
+
+func (rt *Router) allow(path string) string {
+	rt.lock.Lock()
+	defer rt.lock.Unlock()
+	methods := append([]string{http.MethodOptions}, rt.methods[path]...)
+	for _, method := range rt.methods[path] {
+		if method == http.MethodGet {
+			methods = append(methods, http.MethodHead)
+		}
+	}
+	sort.Strings(methods)
+	return strings.Join(methods, ", ")
+}
+
+// Registered routes sorted by path, for the OpenAPI document
+type Route struct {
+	Method string
+	Path   string
+}
+
+func (rt *Router) Routes() []Route {
+	rt.lock.Lock()
+	defer rt.lock.Unlock()
+	routes := make([]Route, 0)
+	for path, methods := range rt.methods {
+		for _, method := range methods {
+			routes = append(routes, Route{Method: method, Path: path})
+		}
+	}
+	sort.Slice(routes, func(i, j int) bool {
+		if routes[i].Path == routes[j].Path {
+			return routes[i].Method < routes[j].Method
+		}
+		return routes[i].Path < routes[j].Path
+	})
+	return routes
+}
+
+// OpenAPI path of a route's pattern, ex: /{$} -> / & /worlds/ -> /worlds/{file}
+// Prefix patterns serve files under them, so they get a file parameter
+func OpenAPIPath(path string) string {
+	path = strings.ReplaceAll(path, "{$}", "")
+	path = strings.ReplaceAll(path, "...}", "}")
+	if path == "" {
+		return "/"
+	}
+	if path != "/" && strings.HasSuffix(path, "/") {
+		return path + "{file}"
+	}
+	return path
+}
+
+func pathParamsToQuery(params []string, handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		for _, param := range params {
+			query.Set(param, r.PathValue(param))
+		}
+		r.URL.RawQuery = query.Encode()
+		handler.ServeHTTP(w, r)
+	})
+}
+
+func (rt *Router) Get(path string, handler http.HandlerFunc, middleware ...Middleware) {
+	rt.Handle(http.MethodGet, path, handler, middleware...)
+}
+
+func (rt *Router) Post(path string, handler http.HandlerFunc, middleware ...Middleware) {
+	rt.Handle(http.MethodPost, path, handler, middleware...)
+}
+
+func Handle(method string, path string, handler http.Handler, middleware ...Middleware) {
+	DefaultRouter.Handle(method, path, handler, middleware...)
+}
+
+func Get(path string, handler http.HandlerFunc, middleware ...Middleware) {
+	DefaultRouter.Get(path, handler, middleware...)
+}
+
+func Post(path string, handler http.HandlerFunc, middleware ...Middleware) {
+	DefaultRouter.Post(path, handler, middleware...)
+}
+
+func Routes() []Route {
+	return DefaultRouter.Routes()
+}
+
+func Use(middleware ...Middleware) {
+	DefaultRouter.Use(middleware...)
+}
+
+// Turn a bool returning middleware check into a Middleware
+func FromCheck(check func(w http.ResponseWriter, r *http.Request) bool) Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if check(w, r) {
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+var NonProduction = FromCheck(NonProductionMiddleware)
+
+func Cors(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		SetupAccessHeaders(w)
+		next.ServeHTTP(w, r)
+	})
+}
+
+const RequestIdHeader = "X-Request-Id"
+
+var requestIdRegexp = regexp.MustCompile(`^[A-Za-z0-9._-]{1,64}$`)
+
+// Keeps the caller's request id when it is safe to log, otherwise generates one
+// The id is set on the response before the handler runs, so responses can include it
+func RequestId(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestId := r.Header.Get(RequestIdHeader)
+		if !requestIdRegexp.MatchString(requestId) {
+			idBytes := make([]byte, 16)
+			rand.Read(idBytes)
+			requestId = hex.EncodeToString(idBytes)
+			r.Header.Set(RequestIdHeader, requestId)
+		}
+		w.Header().Set(RequestIdHeader, requestId)
+		next.ServeHTTP(w, r)
+	})
+}
+
+func Recovery(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer func() {
+			if err := recover(); err != nil {
+				fmt.Println("Recovered from panic in", r.Method, r.URL.Path, err)
+				WriteErrorJson(w, http.StatusInternalServerError, "Internal server error")
+			}
+		}()
+		next.ServeHTTP(w, r)
+	})
+}
+
+// Keeps the status for logging, while still allowing websocket upgrades & event streams
+type statusWriter struct {
+	http.ResponseWriter
+	status int
+}
+
+func (w *statusWriter) WriteHeader(status int) {
+	if w.status == 0 {
+		w.status = status
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *statusWriter) Write(data []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *statusWriter) Flush() {
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+func (w *statusWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hijacker, ok := w.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, fmt.Errorf("response writer does not support hijacking")
+	}
+	w.status = http.StatusSwitchingProtocols
+	return hijacker.Hijack()
+}
+
+func (w *statusWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+func Logging(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		writer := &statusWriter{ResponseWriter: w}
+		next.ServeHTTP(writer, r)
+		fmt.Println(r.Method, r.URL.Path, writer.status, time.Since(start), r.Header.Get(RequestIdHeader))
+	})
+}
+
+// Address of the client, X-Forwarded-For is only read when the request comes from a trusted proxy
+// Its entries are read right to left & the first one not added by a trusted proxy is the client, entries on its left are client provided
+func ClientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	trusted := trustedProxies()
+	if !isTrustedProxy(host, trusted) {
+		return host
+	}
+
+	var hops []string
+	for _, forwarded := range r.Header.Values("X-Forwarded-For") {
+		hops = append(hops, strings.Split(forwarded, ",")...)
+	}
+	for i := len(hops) - 1; i >= 0; i-- {
+		hop := strings.TrimSpace(hops[i])
+		if hop == "" {
+			continue
+		}
+		if !isTrustedProxy(hop, trusted) {
+			return hop
+		}
+		host = hop
+	}
+	// Every hop is a trusted proxy
+	return host
+}
+
+func trustedProxies() []netip.Prefix {
+	if core.ArtPeaceBackend == nil {
+		return nil
+	}
+	var prefixes []netip.Prefix
+	for _, proxy := range core.ArtPeaceBackend.BackendConfig.Http.TrustedProxies {
+		if prefix, err := netip.ParsePrefix(proxy); err == nil {
+			prefixes = append(prefixes, prefix.Masked())
+		} else if addr, err := netip.ParseAddr(proxy); err == nil {
+			prefixes = append(prefixes, netip.PrefixFrom(addr, addr.BitLen()))
+		}
+	}
+	return prefixes
+}
+
+func isTrustedProxy(ip string, trusted []netip.Prefix) bool {
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return false
+	}
+	addr = addr.Unmap()
+	for _, prefix := range trusted {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
@@ -7,6 +7,7 @@ import (
 	"os/exec"
 	"strconv"
 
+	"github.com/keep-starknet-strange/art-peace/backend/auth"
 	"github.com/keep-starknet-strange/art-peace/backend/core"
 	routeutils "github.com/keep-starknet-strange/art-peace/backend/routes/utils"
 )
@@ -18,19 +19,14 @@ type VotableColor struct {
 }
 
 func InitVotableColorsRoutes() {
-	http.HandleFunc("/init-votable-colors", InitVotableColors)
-	http.HandleFunc("/votable-colors", GetVotableColorsWithVoteCount)
+	routeutils.Post("/init-votable-colors", InitVotableColors, routeutils.RequirePermission(auth.PermissionSetup))
+	routeutils.Get("/votable-colors", GetVotableColorsWithVoteCount)
 	if !core.ArtPeaceBackend.BackendConfig.Production {
-		http.HandleFunc("/vote-color-devnet", voteColorDevnet)
+		routeutils.Post("/vote-color-devnet", voteColorDevnet, routeutils.NonProduction)
 	}
 }
 
 func InitVotableColors(w http.ResponseWriter, r *http.Request) {
-	// Only allow admin to initialize votable colors
-	if routeutils.AdminMiddleware(w, r) {
-		return
-	}
-
 	// TODO: Make sure Votable colors is not present in Color Table
 	// TODO: Check if votable colors are already initialized
 	colors, err := routeutils.ReadJsonBody[[]ColorType](r)
@@ -93,10 +89,6 @@ func GetVotableColorsWithVoteCount(w http.ResponseWriter, r *http.Request) {
 }
 
 func voteColorDevnet(w http.ResponseWriter, r *http.Request) {
-	if routeutils.NonProductionMiddleware(w, r) {
-		return
-	}
-
 	jsonBody, err := routeutils.ReadJsonBody[map[string]int](r)
 	if err != nil {
 		routeutils.WriteErrorJson(w, http.StatusBadRequest, "Failed to read request body")
@@ -1,8 +1,14 @@
 package routes
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
+	"os"
+	"sort"
+	"strconv"
+	"sync"
 	"time"
 
 	"github.com/gorilla/websocket"
@@ -11,47 +17,133 @@ import (
 	routeutils "github.com/keep-starknet-strange/art-peace/backend/routes/utils"
 )
 
-var WsMsgPool []map[string]string
+// Control messages sent by clients over the websocket
+type wsClientMessage struct {
+	MessageType string  `json:"messageType"`
+	WorldId     int     `json:"worldId"`
+	From        int64   `json:"from"`
+	To          int64   `json:"to"`
+	Speed       float64 `json:"speed"`
+	Time        int64   `json:"time"`
+	// Topics of subscribe & unsubscribe messages
+	Topics []core.WSTopic `json:"topics"`
+	// Presence & viewport updates
+	Viewport *presenceRect  `json:"viewport"`
+	Cursor   *presencePoint `json:"cursor"`
+	// Chat messages
+	Text string `json:"text"`
+	// Session token of authenticate messages
+	Token string `json:"token"`
+}
 
-func InitWebsocketRoutes() {
-	http.HandleFunc("/ws", wsEndpoint)
-	http.HandleFunc("/ws-msg", wsMsgEndpoint)
+// Messages from the stream waiting for the next flush, with their stream ids to acknowledge
+type wsMessagePool struct {
+	lock     sync.Mutex
+	messages []map[string]string
+	ids      []string
 }
 
-func wsMsgEndpoint(w http.ResponseWriter, r *http.Request) {
-	// TODO: Only allow consumer to send messages
-	msg, err := routeutils.ReadJsonBody[map[string]string](r)
-	if err != nil {
-		routeutils.WriteErrorJson(w, http.StatusBadRequest, "Invalid request body")
-		return
-	}
+func (p *wsMessagePool) add(message core.WSStreamMessage) {
+	p.lock.Lock()
+	p.messages = append(p.messages, message.Message)
+	p.ids = append(p.ids, message.Id)
+	p.lock.Unlock()
+}
+
+func (p *wsMessagePool) take() ([]map[string]string, []string) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	messages, ids := p.messages, p.ids
+	p.messages, p.ids = nil, nil
+	return messages, ids
+}
 
-	WsMsgPool = append(WsMsgPool, *msg)
-	routeutils.WriteResultJson(w, "WS message added to queue")
+var WsMsgPool = &wsMessagePool{}
+
+func InitWebsocketRoutes() {
+	routeutils.Get("/ws", wsEndpoint)
+	routeutils.Get("/world-presence", getWorldPresence)
+	routeutils.Get("/worlds/{worldId}/presence", getWorldPresence)
 }
 
+const wsConsumerRetryDelay = 5 * time.Second
+
 func StartWebsocketServer() {
+	stream, err := core.NewWSStreamConsumer()
+	for err != nil {
+		if errors.Is(err, core.ErrMissingReplicaId) {
+			fmt.Println("Failed to start websocket server:", err)
+			os.Exit(1)
+		}
+		// Redis may still be starting
+		fmt.Println("Failed to create websocket messages consumer, retrying", err)
+		time.Sleep(wsConsumerRetryDelay)
+		stream, err = core.NewWSStreamConsumer()
+	}
+	// Collect the messages published by the consumer & the backend for the next flush
+	go stream.Read(WsMsgPool.add)
+
 	// Send all messages in the pool every 5 seconds
 	timer := 5
 	for {
-		msgPoolCopy := make([]map[string]string, len(WsMsgPool))
-		copy(msgPoolCopy, WsMsgPool)
-		WsMsgPool = WsMsgPool[:0]
-		routeutils.SendWebSocketMessages(msgPoolCopy)
+		messages, ids := WsMsgPool.take()
+		if len(messages) > 0 {
+			// Publishers may race between taking a sequence id & publishing
+			sort.SliceStable(messages, func(i, j int) bool {
+				return core.MessageSeq(messages[i]) < core.MessageSeq(messages[j])
+			})
+			routeutils.SendWebSocketMessages(messages)
+			stream.Ack(ids...)
+		}
 		time.Sleep(time.Duration(timer) * time.Second)
 	}
 }
 
-func wsReader(conn *websocket.Conn) {
+// ip limits the replays the client can run
+func wsReader(client *core.WSClient, ip string) {
+	var replay *replaySession
+	// Session token sent with an authenticate message, checked again on each use so logouts apply
+	var session string
+	defer func() {
+		if replay != nil {
+			replay.close()
+		}
+		Presence.remove(client)
+		client.Close()
+	}()
+
 	for {
-		// TODO: exit on close in backend?
-		// TODO: handle different message types
-		messageType, p, err := conn.ReadMessage()
+		// Fails once the client closes or misses its pongs
+		messageType, p, err := client.Conn.ReadMessage()
 		if err != nil {
-			fmt.Println(err)
+			if websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway, websocket.CloseNormalClosure) {
+				fmt.Println(err)
+			}
 			return
 		}
-		fmt.Println("WS message received: ", messageType, string(p))
+		if messageType != websocket.TextMessage {
+			continue
+		}
+
+		var msg wsClientMessage
+		if err := json.Unmarshal(p, &msg); err != nil {
+			fmt.Println("WS message received: ", messageType, string(p))
+			continue
+		}
+		switch msg.MessageType {
+		case "replayStart", "replayStop", "replayPause", "replayResume", "replaySeek", "replaySpeed":
+			replay = handleReplayMessage(client, ip, replay, &msg)
+		case "subscribe", "unsubscribe", "unsubscribeAll":
+			handleSubscriptionMessage(client, &msg)
+		case "presence", "viewport":
+			handlePresenceMessage(client, session, &msg)
+		case "authenticate":
+			session = handleAuthenticateMessage(client, &msg)
+		case "chatSend":
+			handleChatMessage(client, session, &msg)
+		default:
+			fmt.Println("WS message received: ", messageType, string(p))
+		}
 	}
 }
 
@@ -59,6 +151,9 @@ func wsEndpoint(w http.ResponseWriter, r *http.Request) {
 	upgrader := websocket.Upgrader{
 		ReadBufferSize:  core.ArtPeaceBackend.BackendConfig.WebSocket.ReadBufferSize,
 		WriteBufferSize: core.ArtPeaceBackend.BackendConfig.WebSocket.WriteBufferSize,
+		// Clients not asking for a subprotocol keep the JSON protocol
+		Subprotocols:      []string{core.BinaryProtocol, core.JsonProtocol},
+		EnableCompression: core.ArtPeaceBackend.BackendConfig.WebSocket.EnableCompression,
 	}
 	upgrader.CheckOrigin = func(r *http.Request) bool { return true }
 
@@ -68,8 +163,40 @@ func wsEndpoint(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	core.ArtPeaceBackend.WSConnectionsLock.Lock()
-	core.ArtPeaceBackend.WSConnections = append(core.ArtPeaceBackend.WSConnections, ws)
-	core.ArtPeaceBackend.WSConnectionsLock.Unlock()
-	wsReader(ws)
+	// Reconnecting clients pass the last sequence id they received to get the messages they missed
+	lastSeq, err := strconv.ParseUint(r.URL.Query().Get("lastSeq"), 10, 64)
+	if err != nil {
+		lastSeq = 0
+	}
+
+	// World shown by the client, so a resync points it to that world's canvas
+	worldId, err := strconv.Atoi(r.URL.Query().Get("worldId"))
+	if err != nil || worldId < 0 {
+		worldId = 0
+	}
+
+	wsReader(core.ArtPeaceBackend.WSHub.Register(ws, lastSeq, worldId), routeutils.ClientIP(r))
+}
+
+func handleSubscriptionMessage(client *core.WSClient, msg *wsClientMessage) {
+	switch msg.MessageType {
+	case "subscribe":
+		if err := client.Subscriptions.Subscribe(msg.Topics); err != nil {
+			routeutils.WriteWebSocketMessage(client, map[string]string{
+				"messageType": "subscriptionError",
+				"error":       err.Error(),
+			})
+			return
+		}
+	case "unsubscribe":
+		client.Subscriptions.Unsubscribe(msg.Topics)
+	case "unsubscribeAll":
+		client.Subscriptions.UnsubscribeAll()
+	}
+
+	// Echo the current topics so clients know what they will receive
+	routeutils.WriteWebSocketMessage(client, map[string]interface{}{
+		"messageType": "subscriptions",
+		"topics":      client.Subscriptions.Topics(),
+	})
 }
@@ -10,44 +10,47 @@ import (
 	"strings"
 	"time"
 
+	"github.com/keep-starknet-strange/art-peace/backend/auth"
 	"github.com/keep-starknet-strange/art-peace/backend/core"
 	routeutils "github.com/keep-starknet-strange/art-peace/backend/routes/utils"
 )
 
 // TODO: check-worlds-name-unique?
 func InitWorldsRoutes() {
-	http.HandleFunc("/get-world-canvas", getWorldCanvas)
-	http.HandleFunc("/get-world-id", getWorldId)
-	http.HandleFunc("/get-world", getWorld)
-	http.HandleFunc("/get-worlds", getWorlds)
-	http.HandleFunc("/get-home-worlds", getHomeWorlds)
-	http.HandleFunc("/get-new-worlds", getNewWorlds)
-	http.HandleFunc("/get-favorite-worlds", getFavoriteWorlds)
+	routeutils.Get("/get-world-canvas", getWorldCanvas)
+	routeutils.Get("/worlds/{worldId}/canvas", getWorldCanvas)
+	routeutils.Get("/worlds/{worldId}/pixels/{position}", getWorldsPixelInfo)
+	routeutils.Get("/get-world-id", getWorldId)
+	routeutils.Get("/get-world", getWorld)
+	routeutils.Get("/get-worlds", getWorlds)
+	routeutils.Get("/get-home-worlds", getHomeWorlds)
+	routeutils.Get("/get-new-worlds", getNewWorlds)
+	routeutils.Get("/get-favorite-worlds", getFavoriteWorlds)
 	// TODO: Hot/top use user interactivity instead of favorite count
-	http.HandleFunc("/get-top-worlds", getTopWorlds)
-	http.HandleFunc("/get-hot-worlds", getHotWorlds)
-	http.HandleFunc("/get-worlds-last-placed-time", getWorldsLastPlacedTime)
-	http.HandleFunc("/get-worlds-extra-pixels", getWorldsExtraPixels)
-	http.HandleFunc("/get-worlds-colors", getWorldsColors)
-	http.HandleFunc("/get-worlds-pixel-count", getWorldsPixelCount)
-	http.HandleFunc("/get-worlds-pixel-info", getWorldsPixelInfo)
-	http.HandleFunc("/check-world-name", checkWorldName)
-	http.HandleFunc("/leaderboard-pixels", getLeaderboardPixels)
-	http.HandleFunc("/leaderboard-worlds", getLeaderboardWorlds)
-	http.HandleFunc("/leaderboard-pixels-world", getLeaderboardPixelsWorld)
-	http.HandleFunc("/leaderboard-pixels-user", getLeaderboardPixelsUser)
-	http.HandleFunc("/leaderboard-pixels-world-user", getLeaderboardPixelsWorldUser)
+	routeutils.Get("/get-top-worlds", getTopWorlds, routeutils.RateLimit(routeutils.RateLimitHeavyReads))
+	routeutils.Get("/get-hot-worlds", getHotWorlds, routeutils.RateLimit(routeutils.RateLimitHeavyReads))
+	routeutils.Get("/get-worlds-last-placed-time", getWorldsLastPlacedTime)
+	routeutils.Get("/get-worlds-extra-pixels", getWorldsExtraPixels)
+	routeutils.Get("/get-worlds-colors", getWorldsColors)
+	routeutils.Get("/get-worlds-pixel-count", getWorldsPixelCount)
+	routeutils.Get("/get-worlds-pixel-info", getWorldsPixelInfo)
+	routeutils.Get("/check-world-name", checkWorldName)
+	routeutils.Get("/leaderboard-pixels", getLeaderboardPixels, routeutils.RateLimit(routeutils.RateLimitHeavyReads))
+	routeutils.Get("/leaderboard-worlds", getLeaderboardWorlds, routeutils.RateLimit(routeutils.RateLimitHeavyReads))
+	routeutils.Get("/leaderboard-pixels-world", getLeaderboardPixelsWorld, routeutils.RateLimit(routeutils.RateLimitHeavyReads))
+	routeutils.Get("/leaderboard-pixels-user", getLeaderboardPixelsUser, routeutils.RateLimit(routeutils.RateLimitHeavyReads))
+	routeutils.Get("/leaderboard-pixels-world-user", getLeaderboardPixelsWorldUser, routeutils.RateLimit(routeutils.RateLimitHeavyReads))
 	if !core.ArtPeaceBackend.BackendConfig.Production {
-		http.HandleFunc("/create-canvas-devnet", createCanvasDevnet)
-		http.HandleFunc("/favorite-world-devnet", favoriteWorldDevnet)
-		http.HandleFunc("/unfavorite-world-devnet", unfavoriteWorldDevnet)
-		http.HandleFunc("/place-world-pixel-devnet", placeWorldPixelDevnet)
+		routeutils.Post("/create-canvas-devnet", createCanvasDevnet, routeutils.NonProduction)
+		routeutils.Post("/favorite-world-devnet", favoriteWorldDevnet, routeutils.NonProduction)
+		routeutils.Post("/unfavorite-world-devnet", unfavoriteWorldDevnet, routeutils.NonProduction)
+		routeutils.Post("/place-world-pixel-devnet", placeWorldPixelDevnet, routeutils.NonProduction)
 	}
-	http.HandleFunc("/clear-pixels", clearPixelsRedis)
+	routeutils.Post("/clear-pixels", clearPixelsRedis, routeutils.RequirePermission(auth.PermissionCanvas))
 }
 
 func InitWorldsStaticRoutes() {
-	http.Handle("/worlds/", http.StripPrefix("/worlds/", http.FileServer(http.Dir("./worlds"))))
+	routeutils.Handle(http.MethodGet, "/worlds/", http.StripPrefix("/worlds/", http.FileServer(http.Dir("./worlds"))))
 }
 
 func getWorldCanvas(w http.ResponseWriter, r *http.Request) {
@@ -84,6 +87,18 @@ type WorldData struct {
 	EndTime           *time.Time `json:"endTime"`
 	Favorites         int        `json:"favorites"`
 	Favorited         bool       `json:"favorited"`
+	// Score of the hot ranking
+	Hotness int `json:"hotness,omitempty"`
+}
+
+func nextNewestWorld(last WorldData) interface{} {
+	return newestCursor{Id: last.WorldId}
+}
+
+func nextTopWorld(after rankingCursor) func(last WorldData) interface{} {
+	return func(last WorldData) interface{} {
+		return rankingCursor{AsOf: after.AsOf, Score: last.Favorites, Id: last.WorldId}
+	}
 }
 
 func getWorldId(w http.ResponseWriter, r *http.Request) {
@@ -145,18 +160,12 @@ func getWorlds(w http.ResponseWriter, r *http.Request) {
 	if address == "" {
 		address = "0"
 	}
-	pageLength, err := strconv.Atoi(r.URL.Query().Get("pageLength"))
-	if err != nil || pageLength <= 0 {
-		pageLength = 25
-	}
-	if pageLength > 50 {
-		pageLength = 50
-	}
-	page, err := strconv.Atoi(r.URL.Query().Get("page"))
-	if err != nil || page <= 0 {
-		page = 1
+	pagination := routeutils.ParsePagination(r)
+	after := firstNewestCursor()
+	if err := pagination.ParseCursor(&after); err != nil {
+		writeInvalidCursor(w)
+		return
 	}
-	offset := (page - 1) * pageLength
 
 	query := `
         SELECT 
@@ -174,14 +183,15 @@ func getWorlds(w http.ResponseWriter, r *http.Request) {
             GROUP BY 
                 world_id
         ) worldfavorites ON worlds.world_id = worldfavorites.world_id
+        WHERE worlds.world_id < $4
         ORDER BY worlds.world_id DESC
         LIMIT $2 OFFSET $3`
-	worlds, err := core.PostgresQueryJson[WorldData](query, address, pageLength, offset)
+	worlds, err := core.PostgresQueryJson[WorldData](query, address, pagination.Limit(), pagination.Offset(), after.Id)
 	if err != nil {
 		routeutils.WriteErrorJson(w, http.StatusInternalServerError, "Failed to retrieve Worlds")
 		return
 	}
-	routeutils.WriteDataJson(w, string(worlds))
+	routeutils.WritePageJson(w, worlds, pagination, nextNewestWorld)
 }
 
 func getHomeWorlds(w http.ResponseWriter, r *http.Request) {
@@ -214,18 +224,12 @@ func getNewWorlds(w http.ResponseWriter, r *http.Request) {
 	if address == "" {
 		address = "0"
 	}
-	pageLength, err := strconv.Atoi(r.URL.Query().Get("pageLength"))
-	if err != nil || pageLength <= 0 {
-		pageLength = 25
-	}
-	if pageLength > 50 {
-		pageLength = 50
-	}
-	page, err := strconv.Atoi(r.URL.Query().Get("page"))
-	if err != nil || page <= 0 {
-		page = 1
+	pagination := routeutils.ParsePagination(r)
+	after := firstNewestCursor()
+	if err := pagination.ParseCursor(&after); err != nil {
+		writeInvalidCursor(w)
+		return
 	}
-	offset := (page - 1) * pageLength
 
 	query := `
         SELECT 
@@ -243,14 +247,15 @@ func getNewWorlds(w http.ResponseWriter, r *http.Request) {
             GROUP BY 
                 world_id
         ) worldfavorites ON worlds.world_id = worldfavorites.world_id
+        WHERE worlds.world_id < $4
         ORDER BY worlds.world_id DESC
         LIMIT $2 OFFSET $3`
-	worlds, err := core.PostgresQueryJson[WorldData](query, address, pageLength, offset)
+	worlds, err := core.PostgresQueryJson[WorldData](query, address, pagination.Limit(), pagination.Offset(), after.Id)
 	if err != nil {
 		routeutils.WriteErrorJson(w, http.StatusInternalServerError, "Failed to retrieve Worlds")
 		return
 	}
-	routeutils.WriteDataJson(w, string(worlds))
+	routeutils.WritePageJson(w, worlds, pagination, nextNewestWorld)
 }
 
 func getHotWorlds(w http.ResponseWriter, r *http.Request) {
@@ -266,52 +271,58 @@ func getHotWorlds(w http.ResponseWriter, r *http.Request) {
 	if hotLimit > 500 {
 		hotLimit = 500
 	}
-	pageLength, err := strconv.Atoi(r.URL.Query().Get("pageLength"))
-	if err != nil || pageLength <= 0 {
-		pageLength = 25
-	}
-	if pageLength > 50 {
-		pageLength = 50
+	pagination := routeutils.ParsePagination(r)
+	after := firstRankingCursor()
+	if err := pagination.ParseCursor(&after); err != nil {
+		writeInvalidCursor(w)
+		return
 	}
-	page, err := strconv.Atoi(r.URL.Query().Get("page"))
-	if err != nil || page <= 0 {
-		page = 1
+	if err := after.snapshot("worldfavorites"); err != nil {
+		routeutils.WriteErrorJson(w, http.StatusInternalServerError, "Failed to retrieve Hot Worlds")
+		return
 	}
-	offset := (page - 1) * pageLength
 
+	// Hotness counts the last favorites up to the snapshot
 	query := `
-      SELECT
-          worlds.*,
-          COALESCE(worldfavorites.favorite_count, 0) AS favorites,
-          COALESCE((
-              SELECT true FROM worldfavorites
-              WHERE user_address = $1 AND worldfavorites.world_id = worlds.world_id),
-          false) as favorited
-      FROM
-          worlds
-      LEFT JOIN (
-          SELECT
-              world_id,
-              COUNT(*) AS favorite_count FROM worldfavorites GROUP BY world_id
-      ) worldfavorites ON worlds.world_id = worldfavorites.world_id
-      LEFT JOIN (
+      SELECT * FROM (
           SELECT
-              latestfavorites.world_id,
-              COUNT(*) as rank
-          FROM (
-              SELECT * FROM worldfavorites
-              ORDER BY key DESC LIMIT $2
-          ) latestfavorites
-          GROUP BY world_id
-      ) rank ON worlds.world_id = rank.world_id
-      ORDER BY COALESCE(rank, 0) DESC
+              worlds.*,
+              COALESCE(worldfavorites.favorite_count, 0) AS favorites,
+              COALESCE((
+                  SELECT true FROM worldfavorites
+                  WHERE user_address = $1 AND worldfavorites.world_id = worlds.world_id),
+              false) as favorited,
+              COALESCE(rank.hotness, 0) AS hotness
+          FROM
+              worlds
+          LEFT JOIN (
+              SELECT
+                  world_id,
+                  COUNT(*) AS favorite_count FROM worldfavorites GROUP BY world_id
+          ) worldfavorites ON worlds.world_id = worldfavorites.world_id
+          LEFT JOIN (
+              SELECT
+                  latestfavorites.world_id,
+                  COUNT(*) as hotness
+              FROM (
+                  SELECT * FROM worldfavorites
+                  WHERE key <= $5
+                  ORDER BY key DESC LIMIT $2
+              ) latestfavorites
+              GROUP BY world_id
+          ) rank ON worlds.world_id = rank.world_id
+      ) ranked
+      WHERE (hotness, world_id) < ($6, $7)
+      ORDER BY hotness DESC, world_id DESC
       LIMIT $3 OFFSET $4;`
-	worlds, err := core.PostgresQueryJson[WorldData](query, address, hotLimit, pageLength, offset)
+	worlds, err := core.PostgresQueryJson[WorldData](query, address, hotLimit, pagination.Limit(), pagination.Offset(), after.AsOf, after.Score, after.Id)
 	if err != nil {
 		routeutils.WriteErrorJson(w, http.StatusInternalServerError, "Failed to retrieve Hot Worlds")
 		return
 	}
-	routeutils.WriteDataJson(w, string(worlds))
+	routeutils.WritePageJson(w, worlds, pagination, func(last WorldData) interface{} {
+		return rankingCursor{AsOf: after.AsOf, Score: last.Hotness, Id: last.WorldId}
+	})
 }
 
 func getWorldsLastPlacedTime(w http.ResponseWriter, r *http.Request) {
@@ -334,7 +345,7 @@ func getWorldsLastPlacedTime(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Return the last placed time in utc z format
-	routeutils.WriteDataJson(w, "\""+string((*lastTime).UTC().Format(time.RFC3339))+"\"")
+	routeutils.WriteStringDataJson(w, string((*lastTime).UTC().Format(time.RFC3339)))
 }
 
 func getWorldsExtraPixels(w http.ResponseWriter, r *http.Request) {
@@ -422,7 +433,7 @@ func getWorldsPixelInfo(w http.ResponseWriter, r *http.Request) {
 	if queryRes.Name == "" {
 		routeutils.WriteDataJson(w, "\"0x"+queryRes.Address+"\"")
 	} else {
-		routeutils.WriteDataJson(w, "\""+queryRes.Name+"\"")
+		routeutils.WriteStringDataJson(w, queryRes.Name)
 	}
 }
 
@@ -431,44 +442,48 @@ func getTopWorlds(w http.ResponseWriter, r *http.Request) {
 	if address == "" {
 		address = "0"
 	}
-	pageLength, err := strconv.Atoi(r.URL.Query().Get("pageLength"))
-	if err != nil || pageLength <= 0 {
-		pageLength = 25
-	}
-	if pageLength > 50 {
-		pageLength = 50
+	pagination := routeutils.ParsePagination(r)
+	after := firstRankingCursor()
+	if err := pagination.ParseCursor(&after); err != nil {
+		writeInvalidCursor(w)
+		return
 	}
-	page, err := strconv.Atoi(r.URL.Query().Get("page"))
-	if err != nil || page <= 0 {
-		page = 1
+	if err := after.snapshot("worldfavorites"); err != nil {
+		routeutils.WriteErrorJson(w, http.StatusInternalServerError, "Failed to retrieve Worlds")
+		return
 	}
-	offset := (page - 1) * pageLength
 
+	// Favorites after the snapshot are left out of the ranking
 	query := `
-        SELECT 
-            worlds.*, 
-            COALESCE(worldfavorites.favorite_count, 0) AS favorites,
-            COALESCE((SELECT true FROM worldfavorites WHERE user_address = $1 AND worldfavorites.world_id = worlds.world_id), false) as favorited
-        FROM 
-            worlds
-        LEFT JOIN (
-            SELECT 
-                world_id, 
-                COUNT(*) AS favorite_count
-            FROM 
-                worldfavorites
-            GROUP BY 
-                world_id
-        ) worldfavorites ON worlds.world_id = worldfavorites.world_id
+        SELECT * FROM (
+          SELECT 
+              worlds.*, 
+              COALESCE(worldfavorites.favorite_count, 0) AS favorites,
+              COALESCE((SELECT true FROM worldfavorites WHERE user_address = $1 AND worldfavorites.world_id = worlds.world_id), false) as favorited
+          FROM 
+              worlds
+          LEFT JOIN (
+              SELECT 
+                  world_id, 
+                  COUNT(*) AS favorite_count
+              FROM 
+                  worldfavorites
+              WHERE 
+                  key <= $4
+              GROUP BY 
+                  world_id
+          ) worldfavorites ON worlds.world_id = worldfavorites.world_id
+        ) ranked
+        WHERE (favorites, world_id) < ($5, $6)
         ORDER BY 
-            favorites DESC
+            favorites DESC, world_id DESC
         LIMIT $2 OFFSET $3`
-	worlds, err := core.PostgresQueryJson[WorldData](query, address, pageLength, offset)
+	worlds, err := core.PostgresQueryJson[WorldData](query, address, pagination.Limit(), pagination.Offset(), after.AsOf, after.Score, after.Id)
 	if err != nil {
 		routeutils.WriteErrorJson(w, http.StatusInternalServerError, "Failed to retrieve Worlds")
 		return
 	}
-	routeutils.WriteDataJson(w, string(worlds))
+	routeutils.WritePageJson(w, worlds, pagination, nextTopWorld(after))
 }
 
 func getFavoriteWorlds(w http.ResponseWriter, r *http.Request) {
@@ -476,19 +491,18 @@ func getFavoriteWorlds(w http.ResponseWriter, r *http.Request) {
 	if address == "" {
 		address = "0"
 	}
-	pageLength, err := strconv.Atoi(r.URL.Query().Get("pageLength"))
-	if err != nil || pageLength <= 0 {
-		pageLength = 25
-	}
-	if pageLength > 50 {
-		pageLength = 50
+	pagination := routeutils.ParsePagination(r)
+	after := firstRankingCursor()
+	if err := pagination.ParseCursor(&after); err != nil {
+		writeInvalidCursor(w)
+		return
 	}
-	page, err := strconv.Atoi(r.URL.Query().Get("page"))
-	if err != nil || page <= 0 {
-		page = 1
+	if err := after.snapshot("worldfavorites"); err != nil {
+		routeutils.WriteErrorJson(w, http.StatusInternalServerError, "Failed to retrieve Worlds")
+		return
 	}
-	offset := (page - 1) * pageLength
 
+	// Ranked like the top worlds
 	query := `
         SELECT * FROM (
           SELECT 
@@ -503,27 +517,25 @@ func getFavoriteWorlds(w http.ResponseWriter, r *http.Request) {
                   COUNT(*) AS favorite_count
               FROM 
                   worldfavorites
+              WHERE 
+                  key <= $4
               GROUP BY 
                   world_id
           ) worldfavorites ON worlds.world_id = worldfavorites.world_id
         ) w
-        WHERE w.favorited = true
+        WHERE w.favorited = true AND (w.favorites, w.world_id) < ($5, $6)
         ORDER BY 
-            w.favorites DESC
+            w.favorites DESC, w.world_id DESC
         LIMIT $2 OFFSET $3`
-	worlds, err := core.PostgresQueryJson[WorldData](query, address, pageLength, offset)
+	worlds, err := core.PostgresQueryJson[WorldData](query, address, pagination.Limit(), pagination.Offset(), after.AsOf, after.Score, after.Id)
 	if err != nil {
 		routeutils.WriteErrorJson(w, http.StatusInternalServerError, "Failed to retrieve Worlds")
 		return
 	}
-	routeutils.WriteDataJson(w, string(worlds))
+	routeutils.WritePageJson(w, worlds, pagination, nextTopWorld(after))
 }
 
 func createCanvasDevnet(w http.ResponseWriter, r *http.Request) {
-	if routeutils.NonProductionMiddleware(w, r) {
-		return
-	}
-
 	jsonBody, err := routeutils.ReadJsonBody[map[string]string](r)
 	if err != nil {
 		routeutils.WriteErrorJson(w, http.StatusBadRequest, "Failed to read request body")
@@ -614,11 +626,6 @@ func createCanvasDevnet(w http.ResponseWriter, r *http.Request) {
 }
 
 func favoriteWorldDevnet(w http.ResponseWriter, r *http.Request) {
-	// Disable this in production
-	if routeutils.NonProductionMiddleware(w, r) {
-		return
-	}
-
 	jsonBody, err := routeutils.ReadJsonBody[map[string]string](r)
 	if err != nil {
 		routeutils.WriteErrorJson(w, http.StatusBadRequest, "Failed to read request body")
@@ -641,11 +648,6 @@ func favoriteWorldDevnet(w http.ResponseWriter, r *http.Request) {
 }
 
 func unfavoriteWorldDevnet(w http.ResponseWriter, r *http.Request) {
-	// Disable this in production
-	if routeutils.NonProductionMiddleware(w, r) {
-		return
-	}
-
 	jsonBody, err := routeutils.ReadJsonBody[map[string]string](r)
 	if err != nil {
 		routeutils.WriteErrorJson(w, http.StatusBadRequest, "Failed to read request body")
@@ -668,11 +670,6 @@ func unfavoriteWorldDevnet(w http.ResponseWriter, r *http.Request) {
 }
 
 func placeWorldPixelDevnet(w http.ResponseWriter, r *http.Request) {
-	// Disable this in production
-	if routeutils.NonProductionMiddleware(w, r) {
-		return
-	}
-
 	jsonBody, err := routeutils.ReadJsonBody[map[string]string](r)
 	if err != nil {
 		routeutils.WriteErrorJson(w, http.StatusBadRequest, "Invalid JSON request body")
@@ -753,18 +750,12 @@ type LeaderboardEntry struct {
 
 // Get the leaderboard for total pixels placed by user
 func getLeaderboardPixels(w http.ResponseWriter, r *http.Request) {
-	pageLength, err := strconv.Atoi(r.URL.Query().Get("pageLength"))
-	if err != nil || pageLength <= 0 {
-		pageLength = 25
-	}
-	if pageLength > 50 {
-		pageLength = 50
-	}
-	page, err := strconv.Atoi(r.URL.Query().Get("page"))
-	if err != nil || page <= 0 {
-		page = 1
+	pagination := routeutils.ParsePagination(r)
+	after := firstLeaderboardCursor()
+	if err := pagination.ParseCursor(&after); err != nil {
+		writeInvalidCursor(w)
+		return
 	}
-	offset := (page - 1) * pageLength
 
 	minSupportedWorld := r.URL.Query().Get("minSupportedWorld")
 	if minSupportedWorld == "" {
@@ -786,41 +777,44 @@ func getLeaderboardPixels(w http.ResponseWriter, r *http.Request) {
     return
   }
 
+	if err := after.snapshot(); err != nil {
+		routeutils.WriteErrorJson(w, http.StatusInternalServerError, "Failed to retrieve leaderboard")
+		return
+	}
+
 	query := `
-    SELECT
-      address AS key,
-      COUNT(*) AS score
-    FROM
-      worldspixels
+    SELECT * FROM (
+      SELECT
+        address AS key,
+        COUNT(*) AS score
+      FROM
+        worldspixels
+      WHERE
+        world_id >= $1 and time > TO_TIMESTAMP($4) and time <= $5
+      GROUP BY
+        address
+    ) leaderboard
     WHERE
-      world_id >= $1 and time > TO_TIMESTAMP($4)
-    GROUP BY
-      address
+      score < $6 OR (score = $6 AND key > $7)
     ORDER BY
-      score DESC
+      score DESC, key ASC
     LIMIT $2 OFFSET $3`
-	leaderboard, err := core.PostgresQueryJson[LeaderboardEntry](query, minSupportedWorldInt, pageLength, offset, timeCutoff)
+	leaderboard, err := core.PostgresQueryJson[LeaderboardEntry](query, minSupportedWorldInt, pagination.Limit(), pagination.Offset(), timeCutoff, after.AsOf, after.Score, after.Key)
 	if err != nil {
 		routeutils.WriteErrorJson(w, http.StatusInternalServerError, "Failed to retrieve leaderboard")
 		return
 	}
-	routeutils.WriteDataJson(w, string(leaderboard))
+	routeutils.WritePageJson(w, leaderboard, pagination, after.next)
 }
 
 // Get the leaderboard for total pixels on each world
 func getLeaderboardWorlds(w http.ResponseWriter, r *http.Request) {
-	pageLength, err := strconv.Atoi(r.URL.Query().Get("pageLength"))
-	if err != nil || pageLength <= 0 {
-		pageLength = 25
-	}
-	if pageLength > 50 {
-		pageLength = 50
-	}
-	page, err := strconv.Atoi(r.URL.Query().Get("page"))
-	if err != nil || page <= 0 {
-		page = 1
+	pagination := routeutils.ParsePagination(r)
+	after := firstLeaderboardCursor()
+	if err := pagination.ParseCursor(&after); err != nil {
+		writeInvalidCursor(w)
+		return
 	}
-	offset := (page - 1) * pageLength
 
 	minSupportedWorld := r.URL.Query().Get("minSupportedWorld")
 	if minSupportedWorld == "" {
@@ -842,29 +836,38 @@ func getLeaderboardWorlds(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if err := after.snapshot(); err != nil {
+		routeutils.WriteErrorJson(w, http.StatusInternalServerError, "Failed to retrieve leaderboard")
+		return
+	}
+
 	query := `
-    SELECT
-      w.name AS key,
-      COUNT(*) AS score
-    FROM
-      worldspixels p
-    JOIN
-      worlds w
-    ON
-      p.world_id = w.world_id
+    SELECT * FROM (
+      SELECT
+        w.name AS key,
+        COUNT(*) AS score
+      FROM
+        worldspixels p
+      JOIN
+        worlds w
+      ON
+        p.world_id = w.world_id
+      WHERE
+        w.world_id >= $1 and p.time > TO_TIMESTAMP($4) and p.time <= $5
+      GROUP BY
+        w.name
+    ) leaderboard
     WHERE
-      w.world_id >= $1 and p.time > TO_TIMESTAMP($4)
-    GROUP BY
-      w.name
+      score < $6 OR (score = $6 AND key > $7)
     ORDER BY
-      score DESC
+      score DESC, key ASC
     LIMIT $2 OFFSET $3`
-	leaderboard, err := core.PostgresQueryJson[LeaderboardEntry](query, minSupportedWorldInt, pageLength, offset, timeCutoff)
+	leaderboard, err := core.PostgresQueryJson[LeaderboardEntry](query, minSupportedWorldInt, pagination.Limit(), pagination.Offset(), timeCutoff, after.AsOf, after.Score, after.Key)
 	if err != nil {
 		routeutils.WriteErrorJson(w, http.StatusInternalServerError, "Failed to retrieve leaderboard")
 		return
 	}
-	routeutils.WriteDataJson(w, string(leaderboard))
+	routeutils.WritePageJson(w, leaderboard, pagination, after.next)
 }
 
 // Get the leaderboard for total pixels placed on specific world
@@ -875,18 +878,12 @@ func getLeaderboardPixelsWorld(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	pageLength, err := strconv.Atoi(r.URL.Query().Get("pageLength"))
-	if err != nil || pageLength <= 0 {
-		pageLength = 25
-	}
-	if pageLength > 50 {
-		pageLength = 50
-	}
-	page, err := strconv.Atoi(r.URL.Query().Get("page"))
-	if err != nil || page <= 0 {
-		page = 1
+	pagination := routeutils.ParsePagination(r)
+	after := firstLeaderboardCursor()
+	if err := pagination.ParseCursor(&after); err != nil {
+		writeInvalidCursor(w)
+		return
 	}
-	offset := (page - 1) * pageLength
 
 	timeCutoffStr := r.URL.Query().Get("timeCutoff")
 	if timeCutoffStr == "" {
@@ -898,25 +895,34 @@ func getLeaderboardPixelsWorld(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if err := after.snapshot(); err != nil {
+		routeutils.WriteErrorJson(w, http.StatusInternalServerError, "Failed to retrieve leaderboard")
+		return
+	}
+
 	query := `
-    SELECT
-      address AS key,
-      COUNT(*) AS score
-    FROM
-      worldspixels
+    SELECT * FROM (
+      SELECT
+        address AS key,
+        COUNT(*) AS score
+      FROM
+        worldspixels
+      WHERE
+        world_id = $1 and time > TO_TIMESTAMP($4) and time <= $5
+      GROUP BY
+        address
+    ) leaderboard
     WHERE
-      world_id = $1 and time > TO_TIMESTAMP($4)
-    GROUP BY
-      address
+      score < $6 OR (score = $6 AND key > $7)
     ORDER BY
-      score DESC
+      score DESC, key ASC
     LIMIT $2 OFFSET $3`
-	leaderboard, err := core.PostgresQueryJson[LeaderboardEntry](query, worldId, pageLength, offset, timeCutoff)
+	leaderboard, err := core.PostgresQueryJson[LeaderboardEntry](query, worldId, pagination.Limit(), pagination.Offset(), timeCutoff, after.AsOf, after.Score, after.Key)
 	if err != nil {
 		routeutils.WriteErrorJson(w, http.StatusInternalServerError, "Failed to retrieve leaderboard")
 		return
 	}
-	routeutils.WriteDataJson(w, string(leaderboard))
+	routeutils.WritePageJson(w, leaderboard, pagination, after.next)
 }
 
 // Get the leaderboard for total pixels placed by specific user
@@ -1024,11 +1030,6 @@ type ClearPixelsRequest struct {
 }
 
 func clearPixelsRedis(w http.ResponseWriter, r *http.Request) {
-	// Only allow admin to place pixels on redis
-	if routeutils.AdminMiddleware(w, r) {
-		return
-	}
-
 	jsonBody, err := routeutils.ReadJsonBody[ClearPixelsRequest](r)
 	if err != nil {
 		routeutils.WriteErrorJson(w, http.StatusBadRequest, "Invalid JSON request body")
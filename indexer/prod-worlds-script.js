@@ -4,6 +4,8 @@ export const config = {
   network: "starknet",
   finality: "DATA_STATUS_PENDING",
   filter: {
+    // Block timestamps, protected regions are checked at the time of the placement
+    header: { weak: true },
     events: [
       {
         // Canvas Created Event
@@ -225,7 +225,8 @@ CREATE TABLE NFTs (
   block_number integer NOT NULL,
   day_index integer NOT NULL,
   minter char(64) NOT NULL,
-  owner char(64) NOT NULL
+  owner char(64) NOT NULL,
+  minted_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP
 );
 
 CREATE TABLE NFTLikes (
@@ -408,3 +409,111 @@ CREATE TABLE CanvasClears (
 );
 CREATE INDEX canvasClears_time_index ON CanvasClears (time);
 CREATE INDEX canvasClears_world_id_index ON CanvasClears (world_id);
+
+-- Admin / host defined rectangles where placements are flagged or restored
+CREATE TABLE ProtectedRegions (
+  key int PRIMARY KEY GENERATED ALWAYS AS IDENTITY,
+  world_id integer NOT NULL,
+  name text NOT NULL,
+  x_start integer NOT NULL,
+  x_end integer NOT NULL,
+  y_start integer NOT NULL,
+  y_end integer NOT NULL,
+  start_time timestamp NOT NULL,
+  end_time timestamp NOT NULL,
+  -- 'flag' or 'restore'
+  mode text NOT NULL,
+  created_by char(64) NOT NULL,
+  created_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX protectedRegions_world_id_index ON ProtectedRegions (world_id);
+CREATE INDEX protectedRegions_end_time_index ON ProtectedRegions (end_time);
+
+CREATE TABLE ProtectedRegionViolations (
+  key int PRIMARY KEY GENERATED ALWAYS AS IDENTITY,
+  region_key integer NOT NULL,
+  world_id integer NOT NULL,
+  address char(64) NOT NULL,
+  position integer NOT NULL,
+  color integer NOT NULL,
+  restored boolean NOT NULL,
+  restored_color integer,
+  time timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX protectedRegionViolations_region_key_index ON ProtectedRegionViolations (region_key);
+CREATE INDEX protectedRegionViolations_world_id_index ON ProtectedRegionViolations (world_id);
+CREATE INDEX protectedRegionViolations_address_index ON ProtectedRegionViolations (address);
+
+-- Audit log of admin rollbacks of an address's world pixels
+CREATE TABLE PixelRollbacks (
+  key int PRIMARY KEY GENERATED ALWAYS AS IDENTITY,
+  world_id integer NOT NULL,
+  addresses text[] NOT NULL,
+  from_time timestamp,
+  to_time timestamp,
+  placements_removed integer NOT NULL,
+  positions_restored integer NOT NULL,
+  time timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX pixelRollbacks_world_id_index ON PixelRollbacks (world_id);
+
+-- Placements removed from WorldsPixels by a rollback
+CREATE TABLE PixelRollbackPlacements (
+  rollback_key integer NOT NULL,
+  address char(64) NOT NULL,
+  position integer NOT NULL,
+  color integer NOT NULL,
+  time timestamp NOT NULL
+);
+CREATE INDEX pixelRollbackPlacements_rollback_key_index ON PixelRollbackPlacements (rollback_key);
+
+-- Per-world chat, world 0 is the main canvas
+CREATE TABLE ChatMessages (
+  key int PRIMARY KEY GENERATED ALWAYS AS IDENTITY,
+  world_id integer NOT NULL,
+  address char(64) NOT NULL,
+  message text NOT NULL,
+  deleted boolean NOT NULL DEFAULT false,
+  deleted_by char(64),
+  time timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX chatMessages_world_id_index ON ChatMessages (world_id, key);
+
+-- Addresses muted in a world's chat, until NULL is a permanent mute
+CREATE TABLE ChatMutes (
+  world_id integer NOT NULL,
+  address char(64) NOT NULL,
+  muted_by char(64) NOT NULL,
+  until timestamp,
+  time timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (world_id, address)
+);
+
+-- Admin API keys, only the sha256 hash of a key is stored
+CREATE TABLE AdminKeys (
+  key int PRIMARY KEY GENERATED ALWAYS AS IDENTITY,
+  name text NOT NULL,
+  -- First characters of the key, to recognize it
+  prefix text NOT NULL,
+  key_hash char(64) NOT NULL UNIQUE,
+  -- super-admin, moderator or world-host
+  role text NOT NULL,
+  -- World managed by a world-host key
+  world_id integer,
+  created_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  revoked_at timestamp
+);
+
+-- Requests to admin routes, admin_key is NULL for requests without a valid key
+CREATE TABLE AdminAuditLog (
+  key int PRIMARY KEY GENERATED ALWAYS AS IDENTITY,
+  admin_key integer,
+  role text,
+  method text NOT NULL,
+  path text NOT NULL,
+  query text NOT NULL,
+  status integer NOT NULL,
+  ip text NOT NULL,
+  time timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX adminAuditLog_admin_key_index ON AdminAuditLog (admin_key);